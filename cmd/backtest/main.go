@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/marksartdev/trading/internal/app"
+	"github.com/marksartdev/trading/internal/backtest"
+	"github.com/marksartdev/trading/internal/log"
+	"github.com/marksartdev/trading/internal/strategy"
+)
+
+func main() {
+	logger, cfg := app.Init()
+
+	s, err := strategy.New(cfg.Strategy)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	engineLogger := log.NewLogger(logger, "Backtest", log.Yellow())
+	engine := backtest.NewEngine(engineLogger, cfg.Exchange, cfg.Backtest, s)
+
+	report, err := engine.Run()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	if err := report.Write(cfg.Backtest.Output); err != nil {
+		logger.Fatal(err)
+	}
+}
@@ -31,7 +31,11 @@ func main() {
 	clientRepo := repository.NewClientRepo(db)
 	dealRepo := repository.NewDealRepo(db)
 	posRepo := repository.NewPositionRepo(db)
+	holdRepo := repository.NewHoldRepo(db)
+	settleRepo := repository.NewSettlementRepo(db)
+	ledgerRepo := repository.NewLedgerRepo(db)
 	statRepo := repository.NewStatisticRepo(db)
+	tradeRepo := repository.NewTradeRepo(db)
 
 	conn, err := grpc.Dial(":8000", grpc.WithInsecure())
 	if err != nil {
@@ -45,13 +49,25 @@ func main() {
 	}(conn)
 
 	exchangeClient := exchangeRpc.NewExchangeClient(conn)
-	exchangeService := brokerRpc.NewExchangeService(1, exchangeClient)
+	exchangeService := brokerRpc.NewExchangeService(1, exchangeClient, cfg.Broker.Statistic)
 	serviceLogger := log.NewLogger(logger, "Broker", log.Blue())
 
-	service := services.NewBrokerService(serviceLogger, clientRepo, dealRepo, posRepo, statRepo, exchangeService)
+	service := services.NewBrokerService(
+		serviceLogger,
+		clientRepo,
+		dealRepo,
+		posRepo,
+		holdRepo,
+		settleRepo,
+		ledgerRepo,
+		statRepo,
+		tradeRepo,
+		exchangeService,
+		cfg.Broker,
+	)
 
 	srvLogger := log.NewLogger(logger, "Server", log.Green())
-	grpcServer := brokerRpc.NewBrokerServer(srvLogger, service)
+	grpcServer := brokerRpc.NewBrokerServer(srvLogger, service, cfg.Broker.AdminToken)
 
 	lis, err := net.Listen("tcp", ":8001")
 	if err != nil {
@@ -6,11 +6,14 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 
 	"github.com/marksartdev/trading/internal/app"
+	"github.com/marksartdev/trading/internal/clock"
 	"github.com/marksartdev/trading/internal/exchange/delivery/rpc"
+	"github.com/marksartdev/trading/internal/exchange/repository/file"
 	"github.com/marksartdev/trading/internal/exchange/repository/memory"
 	"github.com/marksartdev/trading/internal/exchange/services"
 	"github.com/marksartdev/trading/internal/log"
@@ -21,15 +24,46 @@ const http log.Action = "http"
 func main() {
 	logger, cfg := app.Init()
 
-	dealQueue := memory.NewDealQueue()
+	clk := clock.Real()
+	if cfg.Exchange.Replay.Speed > 0 {
+		start := cfg.Exchange.Replay.Start
+		if start.IsZero() {
+			start = time.Now()
+		}
+
+		clk = clock.NewScaled(start, cfg.Exchange.Replay.Speed)
+	}
+
+	dealQueue := memory.NewOrderBook()
 	tickLogger := log.NewLogger(logger, "Ticker", log.Blue())
-	ticks := services.NewTickService(tickLogger)
+	ticks := services.NewTickService(tickLogger, clk, cfg.Exchange)
+
+	var journal services.Journal
+
+	if cfg.Exchange.Journal.Dir != "" {
+		fileJournal, err := file.NewJournal(cfg.Exchange.Journal.Dir, cfg.Exchange.Journal.Sync)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		defer func(journal file.Journal) {
+			err := journal.Close()
+			if err != nil {
+				logger.Error(err)
+			}
+		}(fileJournal)
+
+		journal = fileJournal
+	}
 
 	exchangeLogger := log.NewLogger(logger, "Exchanger", log.Purple())
-	service := services.NewExchangeService(exchangeLogger, dealQueue, ticks, cfg.Exchange.Tickers, cfg.Exchange.Interval)
+	service := services.NewExchangeService(exchangeLogger, clk, dealQueue, ticks, journal, cfg.Exchange)
+
+	if err := service.Recover(); err != nil {
+		logger.Fatal(err)
+	}
 
 	srvLogger := log.NewLogger(logger, "Server", log.Green())
-	grpcServer := rpc.NewExchangeServer(srvLogger, service)
+	grpcServer := rpc.NewExchangeServer(srvLogger, service, cfg.Exchange.AdminToken)
 
 	lis, err := net.Listen("tcp", ":8000")
 	if err != nil {
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/marksartdev/trading/internal/app"
+	brokerRpc "github.com/marksartdev/trading/internal/broker/delivery/rpc"
+	"github.com/marksartdev/trading/internal/log"
+	"github.com/marksartdev/trading/internal/strategy"
+)
+
+func main() {
+	logger, cfg := app.Init()
+
+	s, err := strategy.New(cfg.Strategy)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	conn, err := grpc.Dial(":8001", grpc.WithInsecure())
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer func(conn *grpc.ClientConn) {
+		err := conn.Close()
+		if err != nil {
+			logger.Error(err)
+		}
+	}(conn)
+
+	runnerLogger := log.NewLogger(logger, "Strategy", log.Yellow())
+	runner := strategy.NewRunner(runnerLogger, brokerRpc.NewBrokerClient(conn), s, cfg.Strategy)
+
+	go func() {
+		done := make(chan os.Signal, 1)
+		signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
+
+		<-done
+		fmt.Println()
+		runner.Stop()
+	}()
+
+	runner.Start()
+}
@@ -0,0 +1,401 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+	brokerMemory "github.com/marksartdev/trading/internal/broker/repository/memory"
+	brokerServices "github.com/marksartdev/trading/internal/broker/services"
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	exchangeMemory "github.com/marksartdev/trading/internal/exchange/repository/memory"
+	exchangeServices "github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+	"github.com/marksartdev/trading/internal/strategy"
+)
+
+const (
+	brokerID = 1
+	login    = "backtest"
+)
+
+const (
+	mainAction  log.Action = "main"
+	orderAction log.Action = "order"
+)
+
+// Default backtesting settings.
+const (
+	defaultDuration = 24 * time.Hour
+	defaultInterval = time.Minute
+	defaultCash     = 100000000
+)
+
+// Head of a ticker source.
+type head struct {
+	reader exchangeServices.TickReader
+	tick   exchange.Tick
+	time   time.Time
+	ok     bool
+}
+
+// Engine deterministic backtesting engine.
+// It drives exchange and broker services in-process by historical ticks on a virtual clock.
+// Ticks of all tickers are merged by time, ties are broken by order of tickers in config.
+type Engine struct {
+	logger   log.Logger
+	exchange config.Exchange
+	cfg      config.Backtest
+	strategy strategy.Strategy
+
+	clock      clock.VirtualClock
+	service    exchange.ExchangeService
+	broker     broker.BrokerService
+	clientRepo broker.ClientRepo
+	posRepo    broker.PositionRepo
+	statRepo   broker.StatisticRepo
+	clientID   int64
+	bars       map[string]*broker.OHLCV
+	report     Report
+}
+
+// NewEngine creates new backtesting engine.
+func NewEngine(logger log.Logger, exchangeCfg config.Exchange, cfg config.Backtest, s strategy.Strategy) *Engine {
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaultDuration
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+
+	if cfg.Cash <= 0 {
+		cfg.Cash = defaultCash
+	}
+
+	// Strategy is the only participant, so its deals are completed by ticks only.
+	exchangeCfg.Matching = ""
+	exchangeCfg.Session = config.Session{}
+
+	return &Engine{logger: logger, exchange: exchangeCfg, cfg: cfg, strategy: s}
+}
+
+// Run replays ticks through the strategy and returns report.
+func (e *Engine) Run() (Report, error) {
+	if err := e.setup(); err != nil {
+		return Report{}, err
+	}
+
+	heads := make([]*head, len(e.exchange.Tickers))
+	for i, ticker := range e.exchange.Tickers {
+		reader, err := exchangeServices.OpenTickReader(ticker, e.exchange, e.cfg.Start)
+		if err != nil {
+			return Report{}, err
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+
+		heads[i] = &head{reader: reader}
+		e.advance(heads[i])
+	}
+
+	e.logger.Info(mainAction, fmt.Sprintf("started %s from %s", e.strategy.Name(), e.cfg.Start.Format(time.RFC3339)))
+
+	end := e.cfg.Start.Add(e.cfg.Duration)
+
+	for {
+		h := earliest(heads)
+		if h == nil || h.time.After(end) {
+			break
+		}
+
+		e.clock.Set(h.time)
+		e.onTick(h.tick)
+		e.advance(h)
+	}
+
+	for _, ticker := range e.exchange.Tickers {
+		if bar, ok := e.bars[ticker]; ok {
+			e.closeBar(*bar)
+		}
+	}
+
+	e.report.End = e.clock.Now()
+	e.finish()
+
+	e.logger.Info(mainAction, fmt.Sprintf("finished with %d fills and PnL %s", len(e.report.Fills), e.report.PnL))
+
+	return e.report, nil
+}
+
+// Creates services, repositories and client of the strategy.
+func (e *Engine) setup() error {
+	e.clock = clock.NewVirtual(e.cfg.Start)
+	e.service = exchangeServices.NewExchangeService(
+		e.logger, e.clock, exchangeMemory.NewOrderBook(), nil, nil, e.exchange,
+	)
+
+	e.clientRepo = brokerMemory.NewClientRepo()
+	e.posRepo = brokerMemory.NewPositionRepo()
+	e.statRepo = brokerMemory.NewStatisticRepo()
+
+	dealRepo := brokerMemory.NewDealRepo()
+	holdRepo := brokerMemory.NewHoldRepo()
+	ledgerRepo := brokerMemory.NewLedgerRepo(e.clientRepo)
+
+	e.broker = brokerServices.NewBrokerService(
+		e.logger,
+		e.clientRepo,
+		dealRepo,
+		e.posRepo,
+		holdRepo,
+		brokerMemory.NewSettlementRepo(dealRepo, e.posRepo, holdRepo, ledgerRepo),
+		ledgerRepo,
+		e.statRepo,
+		brokerMemory.NewTradeRepo(),
+		newLocalExchange(brokerID, e.service),
+		config.Broker{},
+	)
+
+	client := broker.Client{Login: login}
+	if err := e.clientRepo.Add(&client); err != nil {
+		return err
+	}
+
+	funding := broker.Entry{
+		ClientID: client.ID,
+		Type:     broker.EntryFunding,
+		Debit:    broker.AccountCash,
+		Credit:   broker.AccountEquity,
+		Amount:   e.cfg.Cash,
+		Time:     e.cfg.Start,
+	}
+
+	if err := ledgerRepo.Post(&funding); err != nil {
+		return err
+	}
+
+	e.clientID = client.ID
+	e.bars = make(map[string]*broker.OHLCV)
+	e.report = Report{
+		Strategy:    e.strategy.Name(),
+		Start:       e.cfg.Start,
+		InitialCash: e.cfg.Cash,
+		Tickers:     make(map[string]TickerStats),
+		peak:        e.cfg.Cash,
+	}
+
+	return nil
+}
+
+// Moves source to its next tick. Ticks before start are skipped.
+func (e *Engine) advance(h *head) {
+	for {
+		tick, tm, err := h.reader.Read()
+		if err != nil {
+			h.ok = false
+			return
+		}
+
+		if tm.Before(e.cfg.Start) {
+			continue
+		}
+
+		h.tick, h.time, h.ok = tick, tm, true
+
+		return
+	}
+}
+
+// Returns source with the earliest tick.
+func earliest(heads []*head) *head {
+	var res *head
+
+	for _, h := range heads {
+		if h.ok && (res == nil || h.time.Before(res.time)) {
+			res = h
+		}
+	}
+
+	return res
+}
+
+// Handles tick: closes previous bar of the ticker, completes deals and updates current bar.
+func (e *Engine) onTick(tick exchange.Tick) {
+	start := e.clock.Now().Truncate(e.cfg.Interval)
+
+	bar, ok := e.bars[tick.Ticker]
+	if ok && !bar.Time.Equal(start) {
+		e.closeBar(*bar)
+		ok = false
+	}
+
+	for _, res := range e.service.Process(tick) {
+		e.onResult(result(res))
+	}
+
+	stats := e.report.Tickers[tick.Ticker]
+	stats.LastPrice = tick.Price
+	e.report.Tickers[tick.Ticker] = stats
+
+	if !ok {
+		bar = &broker.OHLCV{
+			Ticker:   tick.Ticker,
+			Time:     start,
+			Interval: e.cfg.Interval,
+			Open:     tick.Price,
+			High:     tick.Price,
+			Low:      tick.Price,
+		}
+		e.bars[tick.Ticker] = bar
+	}
+
+	if tick.Price > bar.High {
+		bar.High = tick.Price
+	}
+
+	if tick.Price < bar.Low {
+		bar.Low = tick.Price
+	}
+
+	bar.Close = tick.Price
+	bar.Volume += tick.Vol
+}
+
+// Settles result of a deal and passes it to the strategy.
+func (e *Engine) onResult(deal broker.Deal) {
+	if err := e.broker.Settle(deal); err != nil {
+		e.logger.Error(orderAction, err)
+		return
+	}
+
+	e.report.addFill(deal)
+
+	fill := strategy.Fill{
+		DealID:  deal.ID,
+		Ticker:  deal.Ticker,
+		Side:    strategy.Side(deal.Type),
+		Amount:  deal.Amount,
+		Price:   deal.Price,
+		Partial: deal.Partial,
+		Time:    deal.Time,
+	}
+
+	if deal.Status == broker.DealStatusExpired {
+		fill.Amount, fill.Price = 0, decimal.Zero
+	}
+
+	e.submit(e.strategy.OnFill(fill))
+}
+
+// Passes closed bar to the strategy and adds point of PnL curve.
+func (e *Engine) closeBar(bar broker.OHLCV) {
+	delete(e.bars, bar.Ticker)
+
+	// Broker values market deals of the strategy by the last bars.
+	if err := e.statRepo.Add(bar); err != nil {
+		e.logger.Error(mainAction, err)
+	}
+
+	e.submit(e.strategy.OnBar(strategy.Bar{
+		Ticker:   bar.Ticker,
+		Time:     bar.Time,
+		Interval: bar.Interval,
+		Open:     bar.Open,
+		High:     bar.High,
+		Low:      bar.Low,
+		Close:    bar.Close,
+		Volume:   bar.Volume,
+	}))
+
+	cash, equity := e.equity()
+	e.report.addPoint(bar.Time.Add(bar.Interval), cash, equity)
+}
+
+// Creates deals of the strategy. Rejected orders are reported to strategy and
+// orders placed in response are retried for at most strategy.MaxRetries rounds.
+func (e *Engine) submit(orders []strategy.Order) {
+	for round := 0; len(orders) > 0; round++ {
+		if round > strategy.MaxRetries {
+			e.logger.Warn(orderAction, fmt.Sprintf("%d orders dropped after %d retries", len(orders), strategy.MaxRetries))
+			return
+		}
+
+		var retry []strategy.Order
+
+		for _, order := range orders {
+			if !e.create(order) {
+				retry = append(retry, e.strategy.OnFill(strategy.Fill{Ticker: order.Ticker, Side: order.Side, Time: e.clock.Now()})...)
+			}
+		}
+
+		orders = retry
+	}
+}
+
+// Creates deal of the order. Returns false if broker rejects it.
+func (e *Engine) create(order strategy.Order) bool {
+	deal := broker.Deal{
+		ClientID:    e.clientID,
+		Ticker:      order.Ticker,
+		Type:        broker.DealType(order.Side),
+		OrderType:   broker.OrderType(order.Type),
+		TimeInForce: broker.GTC,
+		Amount:      order.Amount,
+		Price:       order.Price,
+		StopPrice:   order.StopPrice,
+		Time:        e.clock.Now(),
+	}
+
+	if deal.OrderType == "" {
+		deal.OrderType = broker.Limit
+	}
+
+	if _, err := e.broker.Create(deal); err != nil {
+		e.report.Rejected++
+		e.logger.Error(orderAction, err)
+
+		return false
+	}
+
+	return true
+}
+
+// Returns cash and equity of the strategy marked to last prices.
+func (e *Engine) equity() (decimal.Decimal, decimal.Decimal) {
+	client, _, err := e.clientRepo.Get(login)
+	if err != nil {
+		e.logger.Error(mainAction, err)
+	}
+
+	positions, err := e.posRepo.Get(e.clientID)
+	if err != nil {
+		e.logger.Error(mainAction, err)
+	}
+
+	cash := client.Balance
+
+	equity := cash
+	for _, position := range positions {
+		equity += e.report.Tickers[position.Ticker].LastPrice.Mul(position.Amount)
+	}
+
+	return cash, equity
+}
+
+// Fills final values of report.
+func (e *Engine) finish() {
+	_, equity := e.equity()
+
+	e.report.FinalEquity = equity
+	e.report.PnL = equity - e.report.InitialCash
+
+	for ticker, stats := range e.report.Tickers {
+		stats.UnrealizedPnL = (stats.LastPrice - stats.AvgCost).Mul(stats.Position)
+		e.report.Tickers[ticker] = stats
+	}
+}
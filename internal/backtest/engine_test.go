@@ -0,0 +1,48 @@
+package backtest_test
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/backtest"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/log"
+	"github.com/marksartdev/trading/internal/strategy"
+)
+
+// Strategy which buys more than it can afford on every bar and again on every rejection.
+type greedy struct{}
+
+func (greedy) Name() string { return "greedy" }
+
+func (greedy) OnBar(bar strategy.Bar) []strategy.Order {
+	return []strategy.Order{{Ticker: bar.Ticker, Side: strategy.Buy, Type: strategy.Market, Amount: 1 << 30}}
+}
+
+func (greedy) OnFill(fill strategy.Fill) []strategy.Order {
+	return []strategy.Order{{Ticker: fill.Ticker, Side: strategy.Buy, Type: strategy.Market, Amount: 1 << 30}}
+}
+
+func TestRejectedOrdersAreRetriedBoundedly(t *testing.T) {
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	exchangeCfg := config.Exchange{
+		Tickers: []string{"A"},
+		Sources: map[string]config.TickSource{"A": {Type: "gbm", Seed: 1, Step: time.Second}},
+	}
+	cfg := config.Backtest{Start: time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC), Duration: 5 * time.Minute}
+
+	report, err := backtest.NewEngine(logger, exchangeCfg, cfg, greedy{}).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Rejected == 0 || report.Rejected%(strategy.MaxRetries+1) != 0 {
+		t.Errorf("%d orders rejected, want a multiple of %d", report.Rejected, strategy.MaxRetries+1)
+	}
+
+	if len(report.Fills) != 0 {
+		t.Errorf("%d fills of unaffordable orders", len(report.Fills))
+	}
+}
@@ -0,0 +1,119 @@
+package backtest
+
+import (
+	"context"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// In-process client of exchange service.
+type localExchange struct {
+	brokerID int64
+	service  exchange.ExchangeService
+}
+
+// Creates new in-process client of exchange service.
+func newLocalExchange(brokerID int64, service exchange.ExchangeService) broker.ExchangeService {
+	return localExchange{brokerID: brokerID, service: service}
+}
+
+// Statistic is not streamed in backtesting, bars are built by the engine.
+func (l localExchange) Statistic(ctx context.Context, _ chan broker.OHLCV, connected func()) error {
+	connected()
+	<-ctx.Done()
+	return nil
+}
+
+// Create sends deal to exchange service.
+func (l localExchange) Create(deal broker.Deal) (int64, error) {
+	d, err := l.service.Create(exchange.Deal{
+		BrokerID:    l.brokerID,
+		ClientID:    deal.ClientID,
+		Ticker:      deal.Ticker,
+		Side:        exchange.Side(deal.Type),
+		Type:        exchange.OrderType(deal.OrderType),
+		TimeInForce: exchange.TimeInForce(deal.TimeInForce),
+		Amount:      deal.Amount,
+		Time:        deal.Time,
+		Price:       deal.Price,
+		StopPrice:   deal.StopPrice,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return d.ID, nil
+}
+
+// Cancel sends deal cancel to exchange service.
+func (l localExchange) Cancel(dealID int64) (bool, error) {
+	return l.service.Cancel(l.brokerID, dealID)
+}
+
+// Modify sends deal modification to exchange service.
+func (l localExchange) Modify(dealID int64, price decimal.Decimal, amount int32) (bool, error) {
+	return l.service.Modify(l.brokerID, dealID, price, amount)
+}
+
+// ListOpen returns deals of the broker resting at exchange.
+func (l localExchange) ListOpen() ([]broker.Deal, error) {
+	var deals []broker.Deal
+
+	for _, deal := range l.service.ListOpen(l.brokerID) {
+		d := result(deal)
+		d.Status = broker.DealStatusNew
+		deals = append(deals, d)
+	}
+
+	return deals, nil
+}
+
+// Results are not streamed in backtesting, the engine settles them itself.
+func (l localExchange) Results(ctx context.Context, _ int64, _ chan broker.Deal, connected func()) error {
+	connected()
+	<-ctx.Done()
+	return nil
+}
+
+// Depth is not streamed in backtesting.
+func (l localExchange) Depth(_ context.Context, _ string, _ int32, out chan broker.Depth) error {
+	close(out)
+	return nil
+}
+
+// Trades are not streamed in backtesting.
+func (l localExchange) Trades(ctx context.Context, _ chan broker.Trade, connected func()) error {
+	connected()
+	<-ctx.Done()
+	return nil
+}
+
+// Converts result of exchange to broker deal.
+func result(deal exchange.Deal) broker.Deal {
+	status := broker.DealStatusCompleted
+	if deal.Partial {
+		status = broker.DealStatusNew
+	}
+
+	if deal.Status == exchange.Expired {
+		status = broker.DealStatusExpired
+	}
+
+	return broker.Deal{
+		ID:          deal.ID,
+		ClientID:    deal.ClientID,
+		Ticker:      deal.Ticker,
+		Type:        broker.DealType(deal.Side),
+		OrderType:   broker.OrderType(deal.Type),
+		TimeInForce: broker.TimeInForce(deal.TimeInForce),
+		Amount:      deal.Amount,
+		Partial:     deal.Partial,
+		FillID:      deal.FillID,
+		Price:       deal.Price,
+		StopPrice:   deal.StopPrice,
+		Status:      status,
+		Time:        deal.Time,
+	}
+}
@@ -0,0 +1,258 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Report result of backtesting.
+type Report struct {
+	Strategy       string                 `json:"strategy"`
+	Start          time.Time              `json:"start"`
+	End            time.Time              `json:"end"`
+	InitialCash    decimal.Decimal        `json:"initial_cash"`
+	FinalEquity    decimal.Decimal        `json:"final_equity"`
+	PnL            decimal.Decimal        `json:"pnl"`
+	MaxDrawdown    decimal.Decimal        `json:"max_drawdown"`
+	MaxDrawdownPct float64                `json:"max_drawdown_pct"`
+	Rejected       int                    `json:"rejected"`
+	Fills          []Fill                 `json:"fills"`
+	Equity         []Point                `json:"equity"`
+	Tickers        map[string]TickerStats `json:"tickers"`
+	peak           decimal.Decimal
+}
+
+// Fill execution of a strategy deal.
+type Fill struct {
+	Time   time.Time         `json:"time"`
+	DealID int64             `json:"deal_id"`
+	Ticker string            `json:"ticker"`
+	Type   broker.DealType   `json:"type"`
+	Status broker.DealStatus `json:"status"`
+	Amount int32             `json:"amount"`
+	Price  decimal.Decimal   `json:"price"`
+}
+
+// Point point of PnL curve.
+type Point struct {
+	Time     time.Time       `json:"time"`
+	Cash     decimal.Decimal `json:"cash"`
+	Equity   decimal.Decimal `json:"equity"`
+	PnL      decimal.Decimal `json:"pnl"`
+	Drawdown decimal.Decimal `json:"drawdown"`
+}
+
+// TickerStats statistic of a ticker.
+type TickerStats struct {
+	Fills         int             `json:"fills"`
+	Bought        int32           `json:"bought"`
+	Sold          int32           `json:"sold"`
+	Turnover      decimal.Decimal `json:"turnover"`
+	Position      int32           `json:"position"`
+	AvgCost       decimal.Decimal `json:"avg_cost"`
+	LastPrice     decimal.Decimal `json:"last_price"`
+	RealizedPnL   decimal.Decimal `json:"realized_pnl"`
+	UnrealizedPnL decimal.Decimal `json:"unrealized_pnl"`
+}
+
+// Adds fill to report and updates statistic of its ticker.
+func (r *Report) addFill(deal broker.Deal) {
+	r.Fills = append(r.Fills, Fill{
+		Time:   deal.Time,
+		DealID: deal.ID,
+		Ticker: deal.Ticker,
+		Type:   deal.Type,
+		Status: deal.Status,
+		Amount: deal.Amount,
+		Price:  deal.Price,
+	})
+
+	if deal.Status == broker.DealStatusExpired {
+		return
+	}
+
+	price := deal.Price
+
+	stats := r.Tickers[deal.Ticker]
+	stats.Fills++
+	stats.Turnover += price.Mul(deal.Amount)
+
+	if deal.Type == broker.Buy {
+		stats.Bought += deal.Amount
+		stats.buy(deal.Amount, price)
+	} else {
+		stats.Sold += deal.Amount
+		stats.sell(deal.Amount, price)
+	}
+
+	r.Tickers[deal.Ticker] = stats
+}
+
+// Adds point of PnL curve and updates drawdown.
+func (r *Report) addPoint(tm time.Time, cash, equity decimal.Decimal) {
+	if equity > r.peak {
+		r.peak = equity
+	}
+
+	point := Point{Time: tm, Cash: cash, Equity: equity, PnL: equity - r.InitialCash, Drawdown: r.peak - equity}
+
+	// Bars of different tickers closed at the same time give one point.
+	if n := len(r.Equity); n > 0 && r.Equity[n-1].Time.Equal(tm) {
+		r.Equity[n-1] = point
+	} else {
+		r.Equity = append(r.Equity, point)
+	}
+
+	if point.Drawdown > r.MaxDrawdown {
+		r.MaxDrawdown = point.Drawdown
+		r.MaxDrawdownPct = point.Drawdown.Float64() / r.peak.Float64() * 100
+	}
+}
+
+// Opens or increases position. Purchase which covers a short position realizes its PnL.
+func (t *TickerStats) buy(amount int32, price decimal.Decimal) {
+	if t.Position < 0 {
+		covered := min32(amount, -t.Position)
+		t.RealizedPnL += (t.AvgCost - price).Mul(covered)
+		t.Position += covered
+		amount -= covered
+
+		if t.Position == 0 {
+			t.AvgCost = decimal.Zero
+		}
+	}
+
+	if amount > 0 {
+		t.AvgCost = (t.AvgCost.Mul(t.Position) + price.Mul(amount)).Div(int64(t.Position + amount))
+		t.Position += amount
+	}
+}
+
+// Closes or decreases position. Sale beyond the position opens a short one.
+func (t *TickerStats) sell(amount int32, price decimal.Decimal) {
+	if t.Position > 0 {
+		closed := min32(amount, t.Position)
+		t.RealizedPnL += (price - t.AvgCost).Mul(closed)
+		t.Position -= closed
+		amount -= closed
+
+		if t.Position == 0 {
+			t.AvgCost = decimal.Zero
+		}
+	}
+
+	if amount > 0 {
+		t.AvgCost = (t.AvgCost.Mul(-t.Position) + price.Mul(amount)).Div(int64(amount - t.Position))
+		t.Position -= amount
+	}
+}
+
+// Write writes report.json, fills.csv, equity.csv and tickers.csv to a directory.
+func (r Report) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "report.json"), data, 0o644); err != nil {
+		return err
+	}
+
+	fills := [][]string{{"time", "deal_id", "ticker", "type", "status", "amount", "price"}}
+	for _, f := range r.Fills {
+		fills = append(fills, []string{
+			f.Time.Format(time.RFC3339),
+			strconv.FormatInt(f.DealID, 10),
+			f.Ticker,
+			string(f.Type),
+			string(f.Status),
+			strconv.FormatInt(int64(f.Amount), 10),
+			f.Price.String(),
+		})
+	}
+
+	if err := writeCSV(filepath.Join(dir, "fills.csv"), fills); err != nil {
+		return err
+	}
+
+	equity := [][]string{{"time", "cash", "equity", "pnl", "drawdown"}}
+	for _, p := range r.Equity {
+		equity = append(equity, []string{
+			p.Time.Format(time.RFC3339),
+			p.Cash.String(),
+			p.Equity.String(),
+			p.PnL.String(),
+			p.Drawdown.String(),
+		})
+	}
+
+	if err := writeCSV(filepath.Join(dir, "equity.csv"), equity); err != nil {
+		return err
+	}
+
+	tickers := make([]string, 0, len(r.Tickers))
+	for ticker := range r.Tickers {
+		tickers = append(tickers, ticker)
+	}
+
+	sort.Strings(tickers)
+
+	stats := [][]string{{
+		"ticker", "fills", "bought", "sold", "turnover", "position",
+		"avg_cost", "last_price", "realized_pnl", "unrealized_pnl",
+	}}
+	for _, ticker := range tickers {
+		s := r.Tickers[ticker]
+		stats = append(stats, []string{
+			ticker,
+			strconv.Itoa(s.Fills),
+			strconv.FormatInt(int64(s.Bought), 10),
+			strconv.FormatInt(int64(s.Sold), 10),
+			s.Turnover.String(),
+			strconv.FormatInt(int64(s.Position), 10),
+			s.AvgCost.String(),
+			s.LastPrice.String(),
+			s.RealizedPnL.String(),
+			s.UnrealizedPnL.String(),
+		})
+	}
+
+	return writeCSV(filepath.Join(dir, "tickers.csv"), stats)
+}
+
+// Writes records to a CSV file.
+func writeCSV(path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(records); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Returns minimal value.
+func min32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,47 @@
+package backtest
+
+import (
+	"testing"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+func TestReportKeepsMoneyExact(t *testing.T) {
+	r := Report{Tickers: make(map[string]TickerStats)}
+
+	fill := func(dealType broker.DealType, amount int32, price string) {
+		r.addFill(broker.Deal{
+			Ticker: "A",
+			Type:   dealType,
+			Amount: amount,
+			Price:  decimal.MustParse(price),
+			Status: broker.DealStatusCompleted,
+		})
+	}
+
+	// Tenths are not exact in floats, so their sums drift there.
+	for i := 0; i < 10; i++ {
+		fill(broker.Buy, 1, "0.1")
+	}
+
+	fill(broker.Sell, 4, "0.3")
+
+	stats := r.Tickers["A"]
+
+	if want := decimal.MustParse("2.2"); stats.Turnover != want {
+		t.Errorf("turnover is %s, want %s", stats.Turnover, want)
+	}
+
+	if want := decimal.MustParse("0.1"); stats.AvgCost != want {
+		t.Errorf("average cost is %s, want %s", stats.AvgCost, want)
+	}
+
+	if want := decimal.MustParse("0.8"); stats.RealizedPnL != want {
+		t.Errorf("realized PnL is %s, want %s", stats.RealizedPnL, want)
+	}
+
+	if stats.Position != 6 {
+		t.Errorf("position is %d, want 6", stats.Position)
+	}
+}
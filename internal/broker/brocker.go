@@ -1,21 +1,47 @@
 package broker
 
-import "context"
+import (
+	"context"
+	"time"
 
-// Profile user profile.
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Profile user profile. Available balance and positions are not held by open deals.
 type Profile struct {
 	ClientID  int64
-	Balance   float64
+	Balance   decimal.Decimal
+	Available decimal.Decimal
 	Positions []Position
 	OpenDeals []Deal
 }
 
-// ExchangeService stock exchange service.
+// StreamState state of connection to exchange stream.
+type StreamState string
+
+const (
+	// StreamConnecting stream is requested and waits until exchange establishes it.
+	StreamConnecting StreamState = "CONNECTING"
+	// StreamConnected stream is connected.
+	StreamConnected StreamState = "CONNECTED"
+	// StreamReconnecting stream is lost and waits for reconnection.
+	StreamReconnecting StreamState = "RECONNECTING"
+	// StreamStopped stream is stopped with service.
+	StreamStopped StreamState = "STOPPED"
+)
+
+// ExchangeService stock exchange service. Statistic, results and trades do not close out channels,
+// they return when context is done or connection is lost, connected is called once their stream is established.
+// Depth closes out when it ends.
 type ExchangeService interface {
-	Statistic(ctx context.Context, out chan OHLCV) error
+	Statistic(ctx context.Context, out chan OHLCV, connected func()) error
 	Create(deal Deal) (int64, error)
 	Cancel(dealID int64) (bool, error)
-	Results(ctx context.Context, out chan Deal) error
+	Modify(dealID int64, price decimal.Decimal, amount int32) (bool, error)
+	ListOpen() ([]Deal, error)
+	Results(ctx context.Context, seq int64, out chan Deal, connected func()) error
+	Depth(ctx context.Context, ticker string, levels int32, out chan Depth) error
+	Trades(ctx context.Context, out chan Trade, connected func()) error
 }
 
 // BrokerService broker service.
@@ -24,7 +50,15 @@ type BrokerService interface {
 	Stop()
 	GetClient(login string) (Client, error)
 	GetProfile(login string) (Profile, error)
+	GetDeal(login string, dealID int64) (Deal, error)
 	Create(deal Deal) (Deal, error)
 	Cancel(dealID int64) (bool, error)
-	History(ticker string) ([]OHLCV, error)
+	Modify(dealID int64, price decimal.Decimal, amount int32) (bool, error)
+	Settle(deal Deal) error
+	Ledger(login string) ([]Entry, error)
+	Transfer(login string, entryType EntryType, amount decimal.Decimal, memo string) (Entry, error)
+	History(ticker string, interval time.Duration) ([]OHLCV, error)
+	Tape(ticker string) ([]Trade, error)
+	Depth(ctx context.Context, ticker string, levels int32, out chan Depth) error
+	Streams() map[string]StreamState
 }
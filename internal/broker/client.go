@@ -1,16 +1,19 @@
 package broker
 
+import "github.com/marksartdev/trading/internal/decimal"
+
 // Client broker client.
 type Client struct {
 	ID      int64
 	Login   string
-	Balance float64
+	Balance decimal.Decimal
 }
 
 // ClientRepo client repository.
 type ClientRepo interface {
 	Add(client *Client) error
 	Get(login string) (Client, bool, error)
-	SumBalance(clientID int64, amount float64) error
-	SubBalance(clientID int64, amount float64) error
+	GetByID(clientID int64) (Client, bool, error)
+	SumBalance(clientID int64, amount decimal.Decimal) error
+	SubBalance(clientID int64, amount decimal.Decimal) error
 }
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Money columns which were stored as floating point numbers.
+var decimalColumns = map[string][]string{
+	"clients": {"balance"},
+	"deals":   {"price", "avg_price", "stop_price"},
+	"holds":   {"cash"},
+	"fills":   {"price"},
+	"entries": {"amount"},
+	"ohlcvs":  {"open", "high", "low", "close"},
+	"trades":  {"price"},
+}
+
+// Converts floating point money columns of existing tables into fixed-point numeric ones.
+// Values are rounded to decimal places, so converted data matches values written by the service.
+func migrateDecimals(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for table, columns := range decimalColumns {
+			for _, column := range columns {
+				var count int64
+
+				err := tx.Raw(
+					`SELECT COUNT(*) FROM information_schema.columns
+					WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ? AND data_type = 'double precision'`,
+					table, column,
+				).Scan(&count).Error
+				if err != nil {
+					return err
+				}
+
+				if count == 0 {
+					continue
+				}
+
+				err = tx.Exec(fmt.Sprintf(
+					"ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE numeric(24,6) USING round(%[2]s::numeric, 6)", table, column,
+				)).Error
+				if err != nil {
+					return fmt.Errorf("migrate %s.%s: %w", table, column, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
@@ -15,11 +15,20 @@ func New(cfg config.DB) (*gorm.DB, error) {
 		return nil, err
 	}
 
+	if err := migrateDecimals(db); err != nil {
+		return nil, err
+	}
+
 	if err := db.AutoMigrate(
 		repository.Client{},
 		repository.Deal{},
 		repository.Position{},
 		repository.OHLCV{},
+		repository.Trade{},
+		repository.Hold{},
+		repository.Fill{},
+		repository.Entry{},
+		repository.Cursor{},
 	); err != nil {
 		return nil, err
 	}
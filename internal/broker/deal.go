@@ -1,6 +1,10 @@
 package broker
 
-import "time"
+import (
+	"time"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
 
 // DealType deal type.
 type DealType string
@@ -12,6 +16,34 @@ const (
 	Sell DealType = "SELL"
 )
 
+// OrderType order type.
+type OrderType string
+
+const (
+	// Market order completed by the best available price.
+	Market OrderType = "MARKET"
+	// Limit order completed by the limit price or better.
+	Limit OrderType = "LIMIT"
+	// Stop order which becomes market order when stop price is reached.
+	Stop OrderType = "STOP"
+	// StopLimit order which becomes limit order when stop price is reached.
+	StopLimit OrderType = "STOP_LIMIT"
+)
+
+// TimeInForce how long deal stays active.
+type TimeInForce string
+
+const (
+	// GTC good till cancelled.
+	GTC TimeInForce = "GTC"
+	// IOC immediate or cancel.
+	IOC TimeInForce = "IOC"
+	// FOK fill or kill.
+	FOK TimeInForce = "FOK"
+	// Day deal expires at session close.
+	Day TimeInForce = "DAY"
+)
+
 // DealStatus deal status.
 type DealStatus string
 
@@ -22,25 +54,43 @@ const (
 	DealStatusCompleted DealStatus = "COMPLETED"
 	// DealStatusCanceled canceled deal status.
 	DealStatusCanceled DealStatus = "CANCELED"
+	// DealStatusExpired expired deal status.
+	DealStatusExpired DealStatus = "EXPIRED"
+	// DealStatusOrphaned status of a deal which is lost by exchange.
+	DealStatusOrphaned DealStatus = "ORPHANED"
+	// DealStatusPending status of a deal which is reserved but is not accepted by exchange yet.
+	DealStatusPending DealStatus = "PENDING"
 )
 
-// Deal user deal.
+// Deal user deal. Fills received from exchange carry executed amount and price in Amount and Price,
+// and identifier of the fill in FillID.
 type Deal struct {
-	ID       int64
-	ClientID int64
-	Ticker   string
-	Type     DealType
-	Amount   int32
-	Partial  bool
-	Price    float64
-	Status   DealStatus
-	Time     time.Time
+	ID          int64
+	ClientID    int64
+	Ticker      string
+	Type        DealType
+	OrderType   OrderType
+	TimeInForce TimeInForce
+	Amount      int32
+	Filled      int32
+	Partial     bool
+	FillID      int64
+	Seq         int64
+	Price       decimal.Decimal
+	AvgPrice    decimal.Decimal
+	StopPrice   decimal.Decimal
+	Status      DealStatus
+	Time        time.Time
 }
 
 // DealRepo deal repository.
 type DealRepo interface {
 	Add(deal Deal) error
+	Get(dealID int64) (Deal, bool, error)
 	GetOpened(clientID int64) ([]Deal, error)
+	ListOpened() ([]Deal, error)
 	Update(deal Deal) error
 	UpdateStatus(dealID int64, status DealStatus) error
+	Modify(dealID int64, price decimal.Decimal, amount int32) error
+	Delete(dealID int64) error
 }
@@ -20,6 +20,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Decimal fixed-point number as whole units and nano units of the same sign.
+type Decimal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Units int64 `protobuf:"varint,1,opt,name=Units,proto3" json:"Units,omitempty"`
+	Nanos int32 `protobuf:"varint,2,opt,name=Nanos,proto3" json:"Nanos,omitempty"`
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Decimal) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Decimal) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
 type Client struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -31,7 +87,7 @@ type Client struct {
 func (x *Client) Reset() {
 	*x = Client{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[0]
+		mi := &file_api_broker_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -44,7 +100,7 @@ func (x *Client) String() string {
 func (*Client) ProtoMessage() {}
 
 func (x *Client) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[0]
+	mi := &file_api_broker_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +113,7 @@ func (x *Client) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Client.ProtoReflect.Descriptor instead.
 func (*Client) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{0}
+	return file_api_broker_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Client) GetLogin() string {
@@ -72,15 +128,16 @@ type Profile struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Balance   float64     `protobuf:"fixed64,1,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	Balance   *Decimal    `protobuf:"bytes,5,opt,name=Balance,proto3" json:"Balance,omitempty"`
 	Positions []*Position `protobuf:"bytes,2,rep,name=Positions,proto3" json:"Positions,omitempty"`
 	Deals     []*Deal     `protobuf:"bytes,3,rep,name=Deals,proto3" json:"Deals,omitempty"`
+	Available *Decimal    `protobuf:"bytes,6,opt,name=Available,proto3" json:"Available,omitempty"`
 }
 
 func (x *Profile) Reset() {
 	*x = Profile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[1]
+		mi := &file_api_broker_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -93,7 +150,7 @@ func (x *Profile) String() string {
 func (*Profile) ProtoMessage() {}
 
 func (x *Profile) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[1]
+	mi := &file_api_broker_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,14 +163,14 @@ func (x *Profile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Profile.ProtoReflect.Descriptor instead.
 func (*Profile) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{1}
+	return file_api_broker_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Profile) GetBalance() float64 {
+func (x *Profile) GetBalance() *Decimal {
 	if x != nil {
 		return x.Balance
 	}
-	return 0
+	return nil
 }
 
 func (x *Profile) GetPositions() []*Position {
@@ -130,19 +187,27 @@ func (x *Profile) GetDeals() []*Deal {
 	return nil
 }
 
+func (x *Profile) GetAvailable() *Decimal {
+	if x != nil {
+		return x.Available
+	}
+	return nil
+}
+
 type Position struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticker string `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
-	Amount int32  `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Ticker    string `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Amount    int32  `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Available int32  `protobuf:"varint,3,opt,name=Available,proto3" json:"Available,omitempty"`
 }
 
 func (x *Position) Reset() {
 	*x = Position{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[2]
+		mi := &file_api_broker_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -155,7 +220,7 @@ func (x *Position) String() string {
 func (*Position) ProtoMessage() {}
 
 func (x *Position) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[2]
+	mi := &file_api_broker_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +233,7 @@ func (x *Position) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Position.ProtoReflect.Descriptor instead.
 func (*Position) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{2}
+	return file_api_broker_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Position) GetTicker() string {
@@ -185,23 +250,36 @@ func (x *Position) GetAmount() int32 {
 	return 0
 }
 
+func (x *Position) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
 type Deal struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID     int64   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Ticker string  `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
-	Type   string  `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
-	Amount int32   `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
-	Price  float64 `protobuf:"fixed64,5,opt,name=Price,proto3" json:"Price,omitempty"`
-	Time   int64   `protobuf:"varint,6,opt,name=Time,proto3" json:"Time,omitempty"`
+	ID          int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Ticker      string   `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Type        string   `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
+	Amount      int32    `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Price       *Decimal `protobuf:"bytes,13,opt,name=Price,proto3" json:"Price,omitempty"`
+	Time        int64    `protobuf:"varint,6,opt,name=Time,proto3" json:"Time,omitempty"`
+	OrderType   string   `protobuf:"bytes,7,opt,name=OrderType,proto3" json:"OrderType,omitempty"`
+	StopPrice   *Decimal `protobuf:"bytes,14,opt,name=StopPrice,proto3" json:"StopPrice,omitempty"`
+	TimeInForce string   `protobuf:"bytes,9,opt,name=TimeInForce,proto3" json:"TimeInForce,omitempty"`
+	Filled      int32    `protobuf:"varint,10,opt,name=Filled,proto3" json:"Filled,omitempty"`
+	AvgPrice    *Decimal `protobuf:"bytes,15,opt,name=AvgPrice,proto3" json:"AvgPrice,omitempty"`
+	Status      string   `protobuf:"bytes,12,opt,name=Status,proto3" json:"Status,omitempty"`
 }
 
 func (x *Deal) Reset() {
 	*x = Deal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[3]
+		mi := &file_api_broker_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -214,7 +292,7 @@ func (x *Deal) String() string {
 func (*Deal) ProtoMessage() {}
 
 func (x *Deal) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[3]
+	mi := &file_api_broker_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,7 +305,7 @@ func (x *Deal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Deal.ProtoReflect.Descriptor instead.
 func (*Deal) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{3}
+	return file_api_broker_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Deal) GetID() int64 {
@@ -258,11 +336,11 @@ func (x *Deal) GetAmount() int32 {
 	return 0
 }
 
-func (x *Deal) GetPrice() float64 {
+func (x *Deal) GetPrice() *Decimal {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
 }
 
 func (x *Deal) GetTime() int64 {
@@ -272,22 +350,67 @@ func (x *Deal) GetTime() int64 {
 	return 0
 }
 
+func (x *Deal) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *Deal) GetStopPrice() *Decimal {
+	if x != nil {
+		return x.StopPrice
+	}
+	return nil
+}
+
+func (x *Deal) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *Deal) GetFilled() int32 {
+	if x != nil {
+		return x.Filled
+	}
+	return 0
+}
+
+func (x *Deal) GetAvgPrice() *Decimal {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return nil
+}
+
+func (x *Deal) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 type CreateDeal struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Client *Client `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
-	Ticker string  `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
-	Type   string  `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
-	Amount int32   `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
-	Price  float64 `protobuf:"fixed64,5,opt,name=Price,proto3" json:"Price,omitempty"`
+	Client      *Client  `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
+	Ticker      string   `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Type        string   `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
+	Amount      int32    `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Price       *Decimal `protobuf:"bytes,9,opt,name=Price,proto3" json:"Price,omitempty"`
+	OrderType   string   `protobuf:"bytes,6,opt,name=OrderType,proto3" json:"OrderType,omitempty"`
+	StopPrice   *Decimal `protobuf:"bytes,10,opt,name=StopPrice,proto3" json:"StopPrice,omitempty"`
+	TimeInForce string   `protobuf:"bytes,8,opt,name=TimeInForce,proto3" json:"TimeInForce,omitempty"`
 }
 
 func (x *CreateDeal) Reset() {
 	*x = CreateDeal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[4]
+		mi := &file_api_broker_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -300,7 +423,7 @@ func (x *CreateDeal) String() string {
 func (*CreateDeal) ProtoMessage() {}
 
 func (x *CreateDeal) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[4]
+	mi := &file_api_broker_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,7 +436,7 @@ func (x *CreateDeal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDeal.ProtoReflect.Descriptor instead.
 func (*CreateDeal) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{4}
+	return file_api_broker_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateDeal) GetClient() *Client {
@@ -344,11 +467,32 @@ func (x *CreateDeal) GetAmount() int32 {
 	return 0
 }
 
-func (x *CreateDeal) GetPrice() float64 {
+func (x *CreateDeal) GetPrice() *Decimal {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
+}
+
+func (x *CreateDeal) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *CreateDeal) GetStopPrice() *Decimal {
+	if x != nil {
+		return x.StopPrice
+	}
+	return nil
+}
+
+func (x *CreateDeal) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
 }
 
 type CancelDeal struct {
@@ -363,7 +507,7 @@ type CancelDeal struct {
 func (x *CancelDeal) Reset() {
 	*x = CancelDeal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[5]
+		mi := &file_api_broker_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -376,7 +520,7 @@ func (x *CancelDeal) String() string {
 func (*CancelDeal) ProtoMessage() {}
 
 func (x *CancelDeal) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[5]
+	mi := &file_api_broker_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -389,7 +533,7 @@ func (x *CancelDeal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelDeal.ProtoReflect.Descriptor instead.
 func (*CancelDeal) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{5}
+	return file_api_broker_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CancelDeal) GetClient() *Client {
@@ -406,6 +550,132 @@ func (x *CancelDeal) GetDealID() *DealID {
 	return nil
 }
 
+type DealRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Client *Client `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
+	DealID *DealID `protobuf:"bytes,2,opt,name=DealID,proto3" json:"DealID,omitempty"`
+}
+
+func (x *DealRequest) Reset() {
+	*x = DealRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DealRequest) ProtoMessage() {}
+
+func (x *DealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DealRequest.ProtoReflect.Descriptor instead.
+func (*DealRequest) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DealRequest) GetClient() *Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *DealRequest) GetDealID() *DealID {
+	if x != nil {
+		return x.DealID
+	}
+	return nil
+}
+
+type ModifyDeal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Client *Client  `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
+	DealID *DealID  `protobuf:"bytes,2,opt,name=DealID,proto3" json:"DealID,omitempty"`
+	Price  *Decimal `protobuf:"bytes,5,opt,name=Price,proto3" json:"Price,omitempty"`
+	Amount int32    `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
+}
+
+func (x *ModifyDeal) Reset() {
+	*x = ModifyDeal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifyDeal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyDeal) ProtoMessage() {}
+
+func (x *ModifyDeal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyDeal.ProtoReflect.Descriptor instead.
+func (*ModifyDeal) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ModifyDeal) GetClient() *Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *ModifyDeal) GetDealID() *DealID {
+	if x != nil {
+		return x.DealID
+	}
+	return nil
+}
+
+func (x *ModifyDeal) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ModifyDeal) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
 type DealID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -417,7 +687,7 @@ type DealID struct {
 func (x *DealID) Reset() {
 	*x = DealID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[6]
+		mi := &file_api_broker_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -430,7 +700,7 @@ func (x *DealID) String() string {
 func (*DealID) ProtoMessage() {}
 
 func (x *DealID) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[6]
+	mi := &file_api_broker_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -443,7 +713,7 @@ func (x *DealID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DealID.ProtoReflect.Descriptor instead.
 func (*DealID) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{6}
+	return file_api_broker_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DealID) GetID() int64 {
@@ -464,7 +734,7 @@ type Success struct {
 func (x *Success) Reset() {
 	*x = Success{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[7]
+		mi := &file_api_broker_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -477,7 +747,7 @@ func (x *Success) String() string {
 func (*Success) ProtoMessage() {}
 
 func (x *Success) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[7]
+	mi := &file_api_broker_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,7 +760,7 @@ func (x *Success) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Success.ProtoReflect.Descriptor instead.
 func (*Success) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{7}
+	return file_api_broker_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Success) GetOK() bool {
@@ -505,14 +775,15 @@ type Ticker struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Client *Client `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
-	Name   string  `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Client   *Client `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
+	Name     string  `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Interval int64   `protobuf:"varint,3,opt,name=Interval,proto3" json:"Interval,omitempty"`
 }
 
 func (x *Ticker) Reset() {
 	*x = Ticker{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[8]
+		mi := &file_api_broker_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -525,7 +796,7 @@ func (x *Ticker) String() string {
 func (*Ticker) ProtoMessage() {}
 
 func (x *Ticker) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[8]
+	mi := &file_api_broker_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -538,48 +809,694 @@ func (x *Ticker) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ticker.ProtoReflect.Descriptor instead.
 func (*Ticker) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{8}
+	return file_api_broker_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Ticker) GetClient() *Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *Ticker) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Ticker) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+type OHLCV struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prices []*Price `protobuf:"bytes,1,rep,name=Prices,proto3" json:"Prices,omitempty"`
+}
+
+func (x *OHLCV) Reset() {
+	*x = OHLCV{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OHLCV) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OHLCV) ProtoMessage() {}
+
+func (x *OHLCV) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OHLCV.ProtoReflect.Descriptor instead.
+func (*OHLCV) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OHLCV) GetPrices() []*Price {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type Price struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time     int64    `protobuf:"varint,1,opt,name=Time,proto3" json:"Time,omitempty"`
+	Interval int64    `protobuf:"varint,2,opt,name=Interval,proto3" json:"Interval,omitempty"`
+	Open     *Decimal `protobuf:"bytes,8,opt,name=Open,proto3" json:"Open,omitempty"`
+	High     *Decimal `protobuf:"bytes,9,opt,name=High,proto3" json:"High,omitempty"`
+	Low      *Decimal `protobuf:"bytes,10,opt,name=Low,proto3" json:"Low,omitempty"`
+	Close    *Decimal `protobuf:"bytes,11,opt,name=Close,proto3" json:"Close,omitempty"`
+	Vol      int32    `protobuf:"varint,7,opt,name=Vol,proto3" json:"Vol,omitempty"`
+}
+
+func (x *Price) Reset() {
+	*x = Price{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Price) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Price) ProtoMessage() {}
+
+func (x *Price) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Price.ProtoReflect.Descriptor instead.
+func (*Price) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Price) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Price) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *Price) GetOpen() *Decimal {
+	if x != nil {
+		return x.Open
+	}
+	return nil
+}
+
+func (x *Price) GetHigh() *Decimal {
+	if x != nil {
+		return x.High
+	}
+	return nil
+}
+
+func (x *Price) GetLow() *Decimal {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *Price) GetClose() *Decimal {
+	if x != nil {
+		return x.Close
+	}
+	return nil
+}
+
+func (x *Price) GetVol() int32 {
+	if x != nil {
+		return x.Vol
+	}
+	return 0
+}
+
+type DepthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Client *Client `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
+	Ticker string  `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Levels int32   `protobuf:"varint,3,opt,name=Levels,proto3" json:"Levels,omitempty"`
+}
+
+func (x *DepthRequest) Reset() {
+	*x = DepthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthRequest) ProtoMessage() {}
+
+func (x *DepthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthRequest.ProtoReflect.Descriptor instead.
+func (*DepthRequest) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DepthRequest) GetClient() *Client {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *DepthRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *DepthRequest) GetLevels() int32 {
+	if x != nil {
+		return x.Levels
+	}
+	return 0
+}
+
+type Level struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price  *Decimal `protobuf:"bytes,4,opt,name=Price,proto3" json:"Price,omitempty"`
+	Amount int32    `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Orders int32    `protobuf:"varint,3,opt,name=Orders,proto3" json:"Orders,omitempty"`
+}
+
+func (x *Level) Reset() {
+	*x = Level{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Level) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Level) ProtoMessage() {}
+
+func (x *Level) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Level.ProtoReflect.Descriptor instead.
+func (*Level) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Level) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Level) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Level) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type MarketDepth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticker   string   `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Time     int64    `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	Snapshot bool     `protobuf:"varint,3,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`
+	Bids     []*Level `protobuf:"bytes,4,rep,name=Bids,proto3" json:"Bids,omitempty"`
+	Asks     []*Level `protobuf:"bytes,5,rep,name=Asks,proto3" json:"Asks,omitempty"`
+}
+
+func (x *MarketDepth) Reset() {
+	*x = MarketDepth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarketDepth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketDepth) ProtoMessage() {}
+
+func (x *MarketDepth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketDepth.ProtoReflect.Descriptor instead.
+func (*MarketDepth) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MarketDepth) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *MarketDepth) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MarketDepth) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *MarketDepth) GetBids() []*Level {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *MarketDepth) GetAsks() []*Level {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+type Trade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID     int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Ticker string   `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Price  *Decimal `protobuf:"bytes,7,opt,name=Price,proto3" json:"Price,omitempty"`
+	Amount int32    `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Type   string   `protobuf:"bytes,5,opt,name=Type,proto3" json:"Type,omitempty"`
+	Time   int64    `protobuf:"varint,6,opt,name=Time,proto3" json:"Time,omitempty"`
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Trade) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+func (x *Trade) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Trade) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Trade) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Trade) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Trade) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type Tape struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trades []*Trade `protobuf:"bytes,1,rep,name=Trades,proto3" json:"Trades,omitempty"`
+}
+
+func (x *Tape) Reset() {
+	*x = Tape{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tape) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tape) ProtoMessage() {}
+
+func (x *Tape) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tape.ProtoReflect.Descriptor instead.
+func (*Tape) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Tape) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+type Streams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	States map[string]string `protobuf:"bytes,1,rep,name=States,proto3" json:"States,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Streams) Reset() {
+	*x = Streams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Streams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Streams) ProtoMessage() {}
+
+func (x *Streams) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Streams.ProtoReflect.Descriptor instead.
+func (*Streams) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Streams) GetStates() map[string]string {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type CashMovement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID      int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Type    string   `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty"`
+	Debit   string   `protobuf:"bytes,3,opt,name=Debit,proto3" json:"Debit,omitempty"`
+	Credit  string   `protobuf:"bytes,4,opt,name=Credit,proto3" json:"Credit,omitempty"`
+	Amount  *Decimal `protobuf:"bytes,10,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Balance *Decimal `protobuf:"bytes,11,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	DealID  int64    `protobuf:"varint,7,opt,name=DealID,proto3" json:"DealID,omitempty"`
+	Memo    string   `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	Time    int64    `protobuf:"varint,9,opt,name=Time,proto3" json:"Time,omitempty"`
+}
+
+func (x *CashMovement) Reset() {
+	*x = CashMovement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_broker_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CashMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CashMovement) ProtoMessage() {}
+
+func (x *CashMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Ticker) GetClient() *Client {
+// Deprecated: Use CashMovement.ProtoReflect.Descriptor instead.
+func (*CashMovement) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CashMovement) GetID() int64 {
 	if x != nil {
-		return x.Client
+		return x.ID
+	}
+	return 0
+}
+
+func (x *CashMovement) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CashMovement) GetDebit() string {
+	if x != nil {
+		return x.Debit
+	}
+	return ""
+}
+
+func (x *CashMovement) GetCredit() string {
+	if x != nil {
+		return x.Credit
+	}
+	return ""
+}
+
+func (x *CashMovement) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
 	}
 	return nil
 }
 
-func (x *Ticker) GetName() string {
+func (x *CashMovement) GetBalance() *Decimal {
 	if x != nil {
-		return x.Name
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *CashMovement) GetDealID() int64 {
+	if x != nil {
+		return x.DealID
+	}
+	return 0
+}
+
+func (x *CashMovement) GetMemo() string {
+	if x != nil {
+		return x.Memo
 	}
 	return ""
 }
 
-type OHLCV struct {
+func (x *CashMovement) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type CashMovements struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Prices []*Price `protobuf:"bytes,1,rep,name=Prices,proto3" json:"Prices,omitempty"`
+	Movements []*CashMovement `protobuf:"bytes,1,rep,name=Movements,proto3" json:"Movements,omitempty"`
 }
 
-func (x *OHLCV) Reset() {
-	*x = OHLCV{}
+func (x *CashMovements) Reset() {
+	*x = CashMovements{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[9]
+		mi := &file_api_broker_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OHLCV) String() string {
+func (x *CashMovements) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OHLCV) ProtoMessage() {}
+func (*CashMovements) ProtoMessage() {}
 
-func (x *OHLCV) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[9]
+func (x *CashMovements) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,49 +1507,47 @@ func (x *OHLCV) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OHLCV.ProtoReflect.Descriptor instead.
-func (*OHLCV) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CashMovements.ProtoReflect.Descriptor instead.
+func (*CashMovements) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *OHLCV) GetPrices() []*Price {
+func (x *CashMovements) GetMovements() []*CashMovement {
 	if x != nil {
-		return x.Prices
+		return x.Movements
 	}
 	return nil
 }
 
-type Price struct {
+// Transfer deposit, withdrawal or adjustment of client cash, negative adjustment decreases cash.
+type TransferRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Time     int64   `protobuf:"varint,1,opt,name=Time,proto3" json:"Time,omitempty"`
-	Interval int32   `protobuf:"varint,2,opt,name=Interval,proto3" json:"Interval,omitempty"`
-	Open     float64 `protobuf:"fixed64,3,opt,name=Open,proto3" json:"Open,omitempty"`
-	High     float64 `protobuf:"fixed64,4,opt,name=High,proto3" json:"High,omitempty"`
-	Low      float64 `protobuf:"fixed64,5,opt,name=Low,proto3" json:"Low,omitempty"`
-	Close    float64 `protobuf:"fixed64,6,opt,name=Close,proto3" json:"Close,omitempty"`
-	Vol      int32   `protobuf:"varint,7,opt,name=Vol,proto3" json:"Vol,omitempty"`
+	Client *Client  `protobuf:"bytes,1,opt,name=Client,proto3" json:"Client,omitempty"`
+	Type   string   `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty"`
+	Amount *Decimal `protobuf:"bytes,3,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Memo   string   `protobuf:"bytes,4,opt,name=Memo,proto3" json:"Memo,omitempty"`
 }
 
-func (x *Price) Reset() {
-	*x = Price{}
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_broker_proto_msgTypes[10]
+		mi := &file_api_broker_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Price) String() string {
+func (x *TransferRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Price) ProtoMessage() {}
+func (*TransferRequest) ProtoMessage() {}
 
-func (x *Price) ProtoReflect() protoreflect.Message {
-	mi := &file_api_broker_proto_msgTypes[10]
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_broker_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,139 +1558,271 @@ func (x *Price) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Price.ProtoReflect.Descriptor instead.
-func (*Price) Descriptor() ([]byte, []int) {
-	return file_api_broker_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *Price) GetTime() int64 {
-	if x != nil {
-		return x.Time
-	}
-	return 0
-}
-
-func (x *Price) GetInterval() int32 {
-	if x != nil {
-		return x.Interval
-	}
-	return 0
-}
-
-func (x *Price) GetOpen() float64 {
-	if x != nil {
-		return x.Open
-	}
-	return 0
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_api_broker_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Price) GetHigh() float64 {
+func (x *TransferRequest) GetClient() *Client {
 	if x != nil {
-		return x.High
+		return x.Client
 	}
-	return 0
+	return nil
 }
 
-func (x *Price) GetLow() float64 {
+func (x *TransferRequest) GetType() string {
 	if x != nil {
-		return x.Low
+		return x.Type
 	}
-	return 0
+	return ""
 }
 
-func (x *Price) GetClose() float64 {
+func (x *TransferRequest) GetAmount() *Decimal {
 	if x != nil {
-		return x.Close
+		return x.Amount
 	}
-	return 0
+	return nil
 }
 
-func (x *Price) GetVol() int32 {
+func (x *TransferRequest) GetMemo() string {
 	if x != nil {
-		return x.Vol
+		return x.Memo
 	}
-	return 0
+	return ""
 }
 
 var File_api_broker_proto protoreflect.FileDescriptor
 
 var file_api_broker_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x61, 0x70, 0x69, 0x2f, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x06, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x22, 0x1e, 0x0a, 0x06, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x22, 0x77, 0x0a, 0x07, 0x50, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12,
-	0x2e, 0x0a, 0x09, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x22, 0x0a, 0x05, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c,
-	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x05, 0x44, 0x65,
-	0x61, 0x6c, 0x73, 0x22, 0x3a, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22,
-	0x84, 0x01, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72,
-	0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x50, 0x72, 0x69,
-	0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x8e, 0x01, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54,
-	0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0x5c, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a,
-	0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
-	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x52, 0x06, 0x44,
-	0x65, 0x61, 0x6c, 0x49, 0x44, 0x22, 0x18, 0x0a, 0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x12,
+	0x74, 0x6f, 0x12, 0x06, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x07, 0x44, 0x65,
+	0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4e,
+	0x61, 0x6e, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x4e, 0x61, 0x6e, 0x6f,
+	0x73, 0x22, 0x1e, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4c,
+	0x6f, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x6f, 0x67, 0x69,
+	0x6e, 0x22, 0xc3, 0x01, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x29, 0x0a,
+	0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52,
+	0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x44, 0x65, 0x61, 0x6c,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x05, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x2d, 0x0a, 0x09,
+	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c,
+	0x52, 0x09, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10,
+	0x02, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0x58, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x22, 0xf3, 0x02, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25,
+	0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x05,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x53, 0x74, 0x6f, 0x70, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x09, 0x53, 0x74, 0x6f,
+	0x70, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e,
+	0x46, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x54, 0x69, 0x6d,
+	0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x6c,
+	0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x6c, 0x65, 0x64,
+	0x12, 0x2b, 0x0a, 0x08, 0x41, 0x76, 0x67, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x52, 0x08, 0x41, 0x76, 0x67, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04, 0x08, 0x08, 0x10,
+	0x09, 0x4a, 0x04, 0x08, 0x0b, 0x10, 0x0c, 0x22, 0x9a, 0x02, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x53, 0x74, 0x6f, 0x70, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x09, 0x53, 0x74, 0x6f,
+	0x70, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e,
+	0x46, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x54, 0x69, 0x6d,
+	0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04,
+	0x08, 0x07, 0x10, 0x08, 0x22, 0x5c, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x44, 0x65,
+	0x61, 0x6c, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x44, 0x65,
+	0x61, 0x6c, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x52, 0x06, 0x44, 0x65, 0x61, 0x6c,
+	0x49, 0x44, 0x22, 0x5d, 0x0a, 0x0b, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x44, 0x65, 0x61,
+	0x6c, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x52, 0x06, 0x44, 0x65, 0x61, 0x6c, 0x49,
+	0x44, 0x22, 0xa1, 0x01, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x65, 0x61, 0x6c,
+	0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x44, 0x65, 0x61, 0x6c,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x52, 0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44,
+	0x12, 0x25, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c,
+	0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4a,
+	0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x18, 0x0a, 0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x12,
 	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x22,
 	0x19, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x4f, 0x4b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x4f, 0x4b, 0x22, 0x44, 0x0a, 0x06, 0x54, 0x69,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x4f, 0x4b, 0x22, 0x60, 0x0a, 0x06, 0x54, 0x69,
 	0x63, 0x6b, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x6c,
 	0x69, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
 	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0x2e, 0x0a, 0x05, 0x4f, 0x48, 0x4c, 0x43, 0x56, 0x12, 0x25, 0x0a, 0x06, 0x50, 0x72, 0x69,
-	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
-	0x65, 0x72, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x06, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73,
-	0x22, 0x99, 0x01, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x4f, 0x70,
-	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x12,
-	0x0a, 0x04, 0x48, 0x69, 0x67, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x48, 0x69,
-	0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x4c, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x03, 0x4c, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x56, 0x6f,
-	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x56, 0x6f, 0x6c, 0x32, 0xc8, 0x01, 0x0a,
-	0x06, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x1a, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x12, 0x12, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x44, 0x65, 0x61, 0x6c, 0x1a, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
-	0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63,
-	0x65, 0x6c, 0x12, 0x12, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x61, 0x6e, 0x63,
-	0x65, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x1a, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
-	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09, 0x53, 0x74, 0x61,
-	0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
-	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x1a, 0x0d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
-	0x4f, 0x48, 0x4c, 0x43, 0x56, 0x22, 0x00, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x61, 0x72, 0x74, 0x64, 0x65,
-	0x76, 0x2f, 0x74, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x2f, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
-	0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x1a, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x2e, 0x0a, 0x05,
+	0x4f, 0x48, 0x4c, 0x43, 0x56, 0x12, 0x25, 0x0a, 0x06, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x52, 0x06, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x22, 0xf5, 0x01, 0x0a,
+	0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x23, 0x0a, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65,
+	0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x04, 0x48,
+	0x69, 0x67, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x04, 0x48, 0x69, 0x67, 0x68,
+	0x12, 0x21, 0x0a, 0x03, 0x4c, 0x6f, 0x77, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x03,
+	0x4c, 0x6f, 0x77, 0x12, 0x25, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x52, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x56, 0x6f,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x56, 0x6f, 0x6c, 0x4a, 0x04, 0x08, 0x03,
+	0x10, 0x04, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04,
+	0x08, 0x06, 0x10, 0x07, 0x22, 0x66, 0x0a, 0x0c, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x22, 0x64, 0x0a, 0x05,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65,
+	0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x02, 0x22, 0x9b, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x70,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x42, 0x69,
+	0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x42, 0x69, 0x64, 0x73, 0x12, 0x21, 0x0a,
+	0x04, 0x41, 0x73, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x41, 0x73, 0x6b, 0x73,
+	0x22, 0x9c, 0x01, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x12, 0x25, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22,
+	0x2d, 0x0a, 0x04, 0x54, 0x61, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x22, 0x79,
+	0x0a, 0x07, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x1a, 0x39,
+	0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x80, 0x02, 0x0a, 0x0c, 0x43, 0x61,
+	0x73, 0x68, 0x4d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x44, 0x65, 0x62, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x44,
+	0x65, 0x62, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x12, 0x27, 0x0a, 0x06,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x06, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e,
+	0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x6f,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65,
+	0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x22, 0x43, 0x0a, 0x0d,
+	0x43, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x32, 0x0a,
+	0x09, 0x4d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x61, 0x73, 0x68, 0x4d, 0x6f,
+	0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x4d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x27, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65,
+	0x6d, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x32, 0xb1,
+	0x04, 0x0a, 0x06, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x1a, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x07, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x13, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44,
+	0x65, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x06, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x12, 0x12, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x61, 0x6c, 0x1a, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x06, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x12, 0x12, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x1a, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x06, 0x4d,
+	0x6f, 0x64, 0x69, 0x66, 0x79, 0x12, 0x12, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x4d,
+	0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x65, 0x61, 0x6c, 0x1a, 0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x1a, 0x0d, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x4f, 0x48, 0x4c, 0x43, 0x56, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x05, 0x44, 0x65,
+	0x70, 0x74, 0x68, 0x12, 0x14, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x70,
+	0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x70, 0x74, 0x68, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x28, 0x0a, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x0e, 0x2e, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x1a, 0x0c, 0x2e, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x54, 0x61, 0x70, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0f,
+	0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12,
+	0x0e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x1a,
+	0x0f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73,
+	0x22, 0x00, 0x12, 0x31, 0x0a, 0x06, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x0e, 0x2e, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x1a, 0x15, 0x2e, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x43, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x76, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x12, 0x17, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x2e, 0x43, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x22, 0x00, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x61, 0x72, 0x74, 0x64, 0x65, 0x76, 0x2f, 0x74, 0x72, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x2f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x72, 0x70,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -790,41 +1837,96 @@ func file_api_broker_proto_rawDescGZIP() []byte {
 	return file_api_broker_proto_rawDescData
 }
 
-var file_api_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_api_broker_proto_goTypes = []interface{}{
-	(*Client)(nil),     // 0: broker.Client
-	(*Profile)(nil),    // 1: broker.Profile
-	(*Position)(nil),   // 2: broker.Position
-	(*Deal)(nil),       // 3: broker.Deal
-	(*CreateDeal)(nil), // 4: broker.CreateDeal
-	(*CancelDeal)(nil), // 5: broker.CancelDeal
-	(*DealID)(nil),     // 6: broker.DealID
-	(*Success)(nil),    // 7: broker.Success
-	(*Ticker)(nil),     // 8: broker.Ticker
-	(*OHLCV)(nil),      // 9: broker.OHLCV
-	(*Price)(nil),      // 10: broker.Price
+	(*Decimal)(nil),         // 0: broker.Decimal
+	(*Client)(nil),          // 1: broker.Client
+	(*Profile)(nil),         // 2: broker.Profile
+	(*Position)(nil),        // 3: broker.Position
+	(*Deal)(nil),            // 4: broker.Deal
+	(*CreateDeal)(nil),      // 5: broker.CreateDeal
+	(*CancelDeal)(nil),      // 6: broker.CancelDeal
+	(*DealRequest)(nil),     // 7: broker.DealRequest
+	(*ModifyDeal)(nil),      // 8: broker.ModifyDeal
+	(*DealID)(nil),          // 9: broker.DealID
+	(*Success)(nil),         // 10: broker.Success
+	(*Ticker)(nil),          // 11: broker.Ticker
+	(*OHLCV)(nil),           // 12: broker.OHLCV
+	(*Price)(nil),           // 13: broker.Price
+	(*DepthRequest)(nil),    // 14: broker.DepthRequest
+	(*Level)(nil),           // 15: broker.Level
+	(*MarketDepth)(nil),     // 16: broker.MarketDepth
+	(*Trade)(nil),           // 17: broker.Trade
+	(*Tape)(nil),            // 18: broker.Tape
+	(*Streams)(nil),         // 19: broker.Streams
+	(*CashMovement)(nil),    // 20: broker.CashMovement
+	(*CashMovements)(nil),   // 21: broker.CashMovements
+	(*TransferRequest)(nil), // 22: broker.TransferRequest
+	nil,                     // 23: broker.Streams.StatesEntry
 }
 var file_api_broker_proto_depIdxs = []int32{
-	2,  // 0: broker.Profile.Positions:type_name -> broker.Position
-	3,  // 1: broker.Profile.Deals:type_name -> broker.Deal
-	0,  // 2: broker.CreateDeal.Client:type_name -> broker.Client
-	0,  // 3: broker.CancelDeal.Client:type_name -> broker.Client
-	6,  // 4: broker.CancelDeal.DealID:type_name -> broker.DealID
-	0,  // 5: broker.Ticker.Client:type_name -> broker.Client
-	10, // 6: broker.OHLCV.Prices:type_name -> broker.Price
-	0,  // 7: broker.Broker.GetProfile:input_type -> broker.Client
-	4,  // 8: broker.Broker.Create:input_type -> broker.CreateDeal
-	5,  // 9: broker.Broker.Cancel:input_type -> broker.CancelDeal
-	8,  // 10: broker.Broker.Statistic:input_type -> broker.Ticker
-	1,  // 11: broker.Broker.GetProfile:output_type -> broker.Profile
-	6,  // 12: broker.Broker.Create:output_type -> broker.DealID
-	7,  // 13: broker.Broker.Cancel:output_type -> broker.Success
-	9,  // 14: broker.Broker.Statistic:output_type -> broker.OHLCV
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	0,  // 0: broker.Profile.Balance:type_name -> broker.Decimal
+	3,  // 1: broker.Profile.Positions:type_name -> broker.Position
+	4,  // 2: broker.Profile.Deals:type_name -> broker.Deal
+	0,  // 3: broker.Profile.Available:type_name -> broker.Decimal
+	0,  // 4: broker.Deal.Price:type_name -> broker.Decimal
+	0,  // 5: broker.Deal.StopPrice:type_name -> broker.Decimal
+	0,  // 6: broker.Deal.AvgPrice:type_name -> broker.Decimal
+	1,  // 7: broker.CreateDeal.Client:type_name -> broker.Client
+	0,  // 8: broker.CreateDeal.Price:type_name -> broker.Decimal
+	0,  // 9: broker.CreateDeal.StopPrice:type_name -> broker.Decimal
+	1,  // 10: broker.CancelDeal.Client:type_name -> broker.Client
+	9,  // 11: broker.CancelDeal.DealID:type_name -> broker.DealID
+	1,  // 12: broker.DealRequest.Client:type_name -> broker.Client
+	9,  // 13: broker.DealRequest.DealID:type_name -> broker.DealID
+	1,  // 14: broker.ModifyDeal.Client:type_name -> broker.Client
+	9,  // 15: broker.ModifyDeal.DealID:type_name -> broker.DealID
+	0,  // 16: broker.ModifyDeal.Price:type_name -> broker.Decimal
+	1,  // 17: broker.Ticker.Client:type_name -> broker.Client
+	13, // 18: broker.OHLCV.Prices:type_name -> broker.Price
+	0,  // 19: broker.Price.Open:type_name -> broker.Decimal
+	0,  // 20: broker.Price.High:type_name -> broker.Decimal
+	0,  // 21: broker.Price.Low:type_name -> broker.Decimal
+	0,  // 22: broker.Price.Close:type_name -> broker.Decimal
+	1,  // 23: broker.DepthRequest.Client:type_name -> broker.Client
+	0,  // 24: broker.Level.Price:type_name -> broker.Decimal
+	15, // 25: broker.MarketDepth.Bids:type_name -> broker.Level
+	15, // 26: broker.MarketDepth.Asks:type_name -> broker.Level
+	0,  // 27: broker.Trade.Price:type_name -> broker.Decimal
+	17, // 28: broker.Tape.Trades:type_name -> broker.Trade
+	23, // 29: broker.Streams.States:type_name -> broker.Streams.StatesEntry
+	0,  // 30: broker.CashMovement.Amount:type_name -> broker.Decimal
+	0,  // 31: broker.CashMovement.Balance:type_name -> broker.Decimal
+	20, // 32: broker.CashMovements.Movements:type_name -> broker.CashMovement
+	1,  // 33: broker.TransferRequest.Client:type_name -> broker.Client
+	0,  // 34: broker.TransferRequest.Amount:type_name -> broker.Decimal
+	1,  // 35: broker.Broker.GetProfile:input_type -> broker.Client
+	7,  // 36: broker.Broker.GetDeal:input_type -> broker.DealRequest
+	5,  // 37: broker.Broker.Create:input_type -> broker.CreateDeal
+	6,  // 38: broker.Broker.Cancel:input_type -> broker.CancelDeal
+	8,  // 39: broker.Broker.Modify:input_type -> broker.ModifyDeal
+	11, // 40: broker.Broker.Statistic:input_type -> broker.Ticker
+	14, // 41: broker.Broker.Depth:input_type -> broker.DepthRequest
+	11, // 42: broker.Broker.Trades:input_type -> broker.Ticker
+	1,  // 43: broker.Broker.ExchangeStreams:input_type -> broker.Client
+	1,  // 44: broker.Broker.Ledger:input_type -> broker.Client
+	22, // 45: broker.Broker.Transfer:input_type -> broker.TransferRequest
+	2,  // 46: broker.Broker.GetProfile:output_type -> broker.Profile
+	4,  // 47: broker.Broker.GetDeal:output_type -> broker.Deal
+	9,  // 48: broker.Broker.Create:output_type -> broker.DealID
+	10, // 49: broker.Broker.Cancel:output_type -> broker.Success
+	10, // 50: broker.Broker.Modify:output_type -> broker.Success
+	12, // 51: broker.Broker.Statistic:output_type -> broker.OHLCV
+	16, // 52: broker.Broker.Depth:output_type -> broker.MarketDepth
+	18, // 53: broker.Broker.Trades:output_type -> broker.Tape
+	19, // 54: broker.Broker.ExchangeStreams:output_type -> broker.Streams
+	21, // 55: broker.Broker.Ledger:output_type -> broker.CashMovements
+	20, // 56: broker.Broker.Transfer:output_type -> broker.CashMovement
+	46, // [46:57] is the sub-list for method output_type
+	35, // [35:46] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_api_broker_proto_init() }
@@ -834,7 +1936,7 @@ func file_api_broker_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_api_broker_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Client); i {
+			switch v := v.(*Decimal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -846,7 +1948,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Profile); i {
+			switch v := v.(*Client); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -858,7 +1960,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Position); i {
+			switch v := v.(*Profile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -870,7 +1972,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Deal); i {
+			switch v := v.(*Position); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -882,7 +1984,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateDeal); i {
+			switch v := v.(*Deal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -894,7 +1996,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelDeal); i {
+			switch v := v.(*CreateDeal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -906,7 +2008,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DealID); i {
+			switch v := v.(*CancelDeal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -918,7 +2020,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Success); i {
+			switch v := v.(*DealRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -930,7 +2032,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Ticker); i {
+			switch v := v.(*ModifyDeal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -942,7 +2044,7 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OHLCV); i {
+			switch v := v.(*DealID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -954,6 +2056,42 @@ func file_api_broker_proto_init() {
 			}
 		}
 		file_api_broker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Success); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ticker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OHLCV); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Price); i {
 			case 0:
 				return &v.state
@@ -965,6 +2103,114 @@ func file_api_broker_proto_init() {
 				return nil
 			}
 		}
+		file_api_broker_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Level); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarketDepth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tape); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Streams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CashMovement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CashMovements); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_broker_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -972,7 +2218,7 @@ func file_api_broker_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_broker_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
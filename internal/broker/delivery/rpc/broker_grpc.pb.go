@@ -19,9 +19,16 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BrokerClient interface {
 	GetProfile(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Profile, error)
+	GetDeal(ctx context.Context, in *DealRequest, opts ...grpc.CallOption) (*Deal, error)
 	Create(ctx context.Context, in *CreateDeal, opts ...grpc.CallOption) (*DealID, error)
 	Cancel(ctx context.Context, in *CancelDeal, opts ...grpc.CallOption) (*Success, error)
+	Modify(ctx context.Context, in *ModifyDeal, opts ...grpc.CallOption) (*Success, error)
 	Statistic(ctx context.Context, in *Ticker, opts ...grpc.CallOption) (*OHLCV, error)
+	Depth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (Broker_DepthClient, error)
+	Trades(ctx context.Context, in *Ticker, opts ...grpc.CallOption) (*Tape, error)
+	ExchangeStreams(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Streams, error)
+	Ledger(ctx context.Context, in *Client, opts ...grpc.CallOption) (*CashMovements, error)
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*CashMovement, error)
 }
 
 type brokerClient struct {
@@ -41,6 +48,15 @@ func (c *brokerClient) GetProfile(ctx context.Context, in *Client, opts ...grpc.
 	return out, nil
 }
 
+func (c *brokerClient) GetDeal(ctx context.Context, in *DealRequest, opts ...grpc.CallOption) (*Deal, error) {
+	out := new(Deal)
+	err := c.cc.Invoke(ctx, "/broker.Broker/GetDeal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *brokerClient) Create(ctx context.Context, in *CreateDeal, opts ...grpc.CallOption) (*DealID, error) {
 	out := new(DealID)
 	err := c.cc.Invoke(ctx, "/broker.Broker/Create", in, out, opts...)
@@ -59,6 +75,15 @@ func (c *brokerClient) Cancel(ctx context.Context, in *CancelDeal, opts ...grpc.
 	return out, nil
 }
 
+func (c *brokerClient) Modify(ctx context.Context, in *ModifyDeal, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/broker.Broker/Modify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *brokerClient) Statistic(ctx context.Context, in *Ticker, opts ...grpc.CallOption) (*OHLCV, error) {
 	out := new(OHLCV)
 	err := c.cc.Invoke(ctx, "/broker.Broker/Statistic", in, out, opts...)
@@ -68,14 +93,89 @@ func (c *brokerClient) Statistic(ctx context.Context, in *Ticker, opts ...grpc.C
 	return out, nil
 }
 
+func (c *brokerClient) Depth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (Broker_DepthClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Broker_ServiceDesc.Streams[0], "/broker.Broker/Depth", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &brokerDepthClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Broker_DepthClient interface {
+	Recv() (*MarketDepth, error)
+	grpc.ClientStream
+}
+
+type brokerDepthClient struct {
+	grpc.ClientStream
+}
+
+func (x *brokerDepthClient) Recv() (*MarketDepth, error) {
+	m := new(MarketDepth)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *brokerClient) Trades(ctx context.Context, in *Ticker, opts ...grpc.CallOption) (*Tape, error) {
+	out := new(Tape)
+	err := c.cc.Invoke(ctx, "/broker.Broker/Trades", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) ExchangeStreams(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Streams, error) {
+	out := new(Streams)
+	err := c.cc.Invoke(ctx, "/broker.Broker/ExchangeStreams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) Ledger(ctx context.Context, in *Client, opts ...grpc.CallOption) (*CashMovements, error) {
+	out := new(CashMovements)
+	err := c.cc.Invoke(ctx, "/broker.Broker/Ledger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *brokerClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*CashMovement, error) {
+	out := new(CashMovement)
+	err := c.cc.Invoke(ctx, "/broker.Broker/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BrokerServer is the server API for Broker service.
 // All implementations must embed UnimplementedBrokerServer
 // for forward compatibility
 type BrokerServer interface {
 	GetProfile(context.Context, *Client) (*Profile, error)
+	GetDeal(context.Context, *DealRequest) (*Deal, error)
 	Create(context.Context, *CreateDeal) (*DealID, error)
 	Cancel(context.Context, *CancelDeal) (*Success, error)
+	Modify(context.Context, *ModifyDeal) (*Success, error)
 	Statistic(context.Context, *Ticker) (*OHLCV, error)
+	Depth(*DepthRequest, Broker_DepthServer) error
+	Trades(context.Context, *Ticker) (*Tape, error)
+	ExchangeStreams(context.Context, *Client) (*Streams, error)
+	Ledger(context.Context, *Client) (*CashMovements, error)
+	Transfer(context.Context, *TransferRequest) (*CashMovement, error)
 	mustEmbedUnimplementedBrokerServer()
 }
 
@@ -86,15 +186,36 @@ type UnimplementedBrokerServer struct {
 func (UnimplementedBrokerServer) GetProfile(context.Context, *Client) (*Profile, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
 }
+func (UnimplementedBrokerServer) GetDeal(context.Context, *DealRequest) (*Deal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeal not implemented")
+}
 func (UnimplementedBrokerServer) Create(context.Context, *CreateDeal) (*DealID, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
 func (UnimplementedBrokerServer) Cancel(context.Context, *CancelDeal) (*Success, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
+func (UnimplementedBrokerServer) Modify(context.Context, *ModifyDeal) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Modify not implemented")
+}
 func (UnimplementedBrokerServer) Statistic(context.Context, *Ticker) (*OHLCV, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Statistic not implemented")
 }
+func (UnimplementedBrokerServer) Depth(*DepthRequest, Broker_DepthServer) error {
+	return status.Errorf(codes.Unimplemented, "method Depth not implemented")
+}
+func (UnimplementedBrokerServer) Trades(context.Context, *Ticker) (*Tape, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Trades not implemented")
+}
+func (UnimplementedBrokerServer) ExchangeStreams(context.Context, *Client) (*Streams, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeStreams not implemented")
+}
+func (UnimplementedBrokerServer) Ledger(context.Context, *Client) (*CashMovements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ledger not implemented")
+}
+func (UnimplementedBrokerServer) Transfer(context.Context, *TransferRequest) (*CashMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
 func (UnimplementedBrokerServer) mustEmbedUnimplementedBrokerServer() {}
 
 // UnsafeBrokerServer may be embedded to opt out of forward compatibility for this service.
@@ -126,6 +247,24 @@ func _Broker_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_GetDeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).GetDeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/GetDeal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).GetDeal(ctx, req.(*DealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Broker_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateDeal)
 	if err := dec(in); err != nil {
@@ -162,6 +301,24 @@ func _Broker_Cancel_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_Modify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyDeal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).Modify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/Modify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).Modify(ctx, req.(*ModifyDeal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Broker_Statistic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Ticker)
 	if err := dec(in); err != nil {
@@ -180,6 +337,99 @@ func _Broker_Statistic_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_Depth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DepthRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BrokerServer).Depth(m, &brokerDepthServer{stream})
+}
+
+type Broker_DepthServer interface {
+	Send(*MarketDepth) error
+	grpc.ServerStream
+}
+
+type brokerDepthServer struct {
+	grpc.ServerStream
+}
+
+func (x *brokerDepthServer) Send(m *MarketDepth) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Broker_Trades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ticker)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).Trades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/Trades",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).Trades(ctx, req.(*Ticker))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_ExchangeStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Client)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).ExchangeStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/ExchangeStreams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).ExchangeStreams(ctx, req.(*Client))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_Ledger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Client)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).Ledger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/Ledger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).Ledger(ctx, req.(*Client))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broker_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Broker_ServiceDesc is the grpc.ServiceDesc for Broker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,6 +441,10 @@ var Broker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProfile",
 			Handler:    _Broker_GetProfile_Handler,
 		},
+		{
+			MethodName: "GetDeal",
+			Handler:    _Broker_GetDeal_Handler,
+		},
 		{
 			MethodName: "Create",
 			Handler:    _Broker_Create_Handler,
@@ -199,11 +453,37 @@ var Broker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Cancel",
 			Handler:    _Broker_Cancel_Handler,
 		},
+		{
+			MethodName: "Modify",
+			Handler:    _Broker_Modify_Handler,
+		},
 		{
 			MethodName: "Statistic",
 			Handler:    _Broker_Statistic_Handler,
 		},
+		{
+			MethodName: "Trades",
+			Handler:    _Broker_Trades_Handler,
+		},
+		{
+			MethodName: "ExchangeStreams",
+			Handler:    _Broker_ExchangeStreams_Handler,
+		},
+		{
+			MethodName: "Ledger",
+			Handler:    _Broker_Ledger_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _Broker_Transfer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Depth",
+			Handler:       _Broker_Depth_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/broker.proto",
 }
@@ -2,12 +2,16 @@ package rpc
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
 	"github.com/marksartdev/trading/internal/exchange/delivery/rpc"
 )
 
@@ -17,30 +21,41 @@ const timeout = 10 * time.Second
 type ExchangeService struct {
 	brokerID int64
 	client   rpc.ExchangeClient
+	subs     []config.Subscription
 }
 
 // NewExchangeService creates new exchange service.
-func NewExchangeService(brokerID int64, client rpc.ExchangeClient) *ExchangeService {
-	return &ExchangeService{brokerID: brokerID, client: client}
+// Statistic is requested by subscriptions, without them only bars at base interval of exchange are received.
+func NewExchangeService(brokerID int64, client rpc.ExchangeClient, subs []config.Subscription) *ExchangeService {
+	return &ExchangeService{brokerID: brokerID, client: client, subs: subs}
 }
 
 // Statistic subscribes to statistic.
-func (e ExchangeService) Statistic(ctx context.Context, out chan broker.OHLCV) error {
-	in := rpc.BrokerID{ID: e.brokerID}
+func (e ExchangeService) Statistic(ctx context.Context, out chan broker.OHLCV, connected func()) error {
+	in := rpc.StatisticRequest{BrokerID: e.brokerID}
+	for _, sub := range e.subs {
+		in.Subscriptions = append(in.Subscriptions, &rpc.Subscription{
+			Ticker:   sub.Ticker,
+			Interval: sub.Interval.Nanoseconds(),
+		})
+	}
 
 	stream, err := e.client.Statistic(ctx, &in)
 	if err != nil {
 		return err
 	}
 
+	if err := established(stream); err != nil {
+		return err
+	}
+
+	connected()
+
 	for {
 		resp, err := stream.Recv()
 		if err != nil {
-			if s, ok := status.FromError(err); ok {
-				if s.Code() == codes.Canceled || s.Code() == codes.Unavailable {
-					close(out)
-					return nil
-				}
+			if status.Code(err) == codes.Canceled {
+				return nil
 			}
 			return err
 		}
@@ -50,10 +65,10 @@ func (e ExchangeService) Statistic(ctx context.Context, out chan broker.OHLCV) e
 			Ticker:   resp.GetTicker(),
 			Time:     time.Unix(resp.GetTime(), 0),
 			Interval: time.Duration(resp.GetInterval()),
-			Open:     resp.GetOpen(),
-			High:     resp.GetHigh(),
-			Low:      resp.GetLow(),
-			Close:    resp.GetClose(),
+			Open:     resp.GetOpen().Decimal(),
+			High:     resp.GetHigh().Decimal(),
+			Low:      resp.GetLow().Decimal(),
+			Close:    resp.GetClose().Decimal(),
 			Volume:   resp.GetVolume(),
 		}
 
@@ -66,18 +81,17 @@ func (e ExchangeService) Create(deal broker.Deal) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	price := deal.Price
-	if deal.Type == broker.Sell {
-		price = -price
-	}
-
 	in := rpc.Deal{
-		BrokerID: e.brokerID,
-		ClientID: deal.ClientID,
-		Ticker:   deal.Ticker,
-		Amount:   deal.Amount,
-		Time:     deal.Time.Unix(),
-		Price:    price,
+		BrokerID:    e.brokerID,
+		ClientID:    deal.ClientID,
+		Ticker:      deal.Ticker,
+		Side:        string(deal.Type),
+		Type:        string(deal.OrderType),
+		TimeInForce: string(deal.TimeInForce),
+		Amount:      deal.Amount,
+		Time:        deal.Time.Unix(),
+		Price:       rpc.NewDecimal(deal.Price),
+		StopPrice:   rpc.NewDecimal(deal.StopPrice),
 	}
 
 	resp, err := e.client.Create(ctx, &in)
@@ -106,46 +120,204 @@ func (e ExchangeService) Cancel(dealID int64) (bool, error) {
 	return resp.GetSuccess(), nil
 }
 
-// Results subscribes to result of deals.
-func (e ExchangeService) Results(ctx context.Context, out chan broker.Deal) error {
-	in := rpc.BrokerID{ID: e.brokerID}
+// ListOpen returns deals of the broker resting at exchange.
+func (e ExchangeService) ListOpen() ([]broker.Deal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := e.client.ListOpen(ctx, &rpc.BrokerID{ID: e.brokerID})
+	if err != nil {
+		return nil, err
+	}
+
+	deals := make([]broker.Deal, len(resp.GetDeals()))
+	for i, d := range resp.GetDeals() {
+		deals[i] = broker.Deal{
+			ID:          d.GetID(),
+			ClientID:    d.GetClientID(),
+			Ticker:      d.GetTicker(),
+			Type:        broker.DealType(d.GetSide()),
+			OrderType:   broker.OrderType(d.GetType()),
+			TimeInForce: broker.TimeInForce(d.GetTimeInForce()),
+			Amount:      d.GetAmount(),
+			Price:       d.GetPrice().Decimal(),
+			StopPrice:   d.GetStopPrice().Decimal(),
+			Status:      broker.DealStatusNew,
+			Time:        time.Unix(d.GetTime(), 0),
+		}
+	}
+
+	return deals, nil
+}
+
+// Modify sends deal modification to exchange service.
+func (e ExchangeService) Modify(dealID int64, price decimal.Decimal, amount int32) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	in := rpc.ModifyDeal{
+		ID:       dealID,
+		BrokerID: e.brokerID,
+		Price:    rpc.NewDecimal(price),
+		Amount:   amount,
+	}
+
+	resp, err := e.client.Modify(ctx, &in)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetSuccess(), nil
+}
+
+// Results subscribes to result of deals. Results after the given sequence are replayed first.
+// Stream is ended on a gap in sequence, so missed results are replayed on resubscription.
+func (e ExchangeService) Results(ctx context.Context, seq int64, out chan broker.Deal, connected func()) error {
+	in := rpc.ResultsRequest{BrokerID: e.brokerID, Seq: seq}
 
 	stream, err := e.client.Results(ctx, &in)
 	if err != nil {
 		return err
 	}
 
+	if err := established(stream); err != nil {
+		return err
+	}
+
+	connected()
+
+	for first := true; ; first = false {
+		resp, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+		}
+
+		// Exchange may drop results of slow broker, they are replayed after resubscription.
+		// Gap before the first result is not checked, it means results are out of exchange buffer.
+		if !first && resp.GetSeq() > seq+1 {
+			return fmt.Errorf("results %d-%d are missed", seq+1, resp.GetSeq()-1)
+		}
+
+		status := broker.DealStatusCompleted
+		if resp.GetPartial() {
+			status = broker.DealStatusNew
+		}
+
+		if resp.GetStatus() == string(broker.DealStatusExpired) {
+			status = broker.DealStatusExpired
+		}
+
+		deal := broker.Deal{
+			ID:          resp.GetID(),
+			ClientID:    resp.GetClientID(),
+			Ticker:      resp.GetTicker(),
+			Type:        broker.DealType(resp.GetSide()),
+			OrderType:   broker.OrderType(resp.GetType()),
+			TimeInForce: broker.TimeInForce(resp.GetTimeInForce()),
+			Amount:      resp.GetAmount(),
+			Partial:     resp.GetPartial(),
+			FillID:      resp.GetFillID(),
+			Seq:         resp.GetSeq(),
+			Price:       resp.GetPrice().Decimal(),
+			StopPrice:   resp.GetStopPrice().Decimal(),
+			Status:      status,
+			Time:        time.Unix(resp.GetTime(), 0),
+		}
+
+		out <- deal
+
+		seq = resp.GetSeq()
+	}
+}
+
+// Depth subscribes to market depth of a ticker. Out channel is closed when stream ends.
+func (e ExchangeService) Depth(ctx context.Context, ticker string, levels int32, out chan broker.Depth) error {
+	defer close(out)
+
+	in := rpc.DepthRequest{BrokerID: e.brokerID, Ticker: ticker, Levels: levels}
+
+	stream, err := e.client.Depth(ctx, &in)
+	if err != nil {
+		return err
+	}
+
 	for {
 		resp, err := stream.Recv()
 		if err != nil {
 			if s, ok := status.FromError(err); ok {
 				if s.Code() == codes.Canceled || s.Code() == codes.Unavailable {
-					close(out)
 					return nil
 				}
 			}
 			return err
 		}
 
-		dealType := broker.Buy
-		price := resp.GetPrice()
-		if price < 0 {
-			dealType = broker.Sell
-			price = -price
-		}
-
-		deal := broker.Deal{
-			ID:       resp.GetID(),
-			ClientID: resp.GetClientID(),
+		depth := broker.Depth{
 			Ticker:   resp.GetTicker(),
-			Type:     dealType,
-			Amount:   resp.GetAmount(),
-			Partial:  resp.GetPartial(),
-			Price:    price,
-			Status:   broker.DealStatusCompleted,
 			Time:     time.Unix(resp.GetTime(), 0),
+			Snapshot: resp.GetSnapshot(),
+			Bids:     depthLevels(resp.GetBids()),
+			Asks:     depthLevels(resp.GetAsks()),
 		}
 
-		out <- deal
+		select {
+		case <-ctx.Done():
+			return nil
+		case out <- depth:
+		}
+	}
+}
+
+// Converts price levels.
+func depthLevels(in []*rpc.Level) []broker.Level {
+	res := make([]broker.Level, len(in))
+	for i := range in {
+		res[i] = broker.Level{Price: in[i].GetPrice().Decimal(), Amount: in[i].GetAmount(), Orders: in[i].GetOrders()}
+	}
+
+	return res
+}
+
+// Waits until exchange establishes the stream. Exchange sends header as soon as it subscribes the broker.
+func established(stream grpc.ClientStream) error {
+	_, err := stream.Header()
+	return err
+}
+
+// Trades subscribes to trade tape.
+func (e ExchangeService) Trades(ctx context.Context, out chan broker.Trade, connected func()) error {
+	in := rpc.BrokerID{ID: e.brokerID}
+
+	stream, err := e.client.Trades(ctx, &in)
+	if err != nil {
+		return err
+	}
+
+	if err := established(stream); err != nil {
+		return err
+	}
+
+	connected()
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+		}
+
+		out <- broker.Trade{
+			ID:     resp.GetID(),
+			Ticker: resp.GetTicker(),
+			Price:  resp.GetPrice().Decimal(),
+			Amount: resp.GetAmount(),
+			Type:   broker.DealType(resp.GetSide()),
+			Time:   time.Unix(resp.GetTime(), 0),
+		}
 	}
 }
@@ -2,24 +2,36 @@ package rpc
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
 	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
 	"github.com/marksartdev/trading/internal/log"
 )
 
 type brokerServer struct {
-	logger  log.Logger
-	service broker.BrokerService
+	logger     log.Logger
+	service    broker.BrokerService
+	adminToken string
 	UnimplementedBrokerServer
 }
 
 const gRPC = "gRPC"
 
-// NewBrokerServer creates new broker server.
-func NewBrokerServer(logger log.Logger, service broker.BrokerService) BrokerServer {
-	return &brokerServer{logger: logger, service: service}
+const adminTokenKey = "x-admin-token"
+
+const errLimit = 10
+
+// NewBrokerServer creates new broker server. Transfers are available only with admin token.
+func NewBrokerServer(logger log.Logger, service broker.BrokerService, adminToken string) BrokerServer {
+	return &brokerServer{logger: logger, service: service, adminToken: adminToken}
 }
 
 // GetProfile returns client profile.
@@ -33,25 +45,33 @@ func (b brokerServer) GetProfile(_ context.Context, client *Client) (*Profile, e
 	positions := make([]*Position, len(profile.Positions))
 	for i := range positions {
 		positions[i] = &Position{
-			Ticker: profile.Positions[i].Ticker,
-			Amount: profile.Positions[i].Amount,
+			Ticker:    profile.Positions[i].Ticker,
+			Amount:    profile.Positions[i].Amount,
+			Available: profile.Positions[i].Available,
 		}
 	}
 
 	deals := make([]*Deal, len(profile.OpenDeals))
 	for i := range deals {
 		deals[i] = &Deal{
-			ID:     profile.OpenDeals[i].ID,
-			Ticker: profile.OpenDeals[i].Ticker,
-			Type:   string(profile.OpenDeals[i].Type),
-			Amount: profile.OpenDeals[i].Amount,
-			Price:  profile.OpenDeals[i].Price,
-			Time:   profile.OpenDeals[i].Time.Unix(),
+			ID:          profile.OpenDeals[i].ID,
+			Ticker:      profile.OpenDeals[i].Ticker,
+			Type:        string(profile.OpenDeals[i].Type),
+			OrderType:   string(profile.OpenDeals[i].OrderType),
+			TimeInForce: string(profile.OpenDeals[i].TimeInForce),
+			Amount:      profile.OpenDeals[i].Amount,
+			Filled:      profile.OpenDeals[i].Filled,
+			Price:       NewDecimal(profile.OpenDeals[i].Price),
+			AvgPrice:    NewDecimal(profile.OpenDeals[i].AvgPrice),
+			StopPrice:   NewDecimal(profile.OpenDeals[i].StopPrice),
+			Status:      string(profile.OpenDeals[i].Status),
+			Time:        profile.OpenDeals[i].Time.Unix(),
 		}
 	}
 
 	resp := Profile{
-		Balance:   profile.Balance,
+		Balance:   NewDecimal(profile.Balance),
+		Available: NewDecimal(profile.Available),
 		Positions: positions,
 		Deals:     deals,
 	}
@@ -60,6 +80,31 @@ func (b brokerServer) GetProfile(_ context.Context, client *Client) (*Profile, e
 	return &resp, nil
 }
 
+// GetDeal returns deal of client.
+func (b brokerServer) GetDeal(_ context.Context, req *DealRequest) (*Deal, error) {
+	deal, err := b.service.GetDeal(req.GetClient().GetLogin(), req.GetDealID().GetID())
+	if err != nil {
+		b.logger.Error(gRPC, err)
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	b.logRequest(req.GetClient().GetLogin(), "GetDeal")
+	return &Deal{
+		ID:          deal.ID,
+		Ticker:      deal.Ticker,
+		Type:        string(deal.Type),
+		OrderType:   string(deal.OrderType),
+		TimeInForce: string(deal.TimeInForce),
+		Amount:      deal.Amount,
+		Filled:      deal.Filled,
+		Price:       NewDecimal(deal.Price),
+		AvgPrice:    NewDecimal(deal.AvgPrice),
+		StopPrice:   NewDecimal(deal.StopPrice),
+		Status:      string(deal.Status),
+		Time:        deal.Time.Unix(),
+	}, nil
+}
+
 // Create creates deal.
 func (b brokerServer) Create(_ context.Context, deal *CreateDeal) (*DealID, error) {
 	client, err := b.service.GetClient(deal.GetClient().GetLogin())
@@ -69,26 +114,43 @@ func (b brokerServer) Create(_ context.Context, deal *CreateDeal) (*DealID, erro
 	}
 
 	d := broker.Deal{
-		ClientID: client.ID,
-		Ticker:   deal.GetTicker(),
-		Type:     broker.DealType(deal.GetType()),
-		Amount:   deal.GetAmount(),
-		Price:    deal.GetPrice(),
-		Time:     time.Now(),
+		ClientID:    client.ID,
+		Ticker:      deal.GetTicker(),
+		Type:        broker.DealType(deal.GetType()),
+		OrderType:   broker.OrderType(deal.GetOrderType()),
+		TimeInForce: broker.TimeInForce(deal.GetTimeInForce()),
+		Amount:      deal.GetAmount(),
+		Price:       deal.GetPrice().Decimal(),
+		StopPrice:   deal.GetStopPrice().Decimal(),
+		Time:        time.Now(),
+	}
+
+	if d.OrderType == "" {
+		d.OrderType = broker.Limit
+	}
+
+	if d.TimeInForce == "" {
+		d.TimeInForce = broker.GTC
 	}
 
 	d, err = b.service.Create(d)
 	if err != nil {
 		b.logger.Error(gRPC, err)
-		return nil, err
+
+		return nil, rejection(err)
 	}
 
 	b.logRequest(deal.GetClient().GetLogin(), "Create")
 	return &DealID{ID: d.ID}, nil
 }
 
-// Cancel cancels deal.
+// Cancel cancels deal of client.
 func (b brokerServer) Cancel(_ context.Context, deal *CancelDeal) (*Success, error) {
+	if _, err := b.service.GetDeal(deal.GetClient().GetLogin(), deal.GetDealID().GetID()); err != nil {
+		b.logger.Error(gRPC, err)
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
 	ok, err := b.service.Cancel(deal.GetDealID().GetID())
 	if err != nil {
 		b.logger.Error(gRPC, err)
@@ -99,9 +161,26 @@ func (b brokerServer) Cancel(_ context.Context, deal *CancelDeal) (*Success, err
 	return &Success{OK: ok}, nil
 }
 
+// Modify changes price and/or amount of deal of client.
+func (b brokerServer) Modify(_ context.Context, deal *ModifyDeal) (*Success, error) {
+	if _, err := b.service.GetDeal(deal.GetClient().GetLogin(), deal.GetDealID().GetID()); err != nil {
+		b.logger.Error(gRPC, err)
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	ok, err := b.service.Modify(deal.GetDealID().GetID(), deal.GetPrice().Decimal(), deal.GetAmount())
+	if err != nil {
+		b.logger.Error(gRPC, err)
+		return nil, rejection(err)
+	}
+
+	b.logRequest(deal.GetClient().GetLogin(), "Modify")
+	return &Success{OK: ok}, nil
+}
+
 // Statistic returns ticker statistics.
 func (b brokerServer) Statistic(_ context.Context, ticker *Ticker) (*OHLCV, error) {
-	stats, err := b.service.History(ticker.GetName())
+	stats, err := b.service.History(ticker.GetName(), time.Duration(ticker.GetInterval()))
 	if err != nil {
 		b.logger.Error(gRPC, err)
 		return nil, err
@@ -111,11 +190,11 @@ func (b brokerServer) Statistic(_ context.Context, ticker *Ticker) (*OHLCV, erro
 	for i := range prices {
 		prices[i] = &Price{
 			Time:     stats[i].Time.Unix(),
-			Interval: int32(stats[i].Interval.Nanoseconds()),
-			Open:     stats[i].Open,
-			High:     stats[i].High,
-			Low:      stats[i].Low,
-			Close:    stats[i].Close,
+			Interval: stats[i].Interval.Nanoseconds(),
+			Open:     NewDecimal(stats[i].Open),
+			High:     NewDecimal(stats[i].High),
+			Low:      NewDecimal(stats[i].Low),
+			Close:    NewDecimal(stats[i].Close),
 			Vol:      stats[i].Volume,
 		}
 	}
@@ -128,6 +207,173 @@ func (b brokerServer) Statistic(_ context.Context, ticker *Ticker) (*OHLCV, erro
 	return &resp, nil
 }
 
+// Trades returns last trades of a ticker.
+func (b brokerServer) Trades(_ context.Context, ticker *Ticker) (*Tape, error) {
+	trades, err := b.service.Tape(ticker.GetName())
+	if err != nil {
+		b.logger.Error(gRPC, err)
+		return nil, err
+	}
+
+	resp := Tape{Trades: make([]*Trade, len(trades))}
+	for i := range trades {
+		resp.Trades[i] = &Trade{
+			ID:     trades[i].ID,
+			Ticker: trades[i].Ticker,
+			Price:  NewDecimal(trades[i].Price),
+			Amount: trades[i].Amount,
+			Type:   string(trades[i].Type),
+			Time:   trades[i].Time.Unix(),
+		}
+	}
+
+	b.logRequest(ticker.GetClient().GetLogin(), "Trades")
+	return &resp, nil
+}
+
+// Ledger returns cash movements of client with balance after each of them.
+func (b brokerServer) Ledger(_ context.Context, client *Client) (*CashMovements, error) {
+	entries, err := b.service.Ledger(client.GetLogin())
+	if err != nil {
+		b.logger.Error(gRPC, err)
+		return nil, err
+	}
+
+	var balance decimal.Decimal
+
+	resp := CashMovements{Movements: make([]*CashMovement, len(entries))}
+	for i := range entries {
+		balance += entries[i].Change()
+		resp.Movements[i] = cashMovement(entries[i])
+		resp.Movements[i].Balance = NewDecimal(balance)
+	}
+
+	b.logRequest(client.GetLogin(), "Ledger")
+	return &resp, nil
+}
+
+// Transfer posts deposit, withdrawal or adjustment of client cash. It is an admin request.
+func (b brokerServer) Transfer(ctx context.Context, req *TransferRequest) (*CashMovement, error) {
+	if err := b.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	entry, err := b.service.Transfer(
+		req.GetClient().GetLogin(), broker.EntryType(req.GetType()), req.GetAmount().Decimal(), req.GetMemo(),
+	)
+	if err != nil {
+		b.logger.Error(gRPC, err)
+		return nil, rejection(err)
+	}
+
+	b.logRequest(req.GetClient().GetLogin(), "Transfer")
+	return cashMovement(entry), nil
+}
+
+// Converts ledger entry.
+func cashMovement(entry broker.Entry) *CashMovement {
+	return &CashMovement{
+		ID:     entry.ID,
+		Type:   string(entry.Type),
+		Debit:  string(entry.Debit),
+		Credit: string(entry.Credit),
+		Amount: NewDecimal(entry.Amount),
+		DealID: entry.DealID,
+		Memo:   entry.Memo,
+		Time:   entry.Time.Unix(),
+	}
+}
+
+// Checks admin token of request.
+func (b brokerServer) admin(ctx context.Context) error {
+	if b.adminToken == "" {
+		return status.Error(codes.PermissionDenied, "admin requests are disabled")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, token := range md.Get(adminTokenKey) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(b.adminToken)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.PermissionDenied, "invalid admin token")
+}
+
+// ExchangeStreams returns state of broker streams from exchange.
+func (b brokerServer) ExchangeStreams(_ context.Context, client *Client) (*Streams, error) {
+	resp := Streams{States: make(map[string]string)}
+	for name, state := range b.service.Streams() {
+		resp.States[name] = string(state)
+	}
+
+	b.logRequest(client.GetLogin(), "ExchangeStreams")
+	return &resp, nil
+}
+
+// Depth streams market depth of a ticker.
+func (b brokerServer) Depth(req *DepthRequest, stream Broker_DepthServer) error {
+	var errCount int
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	ch := make(chan broker.Depth, 100)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- b.service.Depth(ctx, req.GetTicker(), req.GetLevels(), ch)
+	}()
+
+	b.logRequest(req.GetClient().GetLogin(), "Depth")
+
+	for d := range ch {
+		res := MarketDepth{
+			Ticker:   d.Ticker,
+			Time:     d.Time.Unix(),
+			Snapshot: d.Snapshot,
+			Bids:     levels(d.Bids),
+			Asks:     levels(d.Asks),
+		}
+
+		if err := stream.Send(&res); err != nil {
+			b.logger.Error(gRPC, err)
+
+			errCount++
+			if errCount > errLimit {
+				return err
+			}
+		}
+	}
+
+	if err := <-errCh; err != nil {
+		b.logger.Error(gRPC, err)
+		return err
+	}
+
+	return nil
+}
+
+// Converts price levels.
+func levels(in []broker.Level) []*Level {
+	res := make([]*Level, len(in))
+	for i := range in {
+		res[i] = &Level{Price: NewDecimal(in[i].Price), Amount: in[i].Amount, Orders: in[i].Orders}
+	}
+
+	return res
+}
+
+// Returns descriptive status of deal rejected by risk checks.
+func rejection(err error) error {
+	var riskErr broker.RiskError
+	if errors.As(err, &riskErr) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return err
+}
+
 func (b brokerServer) logRequest(login string, request string) {
 	b.logger.Info(gRPC, fmt.Sprintf("%q request from client %s wath handled", request, login))
 }
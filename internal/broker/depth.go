@@ -0,0 +1,24 @@
+package broker
+
+import (
+	"time"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Level aggregated price level of a book side.
+type Level struct {
+	Price  decimal.Decimal
+	Amount int32
+	Orders int32
+}
+
+// Depth market depth of a ticker. Snapshot contains top levels of both sides,
+// otherwise only changed levels are sent and a level with zero amount is removed.
+type Depth struct {
+	Ticker   string
+	Time     time.Time
+	Snapshot bool
+	Bids     []Level
+	Asks     []Level
+}
@@ -0,0 +1,23 @@
+package broker
+
+import "github.com/marksartdev/trading/internal/decimal"
+
+// Hold reservation of client funds for an open deal.
+// Buy deal holds cash for its rest, sell deal holds shares of ticker.
+type Hold struct {
+	DealID   int64
+	ClientID int64
+	Ticker   string
+	Type     DealType
+	Amount   int32
+	Cash     decimal.Decimal
+}
+
+// HoldRepo hold repository.
+type HoldRepo interface {
+	Add(hold Hold) error
+	Get(dealID int64) (Hold, bool, error)
+	GetByClient(clientID int64) ([]Hold, error)
+	Update(hold Hold) error
+	Release(dealID int64) error
+}
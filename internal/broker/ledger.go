@@ -0,0 +1,77 @@
+package broker
+
+import (
+	"time"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// EntryType reason of cash movement.
+type EntryType string
+
+const (
+	// EntryFunding initial funding of a new client.
+	EntryFunding EntryType = "FUNDING"
+	// EntryTrade debit of a buy or credit of a sell.
+	EntryTrade EntryType = "TRADE"
+	// EntryFee fee of a fill.
+	EntryFee EntryType = "FEE"
+	// EntryDeposit money deposited by client.
+	EntryDeposit EntryType = "DEPOSIT"
+	// EntryWithdrawal money withdrawn by client.
+	EntryWithdrawal EntryType = "WITHDRAWAL"
+	// EntryAdjustment manual correction of balance.
+	EntryAdjustment EntryType = "ADJUSTMENT"
+)
+
+// Account ledger account.
+type Account string
+
+const (
+	// AccountCash cash of client.
+	AccountCash Account = "CASH"
+	// AccountBank money outside of broker, source of deposits and target of withdrawals.
+	AccountBank Account = "BANK"
+	// AccountMarket settlements with exchange.
+	AccountMarket Account = "MARKET"
+	// AccountFees fee income of broker.
+	AccountFees Account = "FEES"
+	// AccountEquity funds of broker, source of funding and adjustments.
+	AccountEquity Account = "EQUITY"
+)
+
+// Entry immutable journal entry of client ledger. Amount is debited to one account and credited to another,
+// so every entry balances. Cash of client grows by debits and shrinks by credits.
+type Entry struct {
+	ID       int64
+	ClientID int64
+	Type     EntryType
+	Debit    Account
+	Credit   Account
+	Amount   decimal.Decimal
+	DealID   int64
+	FillID   int64
+	Memo     string
+	Time     time.Time
+}
+
+// Change returns change of client cash made by entry.
+func (e Entry) Change() decimal.Decimal {
+	switch {
+	case e.Debit == AccountCash && e.Credit != AccountCash:
+		return e.Amount
+	case e.Credit == AccountCash && e.Debit != AccountCash:
+		return -e.Amount
+	}
+
+	return 0
+}
+
+// LedgerRepo ledger repository. Post adds entry and applies it to cached balance of client,
+// Open adds client with its funding entry, Rebuild recalculates cached balances of all clients from the ledger.
+type LedgerRepo interface {
+	Post(entry *Entry) error
+	Open(client *Client, funding *Entry) error
+	Get(clientID int64) ([]Entry, error)
+	Rebuild() error
+}
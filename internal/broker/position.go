@@ -1,10 +1,11 @@
 package broker
 
-// Position user tickers.
+// Position user tickers. Available amount is set in profile only.
 type Position struct {
-	ClientID int64
-	Ticker   string
-	Amount   int32
+	ClientID  int64
+	Ticker    string
+	Amount    int32
+	Available int32
 }
 
 // PositionRepo position repository.
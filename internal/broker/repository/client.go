@@ -6,13 +6,14 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
 )
 
 // Client entity.
 type Client struct {
-	ID        int64   `gorm:"primarykey"`
-	Login     string  `gorm:"not null"`
-	Balance   float64 `gorm:"not null"`
+	ID        int64           `gorm:"primarykey"`
+	Login     string          `gorm:"not null"`
+	Balance   decimal.Decimal `gorm:"not null"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -63,8 +64,28 @@ func (c clientRepo) Get(login string) (broker.Client, bool, error) {
 	}, true, nil
 }
 
+// GetByID returns client from repository by identifier.
+func (c clientRepo) GetByID(clientID int64) (broker.Client, bool, error) {
+	var entity Client
+
+	err := c.db.Where(Client{ID: clientID}).First(&entity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return broker.Client{}, false, nil
+		}
+
+		return broker.Client{}, false, err
+	}
+
+	return broker.Client{
+		ID:      entity.ID,
+		Login:   entity.Login,
+		Balance: entity.Balance,
+	}, true, nil
+}
+
 // SumBalance adds new sum to client balance.
-func (c clientRepo) SumBalance(clientID int64, amount float64) error {
+func (c clientRepo) SumBalance(clientID int64, amount decimal.Decimal) error {
 	return c.db.
 		Model(&Client{}).
 		Where(Client{ID: clientID}).
@@ -73,7 +94,7 @@ func (c clientRepo) SumBalance(clientID int64, amount float64) error {
 }
 
 // SubBalance removes sum from client balance.
-func (c clientRepo) SubBalance(clientID int64, amount float64) error {
+func (c clientRepo) SubBalance(clientID int64, amount decimal.Decimal) error {
 	return c.db.
 		Model(&Client{}).
 		Where(Client{ID: clientID}).
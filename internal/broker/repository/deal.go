@@ -6,21 +6,47 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
 )
 
 // Deal entity.
 type Deal struct {
-	ID        int64             `gorm:"primarykey"`
-	ClientID  int64             `gorm:"not null"`
-	Ticker    string            `gorm:"not null"`
-	Vol       int32             `gorm:"not null"`
-	Partial   bool              `gorm:"not null"`
-	Price     float64           `gorm:"not null"`
-	Type      broker.DealType   `gorm:"not null"`
-	Status    broker.DealStatus `gorm:"not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID          int64              `gorm:"primarykey"`
+	ClientID    int64              `gorm:"not null"`
+	Ticker      string             `gorm:"not null"`
+	Vol         int32              `gorm:"not null"`
+	Filled      int32              `gorm:"not null;default:0"`
+	Partial     bool               `gorm:"not null"`
+	Price       decimal.Decimal    `gorm:"not null"`
+	AvgPrice    decimal.Decimal    `gorm:"not null;default:0"`
+	StopPrice   decimal.Decimal    `gorm:"not null;default:0"`
+	Type        broker.DealType    `gorm:"not null"`
+	OrderType   broker.OrderType   `gorm:"not null;default:LIMIT"`
+	TimeInForce broker.TimeInForce `gorm:"not null;default:GTC"`
+	Status      broker.DealStatus  `gorm:"not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+// Converts entity to domain deal.
+func (e Deal) deal() broker.Deal {
+	return broker.Deal{
+		ID:          e.ID,
+		ClientID:    e.ClientID,
+		Ticker:      e.Ticker,
+		Type:        e.Type,
+		OrderType:   e.OrderType,
+		TimeInForce: e.TimeInForce,
+		Amount:      e.Vol,
+		Filled:      e.Filled,
+		Partial:     e.Partial,
+		Price:       e.Price,
+		AvgPrice:    e.AvgPrice,
+		StopPrice:   e.StopPrice,
+		Status:      e.Status,
+		Time:        e.CreatedAt,
+	}
 }
 
 // Deal repository.
@@ -36,18 +62,37 @@ func NewDealRepo(db *gorm.DB) broker.DealRepo {
 // Add adds deal to repository.
 func (d dealRepo) Add(deal broker.Deal) error {
 	entity := Deal{
-		ID:       deal.ID,
-		ClientID: deal.ClientID,
-		Ticker:   deal.Ticker,
-		Vol:      deal.Amount,
-		Price:    deal.Price,
-		Type:     deal.Type,
-		Status:   deal.Status,
+		ID:          deal.ID,
+		ClientID:    deal.ClientID,
+		Ticker:      deal.Ticker,
+		Vol:         deal.Amount,
+		Price:       deal.Price,
+		StopPrice:   deal.StopPrice,
+		Type:        deal.Type,
+		OrderType:   deal.OrderType,
+		TimeInForce: deal.TimeInForce,
+		Status:      deal.Status,
 	}
 
 	return d.db.Create(&entity).Error
 }
 
+// Get returns deal from repository.
+func (d dealRepo) Get(dealID int64) (broker.Deal, bool, error) {
+	var entity Deal
+
+	err := d.db.Where(Deal{ID: dealID}).First(&entity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return broker.Deal{}, false, nil
+		}
+
+		return broker.Deal{}, false, err
+	}
+
+	return entity.deal(), true, nil
+}
+
 // GetOpened returns opened deals.
 func (d dealRepo) GetOpened(clientID int64) ([]broker.Deal, error) {
 	var entities []Deal
@@ -59,31 +104,63 @@ func (d dealRepo) GetOpened(clientID int64) ([]broker.Deal, error) {
 
 	deals := make([]broker.Deal, len(entities))
 	for i := range deals {
-		deals[i] = broker.Deal{
-			ID:       entities[i].ID,
-			ClientID: entities[i].ClientID,
-			Ticker:   entities[i].Ticker,
-			Type:     entities[i].Type,
-			Amount:   entities[i].Vol,
-			Price:    entities[i].Price,
-			Status:   entities[i].Status,
-			Time:     entities[i].CreatedAt,
-		}
+		deals[i] = entities[i].deal()
+	}
+
+	return deals, nil
+}
+
+// ListOpened returns opened deals of all clients.
+func (d dealRepo) ListOpened() ([]broker.Deal, error) {
+	var entities []Deal
+
+	err := d.db.Where(Deal{Status: broker.DealStatusNew}).Order("id").Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	deals := make([]broker.Deal, len(entities))
+	for i := range deals {
+		deals[i] = entities[i].deal()
 	}
 
 	return deals, nil
 }
 
-// Update updates deal.
-func (d dealRepo) Update(deal broker.Deal) error {
-	return d.db.Where(Deal{ID: deal.ID}).Updates(Deal{
-		Vol:     deal.Amount,
-		Partial: deal.Partial,
-		Price:   deal.Price,
-		Status:  deal.Status,
+// Update applies fill to deal. It accumulates filled amount and average price.
+func (d dealRepo) Update(fill broker.Deal) error {
+	return d.db.Model(&Deal{}).Where(Deal{ID: fill.ID}).Updates(map[string]interface{}{
+		"avg_price": gorm.Expr("(avg_price * filled + ?) / (filled + ?)", fill.Price.Mul(fill.Amount), fill.Amount),
+		"filled":    gorm.Expr("filled + ?", fill.Amount),
+		"partial":   fill.Partial,
+		"status":    fill.Status,
 	}).Error
 }
 
+// Modify changes price and/or remaining amount of deal, zero values are kept.
+func (d dealRepo) Modify(dealID int64, price decimal.Decimal, amount int32) error {
+	updates := make(map[string]interface{})
+
+	if price != 0 {
+		updates["price"] = price
+	}
+
+	if amount != 0 {
+		updates["vol"] = gorm.Expr("filled + ?", amount)
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return d.db.Model(&Deal{}).Where(Deal{ID: dealID}).Updates(updates).Error
+}
+
+// Delete deletes deal permanently.
+func (d dealRepo) Delete(dealID int64) error {
+	return d.db.Unscoped().Where(Deal{ID: dealID}).Delete(&Deal{}).Error
+}
+
 // UpdateStatus updates deal status.
 func (d dealRepo) UpdateStatus(dealID int64, status broker.DealStatus) error {
 	return d.db.Model(Deal{}).Where(Deal{ID: dealID}).Update("status", status).Error
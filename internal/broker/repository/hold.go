@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Hold entity.
+type Hold struct {
+	DealID   int64           `gorm:"primarykey;autoIncrement:false"`
+	ClientID int64           `gorm:"not null;index"`
+	Ticker   string          `gorm:"not null"`
+	Type     broker.DealType `gorm:"not null"`
+	Vol      int32           `gorm:"not null"`
+	Cash     decimal.Decimal `gorm:"not null;default:0"`
+}
+
+// Converts entity to domain hold.
+func (e Hold) hold() broker.Hold {
+	return broker.Hold{
+		DealID:   e.DealID,
+		ClientID: e.ClientID,
+		Ticker:   e.Ticker,
+		Type:     e.Type,
+		Amount:   e.Vol,
+		Cash:     e.Cash,
+	}
+}
+
+// Hold repository.
+type holdRepo struct {
+	db *gorm.DB
+}
+
+// NewHoldRepo creates new hold repository.
+func NewHoldRepo(db *gorm.DB) broker.HoldRepo {
+	return holdRepo{db: db}
+}
+
+// Add adds hold to repository.
+func (h holdRepo) Add(hold broker.Hold) error {
+	entity := Hold{
+		DealID:   hold.DealID,
+		ClientID: hold.ClientID,
+		Ticker:   hold.Ticker,
+		Type:     hold.Type,
+		Vol:      hold.Amount,
+		Cash:     hold.Cash,
+	}
+
+	return h.db.Create(&entity).Error
+}
+
+// Get returns hold of deal.
+func (h holdRepo) Get(dealID int64) (broker.Hold, bool, error) {
+	var entity Hold
+
+	err := h.db.Where(Hold{DealID: dealID}).First(&entity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return broker.Hold{}, false, nil
+		}
+
+		return broker.Hold{}, false, err
+	}
+
+	return entity.hold(), true, nil
+}
+
+// GetByClient returns holds of client ordered by deal.
+func (h holdRepo) GetByClient(clientID int64) ([]broker.Hold, error) {
+	var entities []Hold
+
+	err := h.db.Where(Hold{ClientID: clientID}).Order("deal_id").Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	holds := make([]broker.Hold, len(entities))
+	for i := range holds {
+		holds[i] = entities[i].hold()
+	}
+
+	return holds, nil
+}
+
+// Update updates reserved amount and cash of hold.
+func (h holdRepo) Update(hold broker.Hold) error {
+	return h.db.Model(&Hold{}).
+		Where(Hold{DealID: hold.DealID}).
+		Updates(map[string]interface{}{"vol": hold.Amount, "cash": hold.Cash}).Error
+}
+
+// Release deletes hold of deal.
+func (h holdRepo) Release(dealID int64) error {
+	return h.db.Where(Hold{DealID: dealID}).Delete(&Hold{}).Error
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Memo of entry which opens ledger of a client funded before the ledger was kept.
+const openingMemo = "opening balance"
+
+// Entry entity. Entries are only added, they are never changed or deleted.
+type Entry struct {
+	ID       int64            `gorm:"primarykey"`
+	ClientID int64            `gorm:"not null;index"`
+	Type     broker.EntryType `gorm:"not null"`
+	Debit    broker.Account   `gorm:"not null"`
+	Credit   broker.Account   `gorm:"not null"`
+	Amount   decimal.Decimal  `gorm:"not null"`
+	DealID   int64            `gorm:"not null;default:0"`
+	FillID   int64            `gorm:"not null;default:0"`
+	Memo     string           `gorm:"not null;default:''"`
+	Time     time.Time        `gorm:"not null"`
+}
+
+// Converts entity to domain entry.
+func (e Entry) entry() broker.Entry {
+	return broker.Entry{
+		ID:       e.ID,
+		ClientID: e.ClientID,
+		Type:     e.Type,
+		Debit:    e.Debit,
+		Credit:   e.Credit,
+		Amount:   e.Amount,
+		DealID:   e.DealID,
+		FillID:   e.FillID,
+		Memo:     e.Memo,
+		Time:     e.Time,
+	}
+}
+
+// Ledger repository.
+type ledgerRepo struct {
+	db *gorm.DB
+}
+
+// NewLedgerRepo creates new ledger repository.
+func NewLedgerRepo(db *gorm.DB) broker.LedgerRepo {
+	return ledgerRepo{db: db}
+}
+
+// Post adds entry and applies it to cached balance of client in a single transaction.
+func (l ledgerRepo) Post(entry *broker.Entry) error {
+	return l.db.Transaction(func(tx *gorm.DB) error {
+		return post(tx, entry)
+	})
+}
+
+// Open adds client with its funding entry in a single transaction.
+func (l ledgerRepo) Open(client *broker.Client, funding *broker.Entry) error {
+	return l.db.Transaction(func(tx *gorm.DB) error {
+		if err := (clientRepo{db: tx}).Add(client); err != nil {
+			return err
+		}
+
+		funding.ClientID = client.ID
+		if err := post(tx, funding); err != nil {
+			return err
+		}
+
+		client.Balance += funding.Change()
+
+		return nil
+	})
+}
+
+// Adds entry and changes balance of client by transaction.
+func post(tx *gorm.DB, entry *broker.Entry) error {
+	entity := Entry{
+		ClientID: entry.ClientID,
+		Type:     entry.Type,
+		Debit:    entry.Debit,
+		Credit:   entry.Credit,
+		Amount:   entry.Amount,
+		DealID:   entry.DealID,
+		FillID:   entry.FillID,
+		Memo:     entry.Memo,
+		Time:     entry.Time,
+	}
+
+	if err := tx.Create(&entity).Error; err != nil {
+		return err
+	}
+
+	entry.ID = entity.ID
+
+	return clientRepo{db: tx}.SumBalance(entry.ClientID, entry.Change())
+}
+
+// Get returns entries of client in order they were posted.
+func (l ledgerRepo) Get(clientID int64) ([]broker.Entry, error) {
+	var entities []Entry
+
+	err := l.db.Where(Entry{ClientID: clientID}).Order("id").Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]broker.Entry, len(entities))
+	for i := range entries {
+		entries[i] = entities[i].entry()
+	}
+
+	return entries, nil
+}
+
+// Rebuild recalculates balances of clients from the ledger.
+// Clients funded before the ledger was kept get an opening adjustment by their balance first.
+func (l ledgerRepo) Rebuild() error {
+	return l.db.Transaction(func(tx *gorm.DB) error {
+		var clients []Client
+
+		err := tx.Where("NOT EXISTS (SELECT 1 FROM entries WHERE entries.client_id = clients.id)").
+			Find(&clients).Error
+		if err != nil {
+			return err
+		}
+
+		for _, client := range clients {
+			if client.Balance == 0 {
+				continue
+			}
+
+			entity := Entry{
+				ClientID: client.ID,
+				Type:     broker.EntryAdjustment,
+				Debit:    broker.AccountCash,
+				Credit:   broker.AccountEquity,
+				Amount:   client.Balance.Abs(),
+				Memo:     openingMemo,
+				Time:     time.Now(),
+			}
+
+			if client.Balance < 0 {
+				entity.Debit, entity.Credit = entity.Credit, entity.Debit
+			}
+
+			if err := tx.Create(&entity).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec(
+			`UPDATE clients SET balance = COALESCE((
+				SELECT SUM(CASE WHEN debit = ? THEN amount WHEN credit = ? THEN -amount ELSE 0 END)
+				FROM entries WHERE entries.client_id = clients.id
+			), 0)`,
+			broker.AccountCash, broker.AccountCash,
+		).Error
+	})
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Client repository.
+type clientRepo struct {
+	mu      *sync.RWMutex
+	lastID  int64
+	clients map[int64]broker.Client
+	logins  map[string]int64
+}
+
+// NewClientRepo creates new in-memory client repository.
+func NewClientRepo() broker.ClientRepo {
+	return &clientRepo{
+		mu:      &sync.RWMutex{},
+		clients: make(map[int64]broker.Client),
+		logins:  make(map[string]int64),
+	}
+}
+
+// Add adds client ot repository.
+func (c *clientRepo) Add(client *broker.Client) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastID++
+	client.ID = c.lastID
+
+	c.clients[client.ID] = *client
+	c.logins[client.Login] = client.ID
+
+	return nil
+}
+
+// Get returns client from repository.
+func (c *clientRepo) Get(login string) (broker.Client, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	id, ok := c.logins[login]
+	if !ok {
+		return broker.Client{}, false, nil
+	}
+
+	return c.clients[id], true, nil
+}
+
+// GetByID returns client from repository by identifier.
+func (c *clientRepo) GetByID(clientID int64) (broker.Client, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	client, ok := c.clients[clientID]
+
+	return client, ok, nil
+}
+
+// SumBalance adds new sum to client balance.
+func (c *clientRepo) SumBalance(clientID int64, amount decimal.Decimal) error {
+	return c.change(clientID, amount)
+}
+
+// SubBalance removes sum from client balance.
+func (c *clientRepo) SubBalance(clientID int64, amount decimal.Decimal) error {
+	return c.change(clientID, -amount)
+}
+
+// Changes client balance.
+func (c *clientRepo) change(clientID int64, amount decimal.Decimal) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	client, ok := c.clients[clientID]
+	if !ok {
+		return nil
+	}
+
+	client.Balance += amount
+	c.clients[clientID] = client
+
+	return nil
+}
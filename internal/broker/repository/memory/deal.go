@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Deal repository.
+type dealRepo struct {
+	mu    *sync.RWMutex
+	deals map[int64]broker.Deal
+}
+
+// NewDealRepo creates new in-memory deal repository.
+func NewDealRepo() broker.DealRepo {
+	return &dealRepo{mu: &sync.RWMutex{}, deals: make(map[int64]broker.Deal)}
+}
+
+// Add adds deal to repository.
+func (d *dealRepo) Add(deal broker.Deal) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.deals[deal.ID] = deal
+
+	return nil
+}
+
+// Get returns deal from repository.
+func (d *dealRepo) Get(dealID int64) (broker.Deal, bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	deal, ok := d.deals[dealID]
+
+	return deal, ok, nil
+}
+
+// GetOpened returns opened deals ordered by identifier.
+func (d *dealRepo) GetOpened(clientID int64) ([]broker.Deal, error) {
+	var deals []broker.Deal
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, deal := range d.deals {
+		if deal.ClientID == clientID && deal.Status == broker.DealStatusNew {
+			deals = append(deals, deal)
+		}
+	}
+
+	sort.Slice(deals, func(i, j int) bool {
+		return deals[i].ID < deals[j].ID
+	})
+
+	return deals, nil
+}
+
+// ListOpened returns opened deals of all clients ordered by identifier.
+func (d *dealRepo) ListOpened() ([]broker.Deal, error) {
+	var deals []broker.Deal
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, deal := range d.deals {
+		if deal.Status == broker.DealStatusNew {
+			deals = append(deals, deal)
+		}
+	}
+
+	sort.Slice(deals, func(i, j int) bool {
+		return deals[i].ID < deals[j].ID
+	})
+
+	return deals, nil
+}
+
+// Update applies fill to deal. It accumulates filled amount and average price.
+func (d *dealRepo) Update(fill broker.Deal) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deal, ok := d.deals[fill.ID]
+	if !ok {
+		return nil
+	}
+
+	filled := deal.Filled + fill.Amount
+	deal.AvgPrice = (deal.AvgPrice.Mul(deal.Filled) + fill.Price.Mul(fill.Amount)).Div(int64(filled))
+	deal.Filled = filled
+	deal.Partial = fill.Partial
+	deal.Status = fill.Status
+	d.deals[fill.ID] = deal
+
+	return nil
+}
+
+// Delete deletes deal.
+func (d *dealRepo) Delete(dealID int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.deals, dealID)
+
+	return nil
+}
+
+// UpdateStatus updates deal status.
+func (d *dealRepo) UpdateStatus(dealID int64, status broker.DealStatus) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deal, ok := d.deals[dealID]
+	if !ok {
+		return nil
+	}
+
+	deal.Status = status
+	d.deals[dealID] = deal
+
+	return nil
+}
+
+// Modify changes price and/or remaining amount of deal, zero values are kept.
+func (d *dealRepo) Modify(dealID int64, price decimal.Decimal, amount int32) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deal, ok := d.deals[dealID]
+	if !ok {
+		return nil
+	}
+
+	if price != 0 {
+		deal.Price = price
+	}
+
+	if amount != 0 {
+		deal.Amount = deal.Filled + amount
+	}
+
+	d.deals[dealID] = deal
+
+	return nil
+}
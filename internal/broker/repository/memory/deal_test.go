@@ -0,0 +1,42 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/broker/repository/memory"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+func TestPartialFillsAccumulate(t *testing.T) {
+	deals := memory.NewDealRepo()
+
+	deal := broker.Deal{ID: 7, ClientID: 1, Ticker: "A", Type: broker.Buy, Amount: 5, Status: broker.DealStatusNew}
+	if err := deals.Add(deal); err != nil {
+		t.Fatal(err)
+	}
+
+	fills := []broker.Deal{
+		{ID: 7, Amount: 2, Price: decimal.New(10), Partial: true, Status: broker.DealStatusNew},
+		{ID: 7, Amount: 3, Price: decimal.New(13), Status: broker.DealStatusCompleted},
+	}
+
+	for _, fill := range fills {
+		if err := deals.Update(fill); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, ok, err := deals.Get(deal.ID)
+	if err != nil || !ok {
+		t.Fatalf("deal is not found: %v", err)
+	}
+
+	if got.Amount != 5 || got.Filled != 5 || got.AvgPrice != decimal.MustParse("11.8") {
+		t.Errorf("deal %d of %d filled at %s, want 5 of 5 at 11.8", got.Filled, got.Amount, got.AvgPrice)
+	}
+
+	if got.Partial || got.Status != broker.DealStatusCompleted {
+		t.Errorf("deal is %s, partial %v, want completed", got.Status, got.Partial)
+	}
+}
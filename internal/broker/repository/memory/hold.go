@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/marksartdev/trading/internal/broker"
+)
+
+// Hold repository.
+type holdRepo struct {
+	mu    *sync.RWMutex
+	holds map[int64]broker.Hold
+}
+
+// NewHoldRepo creates new in-memory hold repository.
+func NewHoldRepo() broker.HoldRepo {
+	return &holdRepo{mu: &sync.RWMutex{}, holds: make(map[int64]broker.Hold)}
+}
+
+// Add adds hold to repository.
+func (h *holdRepo) Add(hold broker.Hold) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.holds[hold.DealID] = hold
+
+	return nil
+}
+
+// Get returns hold of deal.
+func (h *holdRepo) Get(dealID int64) (broker.Hold, bool, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	hold, ok := h.holds[dealID]
+
+	return hold, ok, nil
+}
+
+// GetByClient returns holds of client ordered by deal.
+func (h *holdRepo) GetByClient(clientID int64) ([]broker.Hold, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var holds []broker.Hold
+	for _, hold := range h.holds {
+		if hold.ClientID == clientID {
+			holds = append(holds, hold)
+		}
+	}
+
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].DealID < holds[j].DealID
+	})
+
+	return holds, nil
+}
+
+// Update updates reserved amount and cash of hold.
+func (h *holdRepo) Update(hold broker.Hold) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.holds[hold.DealID]; ok {
+		h.holds[hold.DealID] = hold
+	}
+
+	return nil
+}
+
+// Release deletes hold of deal.
+func (h *holdRepo) Release(dealID int64) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.holds, dealID)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Ledger repository. Balances are cached in client repository.
+type ledgerRepo struct {
+	mu      *sync.RWMutex
+	lastID  int64
+	entries []broker.Entry
+	clients broker.ClientRepo
+}
+
+// NewLedgerRepo creates new in-memory ledger repository over client repository.
+func NewLedgerRepo(clients broker.ClientRepo) broker.LedgerRepo {
+	return &ledgerRepo{mu: &sync.RWMutex{}, clients: clients}
+}
+
+// Post adds entry and applies it to cached balance of client.
+func (l *ledgerRepo) Post(entry *broker.Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.post(entry)
+}
+
+// Open adds client with its funding entry.
+func (l *ledgerRepo) Open(client *broker.Client, funding *broker.Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.clients.Add(client); err != nil {
+		return err
+	}
+
+	funding.ClientID = client.ID
+	if err := l.post(funding); err != nil {
+		return err
+	}
+
+	client.Balance += funding.Change()
+
+	return nil
+}
+
+// Adds entry and applies it to cached balance of client. Caller holds the lock.
+func (l *ledgerRepo) post(entry *broker.Entry) error {
+	l.lastID++
+	entry.ID = l.lastID
+	l.entries = append(l.entries, *entry)
+
+	return l.clients.SumBalance(entry.ClientID, entry.Change())
+}
+
+// Get returns entries of client in order they were posted.
+func (l *ledgerRepo) Get(clientID int64) ([]broker.Entry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var entries []broker.Entry
+	for _, entry := range l.entries {
+		if entry.ClientID == clientID {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// Rebuild recalculates balances of clients which have entries.
+func (l *ledgerRepo) Rebuild() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	balances := make(map[int64]decimal.Decimal)
+	for _, entry := range l.entries {
+		balances[entry.ClientID] += entry.Change()
+	}
+
+	for clientID, balance := range balances {
+		client, ok, err := l.clients.GetByID(clientID)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			if err := l.clients.SumBalance(clientID, balance-client.Balance); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/broker/repository/memory"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+func TestLedgerKeepsBalance(t *testing.T) {
+	clients := memory.NewClientRepo()
+	ledger := memory.NewLedgerRepo(clients)
+
+	client := broker.Client{Login: "user"}
+	funding := broker.Entry{
+		Type:   broker.EntryFunding,
+		Debit:  broker.AccountCash,
+		Credit: broker.AccountEquity,
+		Amount: decimal.New(100),
+		Time:   time.Now(),
+	}
+
+	if err := ledger.Open(&client, &funding); err != nil {
+		t.Fatal(err)
+	}
+
+	if funding.ClientID != client.ID || client.Balance != decimal.New(100) {
+		t.Fatalf("client %+v is opened by funding %+v", client, funding)
+	}
+
+	for _, entry := range []broker.Entry{
+		{Type: broker.EntryTrade, Debit: broker.AccountMarket, Credit: broker.AccountCash, Amount: decimal.New(30)},
+		{Type: broker.EntryFee, Debit: broker.AccountFees, Credit: broker.AccountCash, Amount: decimal.MustParse("0.3")},
+		{Type: broker.EntryDeposit, Debit: broker.AccountCash, Credit: broker.AccountBank, Amount: decimal.New(5)},
+	} {
+		entry.ClientID = client.ID
+		if err := ledger.Post(&entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := decimal.MustParse("74.7")
+
+	got, _, _ := clients.GetByID(client.ID)
+	if got.Balance != want {
+		t.Errorf("balance %s, want %s", got.Balance, want)
+	}
+
+	// Cached balance which is off is recalculated from entries.
+	if err := clients.SumBalance(client.ID, decimal.New(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ledger.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, _ = clients.GetByID(client.ID)
+	if got.Balance != want {
+		t.Errorf("rebuilt balance %s, want %s", got.Balance, want)
+	}
+
+	entries, _ := ledger.Get(client.ID)
+	if len(entries) != 4 {
+		t.Errorf("%d entries, want 4", len(entries))
+	}
+}
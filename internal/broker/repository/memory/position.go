@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/marksartdev/trading/internal/broker"
+)
+
+// Position repository.
+type positionRepo struct {
+	mu        *sync.RWMutex
+	positions map[int64]map[string]int32
+}
+
+// NewPositionRepo creates new in-memory position repository.
+func NewPositionRepo() broker.PositionRepo {
+	return &positionRepo{mu: &sync.RWMutex{}, positions: make(map[int64]map[string]int32)}
+}
+
+// Add adds position to repository.
+func (p *positionRepo) Add(position broker.Position) error {
+	p.change(position.ClientID, position.Ticker, position.Amount)
+	return nil
+}
+
+// Get returns positions from repository ordered by ticker.
+func (p *positionRepo) Get(clientID int64) ([]broker.Position, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	positions := make([]broker.Position, 0, len(p.positions[clientID]))
+	for ticker, amount := range p.positions[clientID] {
+		positions = append(positions, broker.Position{ClientID: clientID, Ticker: ticker, Amount: amount})
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].Ticker < positions[j].Ticker
+	})
+
+	return positions, nil
+}
+
+// Remove deletes position from repository.
+func (p *positionRepo) Remove(position broker.Position) error {
+	p.change(position.ClientID, position.Ticker, -position.Amount)
+	return nil
+}
+
+// Changes amount of client position.
+func (p *positionRepo) change(clientID int64, ticker string, amount int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.positions[clientID] == nil {
+		p.positions[clientID] = make(map[string]int32)
+	}
+
+	p.positions[clientID][ticker] += amount
+}
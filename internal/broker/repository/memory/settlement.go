@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/marksartdev/trading/internal/broker"
+)
+
+// Settlement repository. In-memory repositories never fail, so settlement is applied under a single lock.
+type settlementRepo struct {
+	mu        *sync.Mutex
+	applied   map[int64]bool
+	pending   map[int64]bool
+	lastSeq   int64
+	deals     broker.DealRepo
+	positions broker.PositionRepo
+	holds     broker.HoldRepo
+	ledger    broker.LedgerRepo
+}
+
+// NewSettlementRepo creates new in-memory settlement repository over in-memory repositories.
+func NewSettlementRepo(
+	deals broker.DealRepo,
+	positions broker.PositionRepo,
+	holds broker.HoldRepo,
+	ledger broker.LedgerRepo,
+) broker.SettlementRepo {
+	return &settlementRepo{
+		mu:        &sync.Mutex{},
+		applied:   make(map[int64]bool),
+		pending:   make(map[int64]bool),
+		deals:     deals,
+		positions: positions,
+		holds:     holds,
+		ledger:    ledger,
+	}
+}
+
+// Apply applies fill to deal, position, ledger and hold.
+func (s *settlementRepo) Apply(settlement broker.Settlement) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fill := settlement.Fill
+	if fill.Seq > s.lastSeq {
+		s.lastSeq = fill.Seq
+	}
+
+	if s.applied[fill.FillID] {
+		return false, nil
+	}
+
+	if fill.Status == broker.DealStatusExpired {
+		_ = s.deals.UpdateStatus(fill.ID, broker.DealStatusExpired)
+	} else {
+		_ = s.deals.Update(fill)
+
+		position := broker.Position{ClientID: fill.ClientID, Ticker: fill.Ticker, Amount: fill.Amount}
+
+		if fill.Type == broker.Buy {
+			_ = s.positions.Add(position)
+		} else {
+			_ = s.positions.Remove(position)
+		}
+	}
+
+	for i := range settlement.Entries {
+		_ = s.ledger.Post(&settlement.Entries[i])
+	}
+
+	if settlement.Release {
+		_ = s.holds.Release(fill.ID)
+	} else if settlement.Hold.DealID != 0 {
+		_ = s.holds.Update(settlement.Hold)
+	}
+
+	s.applied[fill.FillID] = true
+
+	return true, nil
+}
+
+// Open adds pending deal with its hold.
+func (s *settlementRepo) Open(deal broker.Deal, hold broker.Hold) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.deals.Add(deal)
+	_ = s.holds.Add(hold)
+	s.pending[deal.ID] = true
+
+	return nil
+}
+
+// Confirm moves pending deal and its hold to identifier given by exchange and opens the deal.
+func (s *settlementRepo) Confirm(pendingID, dealID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deal, ok, _ := s.deals.Get(pendingID)
+	if !ok || deal.Status != broker.DealStatusPending {
+		return nil
+	}
+
+	delete(s.pending, pendingID)
+	_ = s.deals.Delete(pendingID)
+	deal.ID, deal.Status = dealID, broker.DealStatusNew
+	_ = s.deals.Add(deal)
+
+	if hold, ok, _ := s.holds.Get(pendingID); ok {
+		_ = s.holds.Release(pendingID)
+		hold.DealID = dealID
+		_ = s.holds.Add(hold)
+	}
+
+	return nil
+}
+
+// Abort deletes pending deal with its hold.
+func (s *settlementRepo) Abort(pendingID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.abort(pendingID)
+
+	return nil
+}
+
+// AbortPending aborts every pending deal.
+func (s *settlementRepo) AbortPending() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aborted := len(s.pending)
+	for dealID := range s.pending {
+		s.abort(dealID)
+	}
+
+	return aborted, nil
+}
+
+// Deletes pending deal with its hold. Caller holds the lock.
+func (s *settlementRepo) abort(pendingID int64) {
+	delete(s.pending, pendingID)
+	_ = s.holds.Release(pendingID)
+	_ = s.deals.Delete(pendingID)
+}
+
+// LastSeq returns sequence of the last applied result.
+func (s *settlementRepo) LastSeq() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastSeq, nil
+}
@@ -0,0 +1,90 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/broker/repository/memory"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+func TestSettlementIsAppliedOnce(t *testing.T) {
+	clients := memory.NewClientRepo()
+	deals := memory.NewDealRepo()
+	positions := memory.NewPositionRepo()
+	holds := memory.NewHoldRepo()
+	ledger := memory.NewLedgerRepo(clients)
+	repo := memory.NewSettlementRepo(deals, positions, holds, ledger)
+
+	client := broker.Client{Login: "user", Balance: decimal.New(1000)}
+	if err := clients.Add(&client); err != nil {
+		t.Fatal(err)
+	}
+
+	deal := broker.Deal{
+		ID:       7,
+		ClientID: client.ID,
+		Ticker:   "A",
+		Type:     broker.Buy,
+		Amount:   2,
+		Price:    decimal.New(10),
+		Status:   broker.DealStatusNew,
+	}
+	if err := deals.Add(deal); err != nil {
+		t.Fatal(err)
+	}
+
+	fill := deal
+	fill.Amount, fill.FillID, fill.Seq, fill.Status = 2, 100, 3, broker.DealStatusCompleted
+
+	settlement := broker.Settlement{
+		Fill: fill,
+		Entries: []broker.Entry{{
+			ClientID: client.ID,
+			Type:     broker.EntryTrade,
+			Debit:    broker.AccountMarket,
+			Credit:   broker.AccountCash,
+			Amount:   decimal.New(20),
+			DealID:   deal.ID,
+			FillID:   fill.FillID,
+			Time:     time.Now(),
+		}},
+		Release: true,
+	}
+
+	// The duplicate is replayed with an earlier sequence, which must not move the cursor back.
+	for i, want := range []bool{true, false} {
+		if !want {
+			settlement.Fill.Seq = 1
+		}
+
+		applied, err := repo.Apply(settlement)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if applied != want {
+			t.Errorf("apply %d: applied %v, want %v", i, applied, want)
+		}
+	}
+
+	got, _, _ := clients.GetByID(client.ID)
+	if got.Balance != decimal.New(980) {
+		t.Errorf("balance %s, want 980", got.Balance)
+	}
+
+	held, _ := positions.Get(client.ID)
+	if len(held) != 1 || held[0].Amount != 2 {
+		t.Errorf("positions %+v, want 2 of A", held)
+	}
+
+	seq, err := repo.LastSeq()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seq != 3 {
+		t.Errorf("last sequence %d, want 3", seq)
+	}
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+)
+
+const limit = 300
+
+// Statistic repository.
+type statisticRepo struct {
+	mu        *sync.RWMutex
+	statistic map[string][]broker.OHLCV
+}
+
+// NewStatisticRepo creates new in-memory statistic repository.
+func NewStatisticRepo() broker.StatisticRepo {
+	return &statisticRepo{mu: &sync.RWMutex{}, statistic: make(map[string][]broker.OHLCV)}
+}
+
+// Add adds OHLCV to repository.
+func (s *statisticRepo) Add(ohlcv broker.OHLCV) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statistic[ohlcv.Ticker] = append(s.statistic[ohlcv.Ticker], ohlcv)
+
+	return nil
+}
+
+// Get returns last statistic of a ticker at interval starting from the newest one.
+// Zero interval means the smallest stored interval.
+func (s *statisticRepo) Get(ticker string, interval time.Duration) ([]broker.OHLCV, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if interval == 0 {
+		for _, ohlcv := range s.statistic[ticker] {
+			if interval == 0 || ohlcv.Interval < interval {
+				interval = ohlcv.Interval
+			}
+		}
+	}
+
+	var all []broker.OHLCV
+
+	for _, ohlcv := range s.statistic[ticker] {
+		if ohlcv.Interval == interval {
+			all = append(all, ohlcv)
+		}
+	}
+
+	n := len(all)
+	if n > limit {
+		n = limit
+	}
+
+	statistic := make([]broker.OHLCV, n)
+	for i := range statistic {
+		statistic[i] = all[len(all)-1-i]
+	}
+
+	return statistic, nil
+}
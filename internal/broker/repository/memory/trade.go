@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/marksartdev/trading/internal/broker"
+)
+
+// Trade repository.
+type tradeRepo struct {
+	mu     *sync.RWMutex
+	trades map[string][]broker.Trade
+}
+
+// NewTradeRepo creates new in-memory trade repository.
+func NewTradeRepo() broker.TradeRepo {
+	return &tradeRepo{mu: &sync.RWMutex{}, trades: make(map[string][]broker.Trade)}
+}
+
+// Add adds trade to repository.
+func (t *tradeRepo) Add(trade broker.Trade) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.trades[trade.Ticker] = append(t.trades[trade.Ticker], trade)
+
+	return nil
+}
+
+// Get returns last trades of a ticker starting from the newest one.
+func (t *tradeRepo) Get(ticker string) ([]broker.Trade, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	all := t.trades[ticker]
+
+	n := len(all)
+	if n > limit {
+		n = limit
+	}
+
+	trades := make([]broker.Trade, n)
+	for i := range trades {
+		trades[i] = all[len(all)-1-i]
+	}
+
+	return trades, nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Fill entity. It keeps applied results of deals, so a result is never applied twice.
+type Fill struct {
+	ID        int64             `gorm:"primarykey;autoIncrement:false"`
+	DealID    int64             `gorm:"not null;index"`
+	Vol       int32             `gorm:"not null"`
+	Price     decimal.Decimal   `gorm:"not null"`
+	Status    broker.DealStatus `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// Cursor entity. It keeps sequence of the last applied result of a stream.
+type Cursor struct {
+	Stream string `gorm:"primarykey"`
+	Seq    int64  `gorm:"not null"`
+}
+
+// Stream of results of deals.
+const resultsCursor = "results"
+
+// Settlement repository.
+type settlementRepo struct {
+	db *gorm.DB
+}
+
+// NewSettlementRepo creates new settlement repository.
+func NewSettlementRepo(db *gorm.DB) broker.SettlementRepo {
+	return settlementRepo{db: db}
+}
+
+// Apply applies fill to deal, position, ledger and hold in a single transaction.
+func (s settlementRepo) Apply(settlement broker.Settlement) (bool, error) {
+	fill := settlement.Fill
+	applied := false
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if fill.Seq > 0 {
+			cursor := Cursor{Stream: resultsCursor, Seq: fill.Seq}
+
+			// Replayed result never moves the cursor back.
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "stream"}},
+				DoUpdates: clause.Set{{
+					Column: clause.Column{Name: "seq"},
+					Value:  gorm.Expr("GREATEST(cursors.seq, excluded.seq)"),
+				}},
+			}).Create(&cursor).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		entity := Fill{
+			ID:     fill.FillID,
+			DealID: fill.ID,
+			Vol:    fill.Amount,
+			Price:  fill.Price,
+			Status: fill.Status,
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := settle(tx, settlement); err != nil {
+			return err
+		}
+
+		applied = true
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// LastSeq returns sequence of the last applied result.
+func (s settlementRepo) LastSeq() (int64, error) {
+	var cursor Cursor
+
+	err := s.db.Where("stream = ?", resultsCursor).Limit(1).Find(&cursor).Error
+
+	return cursor.Seq, err
+}
+
+// Open adds pending deal with its hold in a single transaction.
+func (s settlementRepo) Open(deal broker.Deal, hold broker.Hold) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := (dealRepo{db: tx}).Add(deal); err != nil {
+			return err
+		}
+
+		return holdRepo{db: tx}.Add(hold)
+	})
+}
+
+// Confirm moves pending deal and its hold to identifier given by exchange and opens the deal in a single transaction.
+func (s settlementRepo) Confirm(pendingID, dealID int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Deal{}).Where(Deal{ID: pendingID, Status: broker.DealStatusPending}).Updates(map[string]interface{}{
+			"id":     dealID,
+			"status": broker.DealStatusNew,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&Hold{}).Where(Hold{DealID: pendingID}).Update("deal_id", dealID).Error
+	})
+}
+
+// Abort deletes pending deal with its hold in a single transaction.
+func (s settlementRepo) Abort(pendingID int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return abort(tx, pendingID)
+	})
+}
+
+// AbortPending aborts every pending deal in a single transaction.
+func (s settlementRepo) AbortPending() (int, error) {
+	var entities []Deal
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(Deal{Status: broker.DealStatusPending}).Find(&entities).Error; err != nil {
+			return err
+		}
+
+		for _, entity := range entities {
+			if err := abort(tx, entity.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return len(entities), err
+}
+
+// Deletes pending deal with its hold by repositories bound to transaction.
+func abort(tx *gorm.DB, pendingID int64) error {
+	if err := (holdRepo{db: tx}).Release(pendingID); err != nil {
+		return err
+	}
+
+	return dealRepo{db: tx}.Delete(pendingID)
+}
+
+// Applies settlement by repositories bound to transaction.
+func settle(tx *gorm.DB, settlement broker.Settlement) error {
+	fill := settlement.Fill
+	deals, positions, holds := dealRepo{db: tx}, positionRepo{db: tx}, holdRepo{db: tx}
+
+	if fill.Status == broker.DealStatusExpired {
+		if err := deals.UpdateStatus(fill.ID, broker.DealStatusExpired); err != nil {
+			return err
+		}
+	} else {
+		if err := deals.Update(fill); err != nil {
+			return err
+		}
+
+		position := broker.Position{ClientID: fill.ClientID, Ticker: fill.Ticker, Amount: fill.Amount}
+
+		var err error
+		if fill.Type == broker.Buy {
+			err = positions.Add(position)
+		} else {
+			err = positions.Remove(position)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range settlement.Entries {
+		if err := post(tx, &settlement.Entries[i]); err != nil {
+			return err
+		}
+	}
+
+	if settlement.Release {
+		return holds.Release(fill.ID)
+	}
+
+	if settlement.Hold.DealID != 0 {
+		return holds.Update(settlement.Hold)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package repository_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/broker/repository"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Driver which records statements instead of executing them. Every statement affects one row
+// and every query returns no rows, so statements of the postgres dialect are checked without a server.
+type recorder struct {
+	mu    sync.Mutex
+	stmts []string
+}
+
+var recorded = &recorder{}
+
+func init() {
+	sql.Register("recorder", recorded)
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) { return recordingConn{r}, nil }
+
+func (r *recorder) record(query string) {
+	r.mu.Lock()
+	r.stmts = append(r.stmts, query)
+	r.mu.Unlock()
+}
+
+// Returns statements recorded since the last call.
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stmts := r.stmts
+	r.stmts = nil
+
+	return stmts
+}
+
+type recordingConn struct{ r *recorder }
+
+func (c recordingConn) Close() error { return nil }
+
+func (c recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return recordingStmt{c.r, query}, nil
+}
+
+func (c recordingConn) Begin() (driver.Tx, error) {
+	c.r.record("BEGIN")
+	return recordingTx{c.r}, nil
+}
+
+type recordingTx struct{ r *recorder }
+
+func (t recordingTx) Commit() error {
+	t.r.record("COMMIT")
+	return nil
+}
+
+func (t recordingTx) Rollback() error {
+	t.r.record("ROLLBACK")
+	return nil
+}
+
+type recordingStmt struct {
+	r     *recorder
+	query string
+}
+
+func (s recordingStmt) Close() error  { return nil }
+func (s recordingStmt) NumInput() int { return -1 }
+
+func (s recordingStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.r.record(s.query)
+	return driver.RowsAffected(1), nil
+}
+
+func (s recordingStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.r.record(s.query)
+	return noRows{}, nil
+}
+
+type noRows struct{}
+
+func (noRows) Columns() []string         { return nil }
+func (noRows) Close() error              { return nil }
+func (noRows) Next([]driver.Value) error { return io.EOF }
+
+func newRecorded(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DriverName: "recorder"}),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded.take()
+
+	return db
+}
+
+// Checks that statements contain fragments in order.
+func expectStatements(t *testing.T, stmts []string, fragments ...string) {
+	t.Helper()
+
+	all := strings.Join(stmts, "\n")
+	for _, fragment := range fragments {
+		i := strings.Index(all, fragment)
+		if i < 0 {
+			t.Fatalf("%q is not found in order in statements:\n%s", fragment, strings.Join(stmts, "\n"))
+		}
+
+		all = all[i+len(fragment):]
+	}
+}
+
+func TestApplyMovesCursorForwardAndAccumulatesFill(t *testing.T) {
+	repo := repository.NewSettlementRepo(newRecorded(t))
+
+	fill := broker.Deal{
+		ID:       7,
+		ClientID: 1,
+		Ticker:   "A",
+		Type:     broker.Buy,
+		Amount:   2,
+		Price:    decimal.New(10),
+		FillID:   100,
+		Seq:      3,
+		Status:   broker.DealStatusCompleted,
+	}
+
+	applied, err := repo.Apply(broker.Settlement{Fill: fill, Release: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !applied {
+		t.Fatal("settlement is not applied")
+	}
+
+	expectStatements(t, recorded.take(),
+		"BEGIN",
+		`INSERT INTO "cursors"`,
+		`ON CONFLICT ("stream") DO UPDATE SET "seq"=GREATEST(cursors.seq, excluded.seq)`,
+		`INSERT INTO "fills"`,
+		"ON CONFLICT DO NOTHING",
+		`UPDATE "deals" SET "avg_price"=(avg_price * filled + $1) / (filled + $2),"filled"=filled + $3`,
+		`INSERT INTO "positions"`,
+		`DELETE FROM "holds"`,
+		"COMMIT",
+	)
+}
+
+func TestConfirmMovesPrimaryKeyOfPendingDeal(t *testing.T) {
+	repo := repository.NewSettlementRepo(newRecorded(t))
+
+	if err := repo.Confirm(-1, 42); err != nil {
+		t.Fatal(err)
+	}
+
+	expectStatements(t, recorded.take(),
+		"BEGIN",
+		`UPDATE "deals" SET "id"=$1,"status"=$2`,
+		`WHERE "deals"."id" = $`,
+		`"deals"."status" = $`,
+		`UPDATE "holds" SET "deal_id"=$1`,
+		"COMMIT",
+	)
+}
+
+func TestRebuildRecalculatesBalancesFromLedger(t *testing.T) {
+	repo := repository.NewLedgerRepo(newRecorded(t))
+
+	if err := repo.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectStatements(t, recorded.take(),
+		"BEGIN",
+		`SELECT * FROM "clients" WHERE NOT EXISTS (SELECT 1 FROM entries WHERE entries.client_id = clients.id)`,
+		"UPDATE clients SET balance = COALESCE((",
+		"SUM(CASE WHEN debit = $1 THEN amount WHEN credit = $2 THEN -amount ELSE 0 END)",
+		"COMMIT",
+	)
+}
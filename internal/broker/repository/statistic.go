@@ -1,24 +1,26 @@
 package repository
 
 import (
+	"database/sql"
 	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
 )
 
 // OHLCV entity.
 type OHLCV struct {
-	ID       int64         `gorm:"primarykey"`
-	Ticker   string        `gorm:"not null"`
-	Time     time.Time     `gorm:"not null"`
-	Interval time.Duration `gorm:"not null"`
-	Open     float64       `gorm:"not null"`
-	High     float64       `gorm:"not null"`
-	Low      float64       `gorm:"not null"`
-	Close    float64       `gorm:"not null"`
-	Volume   int32         `gorm:"not null"`
+	ID       int64           `gorm:"primarykey"`
+	Ticker   string          `gorm:"not null"`
+	Time     time.Time       `gorm:"not null"`
+	Interval time.Duration   `gorm:"not null"`
+	Open     decimal.Decimal `gorm:"not null"`
+	High     decimal.Decimal `gorm:"not null"`
+	Low      decimal.Decimal `gorm:"not null"`
+	Close    decimal.Decimal `gorm:"not null"`
+	Volume   int32           `gorm:"not null"`
 }
 
 const limit = 300
@@ -50,11 +52,23 @@ func (s statisticRepo) Add(ohlcv broker.OHLCV) error {
 	return s.db.Create(&entity).Error
 }
 
-// Get returns statistic for last n sec from repository.
-func (s statisticRepo) Get(ticker string) ([]broker.OHLCV, error) {
+// Get returns last statistic of a ticker at interval from repository.
+// Zero interval means the smallest stored interval.
+func (s statisticRepo) Get(ticker string, interval time.Duration) ([]broker.OHLCV, error) {
 	var entities []OHLCV
 
-	err := s.db.Where(OHLCV{Ticker: ticker}).Order("time DESC").Limit(limit).Find(&entities).Error
+	if interval == 0 {
+		var smallest sql.NullInt64
+
+		err := s.db.Model(&OHLCV{}).Where(OHLCV{Ticker: ticker}).Select(`MIN("interval")`).Scan(&smallest).Error
+		if err != nil {
+			return nil, err
+		}
+
+		interval = time.Duration(smallest.Int64)
+	}
+
+	err := s.db.Where(OHLCV{Ticker: ticker, Interval: interval}).Order("time DESC").Limit(limit).Find(&entities).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Trade entity.
+type Trade struct {
+	ID     int64           `gorm:"primarykey"`
+	Ticker string          `gorm:"not null;index"`
+	Price  decimal.Decimal `gorm:"not null"`
+	Amount int32           `gorm:"not null"`
+	Type   broker.DealType `gorm:"not null"`
+	Time   time.Time       `gorm:"not null"`
+}
+
+// Trade repository.
+type tradeRepo struct {
+	db *gorm.DB
+}
+
+// NewTradeRepo creates new trade repository.
+func NewTradeRepo(db *gorm.DB) broker.TradeRepo {
+	return tradeRepo{db: db}
+}
+
+// Add adds trade to repository.
+func (t tradeRepo) Add(trade broker.Trade) error {
+	entity := Trade{
+		ID:     trade.ID,
+		Ticker: trade.Ticker,
+		Price:  trade.Price,
+		Amount: trade.Amount,
+		Type:   trade.Type,
+		Time:   trade.Time,
+	}
+
+	return t.db.Create(&entity).Error
+}
+
+// Get returns last trades of a ticker starting from the newest one.
+func (t tradeRepo) Get(ticker string) ([]broker.Trade, error) {
+	var entities []Trade
+
+	err := t.db.Where(Trade{Ticker: ticker}).Order("time DESC, id DESC").Limit(limit).Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]broker.Trade, len(entities))
+	for i := range trades {
+		trades[i] = broker.Trade{
+			ID:     entities[i].ID,
+			Ticker: entities[i].Ticker,
+			Price:  entities[i].Price,
+			Amount: entities[i].Amount,
+			Type:   entities[i].Type,
+			Time:   entities[i].Time,
+		}
+	}
+
+	return trades, nil
+}
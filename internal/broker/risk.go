@@ -0,0 +1,14 @@
+package broker
+
+import "fmt"
+
+// RiskError rejection of a deal by pre-trade risk check.
+type RiskError struct {
+	Check  string
+	Reason string
+}
+
+// Error returns description of rejection.
+func (r RiskError) Error() string {
+	return fmt.Sprintf("%s check failed: %s", r.Check, r.Reason)
+}
@@ -3,19 +3,25 @@ package services
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
 	"github.com/marksartdev/trading/internal/log"
 )
 
 const (
-	mainAction      log.Action = "main"
-	statAction      log.Action = "statistic"
-	dealsAction     log.Action = "deals"
-	statGrpcAction  log.Action = "statistic - gRPC"
-	dealsGrpcAction log.Action = "deals - gRPC"
+	mainAction       log.Action = "main"
+	statAction       log.Action = "statistic"
+	dealsAction      log.Action = "deals"
+	statGrpcAction   log.Action = "statistic - gRPC"
+	dealsGrpcAction  log.Action = "deals - gRPC"
+	tradesAction     log.Action = "trades"
+	tradesGrpcAction log.Action = "trades - gRPC"
 )
 
 // Broker service.
@@ -24,8 +30,23 @@ type brokerService struct {
 	clientRepo broker.ClientRepo
 	dealRepo   broker.DealRepo
 	posRepo    broker.PositionRepo
+	holdRepo   broker.HoldRepo
+	settleRepo broker.SettlementRepo
+	ledgerRepo broker.LedgerRepo
 	statRepo   broker.StatisticRepo
+	tradeRepo  broker.TradeRepo
 	exchange   broker.ExchangeService
+	reconcile  config.Reconcile
+	reconnect  config.Reconnect
+	risk       config.Risk
+	fee        float64
+	precision  int
+	riskMu     *sync.Mutex
+	suspects   map[int64]time.Time
+	mu         *sync.RWMutex
+	streams    map[string]broker.StreamState
+	resultSeq  int64
+	results    chan broker.Deal
 	cancel     context.CancelFunc
 }
 
@@ -35,16 +56,35 @@ func NewBrokerService(
 	clientRepo broker.ClientRepo,
 	dealRepo broker.DealRepo,
 	posRepo broker.PositionRepo,
+	holdRepo broker.HoldRepo,
+	settleRepo broker.SettlementRepo,
+	ledgerRepo broker.LedgerRepo,
 	statRepo broker.StatisticRepo,
+	tradeRepo broker.TradeRepo,
 	exchange broker.ExchangeService,
+	cfg config.Broker,
 ) broker.BrokerService {
 	return &brokerService{
 		logger:     logger,
 		clientRepo: clientRepo,
 		dealRepo:   dealRepo,
 		posRepo:    posRepo,
+		holdRepo:   holdRepo,
+		settleRepo: settleRepo,
+		ledgerRepo: ledgerRepo,
 		statRepo:   statRepo,
+		tradeRepo:  tradeRepo,
 		exchange:   exchange,
+		reconcile:  cfg.Reconcile,
+		reconnect:  reconnectConfig(cfg.Reconnect),
+		risk:       riskConfig(cfg.Risk),
+		fee:        cfg.Fee,
+		precision:  precisionConfig(cfg.Precision),
+		riskMu:     &sync.Mutex{},
+		suspects:   make(map[int64]time.Time),
+		mu:         &sync.RWMutex{},
+		streams:    make(map[string]broker.StreamState),
+		results:    make(chan broker.Deal, 100),
 	}
 }
 
@@ -54,6 +94,29 @@ func (b *brokerService) Start() {
 	defer cancel()
 	b.cancel = cancel
 
+	if err := b.ledgerRepo.Rebuild(); err != nil {
+		b.logger.Error(ledgerAction, err)
+	}
+
+	aborted, err := b.settleRepo.AbortPending()
+	if err != nil {
+		b.logger.Error(holdAction, err)
+	}
+
+	if aborted > 0 {
+		b.logger.Warn(holdAction, fmt.Sprintf("%d pending deals are aborted", aborted))
+	}
+
+	if err := b.restoreHolds(); err != nil {
+		b.logger.Error(holdAction, err)
+	}
+
+	seq, err := b.settleRepo.LastSeq()
+	if err != nil {
+		b.logger.Error(dealsAction, err)
+	}
+	b.setResultSeq(seq)
+
 	g := &errgroup.Group{}
 
 	g.Go(func() error {
@@ -64,6 +127,17 @@ func (b *brokerService) Start() {
 		b.consumeResults(ctx)
 		return nil
 	})
+	g.Go(func() error {
+		b.consumeTrades(ctx)
+		return nil
+	})
+
+	if b.reconcile.Interval > 0 {
+		g.Go(func() error {
+			b.reconcileDeals(ctx)
+			return nil
+		})
+	}
 
 	b.logger.Info(mainAction, "started")
 	if err := g.Wait(); err != nil {
@@ -82,7 +156,7 @@ func (b *brokerService) Stop() {
 	b.logger.Error(mainAction, fmt.Errorf("cancel func dose not initialized"))
 }
 
-// GetClient returns client. Create client if it is not exist.
+// GetClient returns client. Create client if it is not exist, new client is added with its funding in one transaction.
 func (b *brokerService) GetClient(login string) (broker.Client, error) {
 	client, ok, err := b.clientRepo.Get(login)
 	if err != nil {
@@ -91,8 +165,15 @@ func (b *brokerService) GetClient(login string) (broker.Client, error) {
 
 	if !ok {
 		client.Login = login
-		client.Balance = 100000000
-		if err := b.clientRepo.Add(&client); err != nil {
+		funding := broker.Entry{
+			Type:   broker.EntryFunding,
+			Debit:  broker.AccountCash,
+			Credit: broker.AccountEquity,
+			Amount: initialFunding,
+			Time:   time.Now(),
+		}
+
+		if err := b.ledgerRepo.Open(&client, &funding); err != nil {
 			return broker.Client{}, err
 		}
 	}
@@ -100,7 +181,7 @@ func (b *brokerService) GetClient(login string) (broker.Client, error) {
 	return client, nil
 }
 
-// GetProfile returns profile.
+// GetProfile returns profile. Available balance and positions exclude funds held by open deals.
 func (b *brokerService) GetProfile(login string) (broker.Profile, error) {
 	client, err := b.GetClient(login)
 	if err != nil {
@@ -117,30 +198,98 @@ func (b *brokerService) GetProfile(login string) (broker.Profile, error) {
 		return broker.Profile{}, err
 	}
 
+	held, err := b.reserved(client.ID, 0)
+	if err != nil {
+		return broker.Profile{}, err
+	}
+
+	for i := range positions {
+		positions[i].Available = positions[i].Amount - held.selling[positions[i].Ticker]
+	}
+
 	return broker.Profile{
 		ClientID:  client.ID,
 		Balance:   client.Balance,
+		Available: client.Balance - held.cash,
 		Positions: positions,
 		OpenDeals: deals,
 	}, nil
 }
 
-// Create creates deal and send it to exchange service.
+// GetDeal returns deal of client.
+func (b *brokerService) GetDeal(login string, dealID int64) (broker.Deal, error) {
+	client, err := b.GetClient(login)
+	if err != nil {
+		return broker.Deal{}, err
+	}
+
+	deal, ok, err := b.dealRepo.Get(dealID)
+	if err != nil {
+		return broker.Deal{}, err
+	}
+
+	if !ok || deal.ClientID != client.ID {
+		return broker.Deal{}, fmt.Errorf("deal %d of client %s not found", dealID, login)
+	}
+
+	return deal, nil
+}
+
+// Create creates deal and send it to exchange service. Deal which fails pre-trade risk checks is rejected,
+// accepted deal holds cash or shares until it is completed, canceled or expired.
+// Deal is reserved as pending before it is sent, so exchange never has a deal without a hold at broker.
+// Deal which exchange does not accept is aborted, deal which is accepted but is not opened is canceled at exchange.
 func (b *brokerService) Create(deal broker.Deal) (broker.Deal, error) {
+	b.riskMu.Lock()
+	defer b.riskMu.Unlock()
+
+	price, err := b.checkRisk(deal)
+	if err != nil {
+		return broker.Deal{}, err
+	}
+
+	pendingID := pendingID()
+	deal.ID = pendingID
+	deal.Status = broker.DealStatusPending
+
+	if err := b.settleRepo.Open(deal, b.holdOf(deal, price)); err != nil {
+		return broker.Deal{}, err
+	}
+
 	dealID, err := b.exchange.Create(deal)
 	if err != nil {
+		b.abort(pendingID)
 		return broker.Deal{}, err
 	}
 
-	deal.ID = dealID
-	deal.Status = broker.DealStatusNew
-	if err := b.dealRepo.Add(deal); err != nil {
+	if err := b.settleRepo.Confirm(pendingID, dealID); err != nil {
+		if _, cancelErr := b.exchange.Cancel(dealID); cancelErr != nil {
+			b.logger.Error(mainAction, fmt.Errorf("deal %d is not canceled at exchange: %w", dealID, cancelErr))
+		}
+
+		b.abort(pendingID)
+
 		return broker.Deal{}, err
 	}
 
+	deal.ID = dealID
+	deal.Status = broker.DealStatusNew
+
 	return deal, nil
 }
 
+// Returns identifier of pending deal. It is negative, so it never meets identifiers given by exchange.
+func pendingID() int64 {
+	return -time.Now().UnixNano()
+}
+
+// Deletes pending deal with its hold. Deal which is left is aborted on the next start.
+func (b *brokerService) abort(pendingID int64) {
+	if err := b.settleRepo.Abort(pendingID); err != nil {
+		b.logger.Error(mainAction, err)
+	}
+}
+
 // Cancel canceled deal.
 func (b *brokerService) Cancel(dealID int64) (bool, error) {
 	ok, err := b.exchange.Cancel(dealID)
@@ -152,14 +301,71 @@ func (b *brokerService) Cancel(dealID int64) (bool, error) {
 		if err := b.dealRepo.UpdateStatus(dealID, broker.DealStatusCanceled); err != nil {
 			return false, err
 		}
+
+		if err := b.release(dealID); err != nil {
+			return false, err
+		}
 	}
 
 	return ok, nil
 }
 
-// History returns ticker history.
-func (b *brokerService) History(ticker string) ([]broker.OHLCV, error) {
-	history, err := b.statRepo.Get(ticker)
+// Modify changes price and/or amount of deal. Increase of deal risk passes pre-trade risk checks.
+// Only a deal which is open is modified, price of market deal is kept.
+func (b *brokerService) Modify(dealID int64, price decimal.Decimal, amount int32) (bool, error) {
+	b.riskMu.Lock()
+	defer b.riskMu.Unlock()
+
+	deal, found, err := b.dealRepo.Get(dealID)
+	if err != nil {
+		return false, err
+	}
+
+	if !found || deal.Status != broker.DealStatusNew {
+		return false, nil
+	}
+
+	next := deal
+	next.Filled = 0
+	next.Amount = deal.Amount - deal.Filled
+
+	if amount != 0 {
+		next.Amount = amount
+	}
+
+	if price != 0 && deal.OrderType != broker.Market {
+		next.Price = price
+	}
+
+	var nextPrice decimal.Decimal
+
+	if next.Amount > deal.Amount-deal.Filled || (deal.Type == broker.Buy && next.Price > deal.Price) {
+		if nextPrice, err = b.checkRisk(next); err != nil {
+			return false, err
+		}
+	} else if nextPrice, err = b.refPrice(next); err != nil {
+		return false, err
+	}
+
+	ok, err := b.exchange.Modify(dealID, next.Price, next.Amount)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if err := b.dealRepo.Modify(dealID, next.Price, next.Amount); err != nil {
+		return false, err
+	}
+
+	if err := b.rehold(next, nextPrice); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// History returns ticker history at interval. Zero interval means the smallest stored one.
+func (b *brokerService) History(ticker string, interval time.Duration) ([]broker.OHLCV, error) {
+	history, err := b.statRepo.Get(ticker, interval)
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +373,16 @@ func (b *brokerService) History(ticker string) ([]broker.OHLCV, error) {
 	return history, nil
 }
 
+// Tape returns last trades of a ticker.
+func (b *brokerService) Tape(ticker string) ([]broker.Trade, error) {
+	return b.tradeRepo.Get(ticker)
+}
+
+// Depth streams market depth of a ticker from exchange until context is done.
+func (b *brokerService) Depth(ctx context.Context, ticker string, levels int32, out chan broker.Depth) error {
+	return b.exchange.Depth(ctx, ticker, levels, out)
+}
+
 // Consumes statistic.
 func (b *brokerService) consumeStatistic(ctx context.Context) {
 	in := make(chan broker.OHLCV, 100)
@@ -177,9 +393,10 @@ func (b *brokerService) consumeStatistic(ctx context.Context) {
 		b.logger.Info(statGrpcAction, "started")
 		defer b.logger.Info(statGrpcAction, "stopped")
 
-		if err := b.exchange.Statistic(ctx, in); err != nil {
-			b.logger.Error(statGrpcAction, err)
-		}
+		b.supervise(ctx, statStream, statGrpcAction, func(ctx context.Context, connected func()) error {
+			return b.exchange.Statistic(ctx, in, connected)
+		})
+		close(in)
 		return nil
 	})
 
@@ -199,7 +416,7 @@ func (b *brokerService) consumeStatistic(ctx context.Context) {
 
 // Consumes results of deals.
 func (b *brokerService) consumeResults(ctx context.Context) {
-	in := make(chan broker.Deal, 100)
+	in := b.results
 
 	g := &errgroup.Group{}
 
@@ -207,9 +424,10 @@ func (b *brokerService) consumeResults(ctx context.Context) {
 		b.logger.Info(dealsGrpcAction, "started")
 		defer b.logger.Info(dealsGrpcAction, "stopped")
 
-		if err := b.exchange.Results(ctx, in); err != nil {
-			b.logger.Error(dealsGrpcAction, err)
-		}
+		b.supervise(ctx, resultsStream, dealsGrpcAction, func(ctx context.Context, connected func()) error {
+			return b.exchange.Results(ctx, b.appliedSeq(), in, connected)
+		})
+		close(in)
 		return nil
 	})
 
@@ -217,43 +435,41 @@ func (b *brokerService) consumeResults(ctx context.Context) {
 	defer b.logger.Info(dealsAction, "stopped")
 
 	for deal := range in {
-		if err := b.dealRepo.Update(deal); err != nil {
-			b.logger.Error(dealsAction, err)
-			continue
-		}
+		b.settle(ctx, deal)
+	}
 
-		position := broker.Position{
-			ClientID: deal.ClientID,
-			Ticker:   deal.Ticker,
-			Amount:   deal.Amount,
-		}
+	if err := g.Wait(); err != nil {
+		b.logger.Error(dealsAction, err)
+	}
+}
 
-		var err error
-		if deal.Type == broker.Buy {
-			err = b.posRepo.Add(position)
-		} else {
-			err = b.posRepo.Remove(position)
-		}
+// Consumes trade tape.
+func (b *brokerService) consumeTrades(ctx context.Context) {
+	in := make(chan broker.Trade, 100)
 
-		if err != nil {
-			b.logger.Error(dealsAction, err)
-			continue
-		}
+	g := &errgroup.Group{}
 
-		if deal.Type == broker.Buy {
-			err = b.clientRepo.SubBalance(deal.ClientID, deal.Price*float64(deal.Amount))
-		} else {
-			err = b.clientRepo.SumBalance(deal.ClientID, deal.Price*float64(deal.Amount))
-		}
+	g.Go(func() error {
+		b.logger.Info(tradesGrpcAction, "started")
+		defer b.logger.Info(tradesGrpcAction, "stopped")
 
-		if err != nil {
-			b.logger.Error(dealsAction, err)
-			continue
-		}
+		b.supervise(ctx, tradesStream, tradesGrpcAction, func(ctx context.Context, connected func()) error {
+			return b.exchange.Trades(ctx, in, connected)
+		})
+		close(in)
+		return nil
+	})
 
+	b.logger.Info(tradesAction, "started")
+	defer b.logger.Info(tradesAction, "stopped")
+
+	for trade := range in {
+		if err := b.tradeRepo.Add(trade); err != nil {
+			b.logger.Error(tradesAction, err)
+		}
 	}
 
 	if err := g.Wait(); err != nil {
-		b.logger.Error(dealsAction, err)
+		b.logger.Error(tradesAction, err)
 	}
 }
@@ -0,0 +1,283 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/broker/repository/memory"
+	"github.com/marksartdev/trading/internal/broker/services"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+// Exchange which accepts every request unless it is down and records modifications.
+type fakeExchange struct {
+	lastID   int64
+	down     bool
+	modified []broker.Deal
+}
+
+func (f *fakeExchange) Statistic(context.Context, chan broker.OHLCV, func()) error { return nil }
+
+func (f *fakeExchange) Create(broker.Deal) (int64, error) {
+	if f.down {
+		return 0, errors.New("exchange is down")
+	}
+
+	f.lastID++
+	return f.lastID, nil
+}
+
+func (f *fakeExchange) Cancel(int64) (bool, error) { return true, nil }
+
+func (f *fakeExchange) Modify(dealID int64, price decimal.Decimal, amount int32) (bool, error) {
+	f.modified = append(f.modified, broker.Deal{ID: dealID, Price: price, Amount: amount})
+	return true, nil
+}
+
+func (f *fakeExchange) ListOpen() ([]broker.Deal, error) { return nil, nil }
+
+func (f *fakeExchange) Results(context.Context, int64, chan broker.Deal, func()) error { return nil }
+
+func (f *fakeExchange) Depth(context.Context, string, int32, chan broker.Depth) error { return nil }
+
+func (f *fakeExchange) Trades(context.Context, chan broker.Trade, func()) error { return nil }
+
+// Broker over memory repositories with the last trade of ticker "A" at 10.
+type fixture struct {
+	service   broker.BrokerService
+	exchange  *fakeExchange
+	deals     broker.DealRepo
+	holds     broker.HoldRepo
+	positions broker.PositionRepo
+}
+
+func newFixture(t *testing.T, cfg config.Broker) fixture {
+	t.Helper()
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	clients := memory.NewClientRepo()
+	deals := memory.NewDealRepo()
+	positions := memory.NewPositionRepo()
+	holds := memory.NewHoldRepo()
+	ledger := memory.NewLedgerRepo(clients)
+	trades := memory.NewTradeRepo()
+	exchange := &fakeExchange{}
+
+	if err := trades.Add(broker.Trade{ID: 1, Ticker: "A", Price: decimal.New(10), Amount: 1, Time: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	service := services.NewBrokerService(
+		logger,
+		clients,
+		deals,
+		positions,
+		holds,
+		memory.NewSettlementRepo(deals, positions, holds, ledger),
+		ledger,
+		memory.NewStatisticRepo(),
+		trades,
+		exchange,
+		cfg,
+	)
+
+	return fixture{service: service, exchange: exchange, deals: deals, holds: holds, positions: positions}
+}
+
+func (f fixture) create(t *testing.T, login string, deal broker.Deal) broker.Deal {
+	t.Helper()
+
+	client, err := f.service.GetClient(login)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deal.ClientID = client.ID
+	deal.Ticker = "A"
+	deal.TimeInForce = broker.GTC
+	deal.Time = time.Now()
+
+	deal, err = f.service.Create(deal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return deal
+}
+
+func TestModifyKeepsPriceOfMarketDeal(t *testing.T) {
+	f := newFixture(t, config.Broker{})
+	deal := f.create(t, "user", broker.Deal{Type: broker.Buy, OrderType: broker.Market, Amount: 5})
+
+	ok, err := f.service.Modify(deal.ID, decimal.New(1), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Fatal("deal is not modified")
+	}
+
+	stored, _, _ := f.deals.Get(deal.ID)
+	if stored.Price != deal.Price || stored.Amount != 3 {
+		t.Errorf("stored price %s and amount %d, want %s and 3", stored.Price, stored.Amount, deal.Price)
+	}
+
+	if sent := f.exchange.modified[0]; sent.Price != deal.Price || sent.Amount != 3 {
+		t.Errorf("sent price %s and amount %d, want %s and 3", sent.Price, sent.Amount, deal.Price)
+	}
+}
+
+func TestModifyIgnoresClosedDeal(t *testing.T) {
+	f := newFixture(t, config.Broker{})
+	deal := f.create(t, "user", broker.Deal{Type: broker.Buy, OrderType: broker.Limit, Amount: 5, Price: decimal.New(10)})
+
+	if ok, err := f.service.Cancel(deal.ID); err != nil || !ok {
+		t.Fatalf("cancel: %v %v", ok, err)
+	}
+
+	ok, err := f.service.Modify(deal.ID, decimal.New(11), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok || len(f.exchange.modified) > 0 {
+		t.Error("canceled deal is modified")
+	}
+}
+
+func TestCreateHoldsFundsOfAcceptedDeal(t *testing.T) {
+	f := newFixture(t, config.Broker{})
+	deal := f.create(t, "user", broker.Deal{Type: broker.Buy, OrderType: broker.Limit, Amount: 5, Price: decimal.New(10)})
+
+	if deal.ID != f.exchange.lastID || deal.Status != broker.DealStatusNew {
+		t.Errorf("deal %d is %s, want %d and %s", deal.ID, deal.Status, f.exchange.lastID, broker.DealStatusNew)
+	}
+
+	hold, ok, _ := f.holds.Get(deal.ID)
+	if !ok || hold.Cash != decimal.New(50) {
+		t.Errorf("hold %+v, want 50 of cash", hold)
+	}
+}
+
+func TestCreateAbortsDealWhichExchangeRejects(t *testing.T) {
+	f := newFixture(t, config.Broker{})
+
+	client, err := f.service.GetClient("user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.exchange.down = true
+
+	_, err = f.service.Create(broker.Deal{
+		ClientID:    client.ID,
+		Ticker:      "A",
+		Type:        broker.Buy,
+		OrderType:   broker.Limit,
+		TimeInForce: broker.GTC,
+		Amount:      5,
+		Price:       decimal.New(10),
+	})
+	if err == nil {
+		t.Fatal("deal is created while exchange is down")
+	}
+
+	holds, _ := f.holds.GetByClient(client.ID)
+	if len(holds) > 0 {
+		t.Errorf("holds %+v are left by rejected deal", holds)
+	}
+
+	profile, err := f.service.GetProfile("user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if profile.Available != profile.Balance || len(profile.OpenDeals) > 0 {
+		t.Errorf("profile %+v is changed by rejected deal", profile)
+	}
+}
+
+func TestHoldCoversFeeAndMarketBuffer(t *testing.T) {
+	f := newFixture(t, config.Broker{Fee: 0.01, Risk: config.Risk{MarketBuffer: 0.1}})
+
+	limit := f.create(t, "user", broker.Deal{Type: broker.Buy, OrderType: broker.Limit, Amount: 10, Price: decimal.New(10)})
+	market := f.create(t, "user", broker.Deal{Type: broker.Buy, OrderType: broker.Market, Amount: 10})
+
+	for _, tc := range []struct {
+		deal broker.Deal
+		cash decimal.Decimal
+	}{
+		{limit, decimal.New(101)},
+		{market, decimal.MustParse("111.1")},
+	} {
+		hold, _, _ := f.holds.Get(tc.deal.ID)
+		if hold.Cash != tc.cash {
+			t.Errorf("%s deal holds %s, want %s", tc.deal.OrderType, hold.Cash, tc.cash)
+		}
+	}
+}
+
+func TestWithdrawalIsLimitedByHolds(t *testing.T) {
+	f := newFixture(t, config.Broker{})
+	deal := f.create(t, "user", broker.Deal{Type: broker.Buy, OrderType: broker.Limit, Amount: 10, Price: decimal.New(10)})
+
+	profile, err := f.service.GetProfile("user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.service.Transfer("user", broker.EntryWithdrawal, profile.Available+decimal.New(1), ""); err == nil {
+		t.Error("held cash is withdrawn")
+	}
+
+	if _, err := f.service.Cancel(deal.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.service.Transfer("user", broker.EntryWithdrawal, profile.Available+decimal.New(1), ""); err != nil {
+		t.Errorf("released cash is not withdrawn: %v", err)
+	}
+}
+
+func TestCoveredMarketSellNeedsNoPrice(t *testing.T) {
+	f := newFixture(t, config.Broker{})
+
+	client, err := f.service.GetClient("user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.positions.Add(broker.Position{ClientID: client.ID, Ticker: "B", Amount: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	sell := broker.Deal{
+		ClientID:    client.ID,
+		Ticker:      "B",
+		Type:        broker.Sell,
+		OrderType:   broker.Market,
+		TimeInForce: broker.GTC,
+		Amount:      5,
+		Time:        time.Now(),
+	}
+
+	if _, err := f.service.Create(sell); err != nil {
+		t.Fatalf("covered sell without price: %v", err)
+	}
+
+	buy := sell
+	buy.Type = broker.Buy
+
+	var riskErr broker.RiskError
+	if _, err := f.service.Create(buy); !errors.As(err, &riskErr) || riskErr.Check != "price" {
+		t.Errorf("buy without price: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+const holdAction log.Action = "hold"
+
+// Funds of client reserved by holds of open deals.
+type reserved struct {
+	cash    decimal.Decimal
+	buying  map[string]int32
+	selling map[string]int32
+}
+
+// Returns funds of client reserved by holds, except hold of the given deal.
+func (b *brokerService) reserved(clientID, except int64) (reserved, error) {
+	res := reserved{buying: make(map[string]int32), selling: make(map[string]int32)}
+
+	holds, err := b.holdRepo.GetByClient(clientID)
+	if err != nil {
+		return reserved{}, err
+	}
+
+	for _, hold := range holds {
+		if hold.DealID == except {
+			continue
+		}
+
+		if hold.Type == broker.Sell {
+			res.selling[hold.Ticker] += hold.Amount
+			continue
+		}
+
+		res.cash += hold.Cash
+		res.buying[hold.Ticker] += hold.Amount
+	}
+
+	return res, nil
+}
+
+// Reserves cash or shares for the rest of deal. Caller holds risk lock.
+func (b *brokerService) reserve(deal broker.Deal, price decimal.Decimal) error {
+	return b.holdRepo.Add(b.holdOf(deal, price))
+}
+
+// Returns hold of cash or shares for the rest of deal. Buy deal holds cash to pay for it at price with fee.
+func (b *brokerService) holdOf(deal broker.Deal, price decimal.Decimal) broker.Hold {
+	hold := broker.Hold{
+		DealID:   deal.ID,
+		ClientID: deal.ClientID,
+		Ticker:   deal.Ticker,
+		Type:     deal.Type,
+		Amount:   deal.Amount - deal.Filled,
+	}
+
+	if deal.Type == broker.Buy {
+		hold.Cash = b.cost(price, hold.Amount)
+	}
+
+	return hold
+}
+
+// Returns cash which pays for amount at price with fee.
+func (b *brokerService) cost(price decimal.Decimal, amount int32) decimal.Decimal {
+	value := price.Mul(amount)
+	return value + value.MulFloat(b.fee).Round(b.precision)
+}
+
+// Changes hold of modified deal to its new rest and price. Caller holds risk lock.
+func (b *brokerService) rehold(deal broker.Deal, price decimal.Decimal) error {
+	if err := b.holdRepo.Release(deal.ID); err != nil {
+		return err
+	}
+
+	return b.reserve(deal, price)
+}
+
+// Releases hold of deal.
+func (b *brokerService) release(dealID int64) error {
+	b.riskMu.Lock()
+	defer b.riskMu.Unlock()
+
+	return b.holdRepo.Release(dealID)
+}
+
+// Reserves funds for open deals which have no hold, e.g. deals created before holds were kept.
+func (b *brokerService) restoreHolds() error {
+	b.riskMu.Lock()
+	defer b.riskMu.Unlock()
+
+	opened, err := b.dealRepo.ListOpened()
+	if err != nil {
+		return err
+	}
+
+	var restored int
+
+	for _, deal := range opened {
+		_, ok, err := b.holdRepo.Get(deal.ID)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			continue
+		}
+
+		price, err := b.refPrice(deal)
+		if err != nil {
+			return err
+		}
+
+		if err := b.reserve(deal, price); err != nil {
+			return err
+		}
+
+		restored++
+	}
+
+	if restored > 0 {
+		b.logger.Warn(holdAction, fmt.Sprintf("holds of %d open deals are restored", restored))
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+const ledgerAction log.Action = "ledger"
+
+// Initial funding of a new client.
+var initialFunding = decimal.New(100000000)
+
+// Default number of fractional digits of currency.
+const defaultPrecision = 2
+
+// Returns number of fractional digits of currency with default.
+func precisionConfig(precision int) int {
+	if precision <= 0 {
+		return defaultPrecision
+	}
+
+	return precision
+}
+
+// Returns amount of money formatted with currency precision.
+func (b *brokerService) money(amount decimal.Decimal) string {
+	return amount.StringFixed(b.precision)
+}
+
+// Ledger returns cash movements of client in order they were posted.
+func (b *brokerService) Ledger(login string) ([]broker.Entry, error) {
+	client, err := b.GetClient(login)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.ledgerRepo.Get(client.ID)
+}
+
+// Transfer posts deposit, withdrawal or adjustment of client cash. Amount is rounded to currency precision.
+// Negative adjustment decreases cash, withdrawal is limited by balance which is not held by open deals.
+func (b *brokerService) Transfer(
+	login string, entryType broker.EntryType, amount decimal.Decimal, memo string,
+) (broker.Entry, error) {
+	client, err := b.GetClient(login)
+	if err != nil {
+		return broker.Entry{}, err
+	}
+
+	entry := broker.Entry{
+		ClientID: client.ID,
+		Type:     entryType,
+		Amount:   amount.Abs().Round(b.precision),
+		Memo:     memo,
+		Time:     time.Now(),
+	}
+
+	if entry.Amount == 0 {
+		return broker.Entry{}, fmt.Errorf("amount of %s must not be zero", entryType)
+	}
+
+	switch {
+	case entryType == broker.EntryDeposit && amount > 0:
+		entry.Debit, entry.Credit = broker.AccountCash, broker.AccountBank
+	case entryType == broker.EntryWithdrawal && amount > 0:
+		entry.Debit, entry.Credit = broker.AccountBank, broker.AccountCash
+	case entryType == broker.EntryAdjustment && amount > 0:
+		entry.Debit, entry.Credit = broker.AccountCash, broker.AccountEquity
+	case entryType == broker.EntryAdjustment:
+		entry.Debit, entry.Credit = broker.AccountEquity, broker.AccountCash
+	default:
+		return broker.Entry{}, fmt.Errorf("%s of %s is not allowed", entryType, b.money(amount))
+	}
+
+	b.riskMu.Lock()
+	defer b.riskMu.Unlock()
+
+	if entryType == broker.EntryWithdrawal {
+		held, err := b.reserved(client.ID, 0)
+		if err != nil {
+			return broker.Entry{}, err
+		}
+
+		if available := client.Balance - held.cash; entry.Amount > available {
+			return broker.Entry{}, broker.RiskError{
+				Check:  checkBuyingPower,
+				Reason: fmt.Sprintf("%s to withdraw, %s available", b.money(entry.Amount), b.money(available)),
+			}
+		}
+	}
+
+	if err := b.ledgerRepo.Post(&entry); err != nil {
+		return broker.Entry{}, err
+	}
+
+	b.logger.Info(ledgerAction, fmt.Sprintf("%s of %s to client %d", entryType, b.money(entry.Amount), client.ID))
+
+	return entry, nil
+}
+
+// Returns entries which move cash of client by a fill: trade debit or credit and fee.
+func (b *brokerService) fillEntries(fill broker.Deal) []broker.Entry {
+	if fill.Status == broker.DealStatusExpired || fill.Amount == 0 {
+		return nil
+	}
+
+	value := fill.Price.Mul(fill.Amount).Round(b.precision)
+	trade := broker.Entry{
+		ClientID: fill.ClientID,
+		Type:     broker.EntryTrade,
+		Debit:    broker.AccountMarket,
+		Credit:   broker.AccountCash,
+		Amount:   value,
+		DealID:   fill.ID,
+		FillID:   fill.FillID,
+		Memo:     fmt.Sprintf("%s %d %s at %s", fill.Type, fill.Amount, fill.Ticker, fill.Price),
+		Time:     fill.Time,
+	}
+
+	if fill.Type == broker.Sell {
+		trade.Debit, trade.Credit = broker.AccountCash, broker.AccountMarket
+	}
+
+	entries := []broker.Entry{trade}
+
+	if fee := value.MulFloat(b.fee).Round(b.precision); fee > 0 {
+		entries = append(entries, broker.Entry{
+			ClientID: fill.ClientID,
+			Type:     broker.EntryFee,
+			Debit:    broker.AccountFees,
+			Credit:   broker.AccountCash,
+			Amount:   fee,
+			DealID:   fill.ID,
+			FillID:   fill.FillID,
+			Time:     fill.Time,
+		})
+	}
+
+	return entries
+}
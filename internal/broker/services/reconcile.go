@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+const reconcileAction log.Action = "reconcile"
+
+// Resubmit policy of reconciliation, orphaned deals are created again by their rest.
+const policyResubmit = "resubmit"
+
+// Periodically reconciles open deals with deals resting at exchange.
+func (b *brokerService) reconcileDeals(ctx context.Context) {
+	t := time.NewTicker(b.reconcile.Interval)
+	defer t.Stop()
+
+	b.logger.Info(reconcileAction, "started")
+	defer b.logger.Info(reconcileAction, "stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := b.reconcileOnce(); err != nil {
+				b.logger.Error(reconcileAction, err)
+			}
+		}
+	}
+}
+
+// Compares open deals with deals resting at exchange. It runs only when results are caught up.
+// A deal is treated as lost only when it is missing on two runs in a row and grace has passed
+// since it was missing first, so results which are still on the way do not cause false discrepancies.
+// Grace is measured by the broker clock only, deal times are set by the exchange clock.
+func (b *brokerService) reconcileOnce() error {
+	if !b.caughtUp() {
+		b.logger.Warn(reconcileAction, "results are not caught up, reconciliation is skipped")
+		return nil
+	}
+
+	opened, err := b.dealRepo.ListOpened()
+	if err != nil {
+		return err
+	}
+
+	resting, err := b.exchange.ListOpen()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	suspects := make(map[int64]time.Time)
+
+	remote := make(map[int64]broker.Deal, len(resting))
+	for _, deal := range resting {
+		remote[deal.ID] = deal
+	}
+
+	local := make(map[int64]broker.Deal, len(opened))
+	for _, deal := range opened {
+		local[deal.ID] = deal
+
+		r, ok := remote[deal.ID]
+		if ok {
+			if rest := deal.Amount - deal.Filled; r.Amount != rest {
+				b.logger.Warn(reconcileAction, fmt.Sprintf(
+					"deal %d has rest %d at broker and %d at exchange", deal.ID, rest, r.Amount,
+				))
+			}
+
+			continue
+		}
+
+		if !b.suspect(suspects, deal.ID, now) {
+			b.logger.Warn(reconcileAction, fmt.Sprintf("deal %d is open at broker but missing at exchange", deal.ID))
+			continue
+		}
+
+		if err := b.orphan(deal); err != nil {
+			b.logger.Error(reconcileAction, err)
+		}
+	}
+
+	for _, deal := range resting {
+		if _, ok := local[deal.ID]; ok {
+			continue
+		}
+
+		if !b.suspect(suspects, deal.ID, now) {
+			b.logger.Warn(reconcileAction, fmt.Sprintf("deal %d rests at exchange but is not open at broker", deal.ID))
+			continue
+		}
+
+		ok, err := b.exchange.Cancel(deal.ID)
+		if err != nil {
+			b.logger.Error(reconcileAction, err)
+			continue
+		}
+
+		b.logger.Warn(reconcileAction, fmt.Sprintf("deal %d is canceled at exchange: %t", deal.ID, ok))
+	}
+
+	b.suspects = suspects
+
+	return nil
+}
+
+// Checks that results stream is connected and every received result is taken for settlement,
+// otherwise deals which are completed at exchange still look open at broker.
+func (b *brokerService) caughtUp() bool {
+	return b.Streams()[resultsStream] == broker.StreamConnected && len(b.results) == 0
+}
+
+// Keeps deal as suspect of this run and reports whether it is lost,
+// i.e. it was suspect of the previous run and grace has passed since it was suspected first.
+func (b *brokerService) suspect(suspects map[int64]time.Time, dealID int64, now time.Time) bool {
+	since, ok := b.suspects[dealID]
+	if !ok {
+		suspects[dealID] = now
+		return false
+	}
+
+	if now.Sub(since) < b.reconcile.Grace {
+		suspects[dealID] = since
+		return false
+	}
+
+	return true
+}
+
+// Marks deal lost by exchange as orphaned and resubmits its rest by policy.
+func (b *brokerService) orphan(deal broker.Deal) error {
+	if err := b.dealRepo.UpdateStatus(deal.ID, broker.DealStatusOrphaned); err != nil {
+		return err
+	}
+
+	if err := b.release(deal.ID); err != nil {
+		return err
+	}
+
+	b.logger.Warn(reconcileAction, fmt.Sprintf("deal %d is marked as %s", deal.ID, broker.DealStatusOrphaned))
+
+	if b.reconcile.Policy != policyResubmit {
+		return nil
+	}
+
+	rest := deal
+	rest.ID = 0
+	rest.Amount = deal.Amount - deal.Filled
+	rest.Filled = 0
+	rest.AvgPrice = 0
+	rest.Partial = false
+	rest.Time = time.Now()
+
+	created, err := b.Create(rest)
+	if err != nil {
+		return err
+	}
+
+	b.logger.Warn(reconcileAction, fmt.Sprintf("deal %d is resubmitted as %d", deal.ID, created.ID))
+
+	return nil
+}
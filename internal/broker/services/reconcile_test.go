@@ -0,0 +1,140 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/broker/repository/memory"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+// Exchange which rests given deals and records cancels.
+type restingExchange struct {
+	broker.ExchangeService
+	resting  []broker.Deal
+	canceled []int64
+}
+
+func (r *restingExchange) ListOpen() ([]broker.Deal, error) { return r.resting, nil }
+
+func (r *restingExchange) Cancel(dealID int64) (bool, error) {
+	r.canceled = append(r.canceled, dealID)
+	return true, nil
+}
+
+func newReconciled(t *testing.T, grace time.Duration, exchange broker.ExchangeService) (*brokerService, broker.DealRepo) {
+	t.Helper()
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	clients := memory.NewClientRepo()
+	deals := memory.NewDealRepo()
+	positions := memory.NewPositionRepo()
+	holds := memory.NewHoldRepo()
+	ledger := memory.NewLedgerRepo(clients)
+
+	b := NewBrokerService(
+		logger,
+		clients,
+		deals,
+		positions,
+		holds,
+		memory.NewSettlementRepo(deals, positions, holds, ledger),
+		ledger,
+		memory.NewStatisticRepo(),
+		memory.NewTradeRepo(),
+		exchange,
+		config.Broker{Reconcile: config.Reconcile{Grace: grace}},
+	).(*brokerService)
+
+	b.setStream(resultsStream, broker.StreamConnected)
+
+	return b, deals
+}
+
+func reconcile(t *testing.T, b *brokerService) {
+	t.Helper()
+
+	if err := b.reconcileOnce(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMissingDealIsOrphanedAfterGrace(t *testing.T) {
+	b, deals := newReconciled(t, 50*time.Millisecond, &restingExchange{})
+
+	if err := deals.Add(broker.Deal{ID: 7, ClientID: 1, Ticker: "A", Amount: 5, Status: broker.DealStatusNew}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The deal is suspected on the first run and kept until grace has passed.
+	reconcile(t, b)
+	reconcile(t, b)
+
+	if deal, _, _ := deals.Get(7); deal.Status != broker.DealStatusNew {
+		t.Fatalf("deal is %s within grace, want %s", deal.Status, broker.DealStatusNew)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	reconcile(t, b)
+
+	if deal, _, _ := deals.Get(7); deal.Status != broker.DealStatusOrphaned {
+		t.Errorf("deal is %s after grace, want %s", deal.Status, broker.DealStatusOrphaned)
+	}
+}
+
+func TestDealFoundAgainIsNotSuspect(t *testing.T) {
+	exchange := &restingExchange{}
+	b, deals := newReconciled(t, 0, exchange)
+
+	deal := broker.Deal{ID: 7, ClientID: 1, Ticker: "A", Amount: 5, Status: broker.DealStatusNew}
+	if err := deals.Add(deal); err != nil {
+		t.Fatal(err)
+	}
+
+	reconcile(t, b)
+
+	exchange.resting = []broker.Deal{deal}
+	reconcile(t, b)
+
+	exchange.resting = nil
+	reconcile(t, b)
+
+	if deal, _, _ := deals.Get(7); deal.Status != broker.DealStatusNew {
+		t.Errorf("deal is %s after it was missing once, want %s", deal.Status, broker.DealStatusNew)
+	}
+}
+
+func TestUnknownRestingDealIsCanceled(t *testing.T) {
+	exchange := &restingExchange{resting: []broker.Deal{{ID: 9, Ticker: "A", Amount: 1}}}
+	b, _ := newReconciled(t, 0, exchange)
+
+	reconcile(t, b)
+
+	if len(exchange.canceled) != 0 {
+		t.Fatalf("deal is canceled on the first run: %v", exchange.canceled)
+	}
+
+	reconcile(t, b)
+
+	if len(exchange.canceled) != 1 || exchange.canceled[0] != 9 {
+		t.Errorf("canceled %v, want deal 9", exchange.canceled)
+	}
+}
+
+func TestReconciliationWaitsForResults(t *testing.T) {
+	exchange := &restingExchange{resting: []broker.Deal{{ID: 9, Ticker: "A", Amount: 1}}}
+	b, _ := newReconciled(t, 0, exchange)
+
+	b.setStream(resultsStream, broker.StreamReconnecting)
+
+	reconcile(t, b)
+	reconcile(t, b)
+
+	if len(exchange.canceled) != 0 {
+		t.Errorf("deals %v are canceled while results are not caught up", exchange.canceled)
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Pre-trade risk checks.
+const (
+	checkAmount      = "amount"
+	checkOrderSize   = "order size"
+	checkPrice       = "price"
+	checkNotional    = "notional"
+	checkBuyingPower = "buying power"
+	checkPosition    = "position"
+	checkExposure    = "exposure"
+)
+
+// Default rate by which the last price is raised to value buys at market price.
+const defaultMarketBuffer = 0.05
+
+// Returns risk config with defaults.
+func riskConfig(cfg config.Risk) config.Risk {
+	if cfg.MarketBuffer <= 0 {
+		cfg.MarketBuffer = defaultMarketBuffer
+	}
+
+	return cfg
+}
+
+// Returns risk limits of client.
+func riskLimits(cfg config.Risk, login string) config.RiskLimits {
+	if limits, ok := cfg.Clients[login]; ok {
+		return limits
+	}
+
+	return cfg.RiskLimits
+}
+
+// Checks that client may create deal and returns price the deal is valued at. Caller holds risk lock.
+// Buys are limited by balance, sells by position, both reduced by holds of other open deals.
+// Buy costs its value with fee, the same cash is held for it. Sell covered by position is accepted without a price.
+func (b *brokerService) checkRisk(deal broker.Deal) (decimal.Decimal, error) {
+	if deal.Amount <= 0 {
+		return 0, broker.RiskError{Check: checkAmount, Reason: "amount must be positive"}
+	}
+
+	client, ok, err := b.clientRepo.GetByID(deal.ClientID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		return 0, fmt.Errorf("client %d not found", deal.ClientID)
+	}
+
+	limits := riskLimits(b.risk, client.Login)
+
+	if limits.MaxOrderSize > 0 && deal.Amount > limits.MaxOrderSize {
+		return 0, broker.RiskError{
+			Check:  checkOrderSize,
+			Reason: fmt.Sprintf("%d exceeds limit of %d", deal.Amount, limits.MaxOrderSize),
+		}
+	}
+
+	price, err := b.refPrice(deal)
+	if err != nil {
+		return 0, err
+	}
+
+	held, err := b.reserved(client.ID, deal.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	position, err := b.position(client.ID, deal.Ticker)
+	if err != nil {
+		return 0, err
+	}
+
+	if deal.Type == broker.Sell {
+		if available := position - held.selling[deal.Ticker]; deal.Amount > available {
+			return 0, broker.RiskError{
+				Check:  checkPosition,
+				Reason: fmt.Sprintf("%d of %s to sell, %d available", deal.Amount, deal.Ticker, available),
+			}
+		}
+
+		// Sell covered by position holds no cash, so without a price only its notional is not checked.
+		if price <= 0 {
+			return 0, nil
+		}
+
+		if err := b.limitNotional(limits, price, deal.Amount); err != nil {
+			return 0, err
+		}
+
+		return price, nil
+	}
+
+	if price <= 0 {
+		return 0, broker.RiskError{Check: checkPrice, Reason: fmt.Sprintf("no price of %s to value the deal", deal.Ticker)}
+	}
+
+	if err := b.limitNotional(limits, price, deal.Amount); err != nil {
+		return 0, err
+	}
+
+	if cost, power := b.cost(price, deal.Amount), client.Balance-held.cash; cost > power {
+		return 0, broker.RiskError{
+			Check:  checkBuyingPower,
+			Reason: fmt.Sprintf("%s to buy, %s available", b.money(cost), b.money(power)),
+		}
+	}
+
+	limit := limits.MaxExposure
+	if l, ok := limits.Exposure[deal.Ticker]; ok {
+		limit = l
+	}
+
+	if exposure := price.Mul(position + held.buying[deal.Ticker] + deal.Amount); limit > 0 && exposure > limit {
+		return 0, broker.RiskError{
+			Check:  checkExposure,
+			Reason: fmt.Sprintf("%s of %s exceeds limit of %s", b.money(exposure), deal.Ticker, b.money(limit)),
+		}
+	}
+
+	return price, nil
+}
+
+// Checks that value of a deal does not exceed notional limit.
+func (b *brokerService) limitNotional(limits config.RiskLimits, price decimal.Decimal, amount int32) error {
+	notional := price.Mul(amount)
+	if limits.MaxNotional > 0 && notional > limits.MaxNotional {
+		return broker.RiskError{
+			Check:  checkNotional,
+			Reason: fmt.Sprintf("%s exceeds limit of %s", b.money(notional), b.money(limits.MaxNotional)),
+		}
+	}
+
+	return nil
+}
+
+// Returns price to value deal. Limit price is used when it is set, otherwise stop price or the last price of ticker.
+// Buy which executes at market price may cost more, so its price is raised by market buffer.
+func (b *brokerService) refPrice(deal broker.Deal) (decimal.Decimal, error) {
+	if (deal.OrderType == broker.Limit || deal.OrderType == broker.StopLimit) && deal.Price > 0 {
+		return deal.Price, nil
+	}
+
+	price := deal.StopPrice
+	if deal.OrderType != broker.Stop || price <= 0 {
+		var err error
+		if price, err = b.lastPrice(deal.Ticker); err != nil {
+			return 0, err
+		}
+	}
+
+	if deal.Type == broker.Buy {
+		price += price.MulFloat(b.risk.MarketBuffer)
+	}
+
+	return price, nil
+}
+
+// Returns the last known price of ticker from trade tape or statistic.
+func (b *brokerService) lastPrice(ticker string) (decimal.Decimal, error) {
+	trades, err := b.tradeRepo.Get(ticker)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(trades) > 0 {
+		return trades[0].Price, nil
+	}
+
+	history, err := b.statRepo.Get(ticker, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(history) > 0 {
+		return history[0].Close, nil
+	}
+
+	return 0, nil
+}
+
+// Returns amount of ticker held by client.
+func (b *brokerService) position(clientID int64, ticker string) (int32, error) {
+	positions, err := b.posRepo.Get(clientID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range positions {
+		if p.Ticker == ticker {
+			return p.Amount, nil
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+)
+
+var errNoFillID = errors.New("result has no fill identifier")
+
+// Settle applies result of deal to deal, position and ledger of client in one transaction.
+// Fill converts hold of deal into debit, expiry releases the hold. Result which has already been applied is skipped.
+func (b *brokerService) Settle(deal broker.Deal) error {
+	if deal.FillID == 0 {
+		return fmt.Errorf("deal %d: %w", deal.ID, errNoFillID)
+	}
+
+	b.riskMu.Lock()
+	defer b.riskMu.Unlock()
+
+	settlement, err := b.settlement(deal)
+	if err != nil {
+		return err
+	}
+
+	applied, err := b.settleRepo.Apply(settlement)
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		b.logger.Warn(dealsAction, fmt.Sprintf("fill %d of deal %d is already applied", deal.FillID, deal.ID))
+	}
+
+	return nil
+}
+
+// Builds settlement of a result. Part of hold is converted into debit of a fill, the rest stays reserved.
+// Hold of a deal which is no longer open is released. Caller holds risk lock.
+func (b *brokerService) settlement(fill broker.Deal) (broker.Settlement, error) {
+	settlement := broker.Settlement{Fill: fill, Entries: b.fillEntries(fill)}
+
+	hold, ok, err := b.holdRepo.Get(fill.ID)
+	if err != nil || !ok {
+		return settlement, err
+	}
+
+	if fill.Status != broker.DealStatusNew || fill.Amount >= hold.Amount {
+		settlement.Release = true
+		return settlement, nil
+	}
+
+	hold.Cash -= hold.Cash.Mul(fill.Amount).Div(int64(hold.Amount))
+	hold.Amount -= fill.Amount
+	settlement.Hold = hold
+
+	return settlement, nil
+}
+
+// Returns sequence of the last applied result. Results are resumed after it.
+func (b *brokerService) appliedSeq() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.resultSeq
+}
+
+// Advances sequence of the last applied result. Replayed result and result which is not streamed are ignored.
+func (b *brokerService) setResultSeq(seq int64) {
+	b.mu.Lock()
+	if seq > b.resultSeq {
+		b.resultSeq = seq
+	}
+	b.mu.Unlock()
+}
+
+// Settles result received from exchange. Failed settlement is rolled back,
+// so it is retried with backoff until it succeeds or context is done.
+func (b *brokerService) settle(ctx context.Context, deal broker.Deal) {
+	delay := b.reconnect.Min
+
+	for {
+		err := b.Settle(deal)
+		if err == nil {
+			b.setResultSeq(deal.Seq)
+			return
+		}
+
+		if errors.Is(err, errNoFillID) {
+			b.logger.Error(dealsAction, err)
+			b.setResultSeq(deal.Seq)
+			return
+		}
+
+		b.logger.Warn(dealsAction, fmt.Sprintf(
+			"settlement of deal %d failed: %v, retrying in %s", deal.ID, err, delay.Round(time.Millisecond),
+		))
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+
+		delay = time.Duration(float64(delay) * b.reconnect.Factor)
+		if delay > b.reconnect.Max {
+			delay = b.reconnect.Max
+		}
+	}
+}
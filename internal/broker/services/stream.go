@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+const (
+	defaultReconnectMin    = 500 * time.Millisecond
+	defaultReconnectMax    = 30 * time.Second
+	defaultReconnectFactor = 2
+)
+
+const (
+	statStream    = "statistic"
+	resultsStream = "results"
+	tradesStream  = "trades"
+)
+
+// Returns reconnection backoff with defaults.
+func reconnectConfig(cfg config.Reconnect) config.Reconnect {
+	if cfg.Min <= 0 {
+		cfg.Min = defaultReconnectMin
+	}
+
+	if cfg.Max <= 0 {
+		cfg.Max = defaultReconnectMax
+	}
+
+	if cfg.Max < cfg.Min {
+		cfg.Max = cfg.Min
+	}
+
+	if cfg.Factor <= 1 {
+		cfg.Factor = defaultReconnectFactor
+	}
+
+	return cfg
+}
+
+// Streams returns state of exchange streams.
+func (b *brokerService) Streams() map[string]broker.StreamState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	streams := make(map[string]broker.StreamState, len(b.streams))
+	for name, state := range b.streams {
+		streams[name] = state
+	}
+
+	return streams
+}
+
+// Sets state of exchange stream.
+func (b *brokerService) setStream(name string, state broker.StreamState) {
+	b.mu.Lock()
+	b.streams[name] = state
+	b.mu.Unlock()
+}
+
+// Runs exchange stream until context is done. Stream is connecting until it calls connected.
+// Lost stream is reconnected with exponential backoff and jitter,
+// backoff is reset when stream has lived longer than max delay.
+func (b *brokerService) supervise(
+	ctx context.Context, name string, action log.Action, stream func(ctx context.Context, connected func()) error,
+) {
+	defer b.setStream(name, broker.StreamStopped)
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	delay := b.reconnect.Min
+	attempt := 0
+
+	for {
+		if attempt > 0 {
+			b.logger.Info(action, fmt.Sprintf("reconnecting %s stream, attempt %d", name, attempt))
+		}
+
+		b.setStream(name, broker.StreamConnecting)
+
+		started := time.Now()
+		err := stream(ctx, func() { b.setStream(name, broker.StreamConnected) })
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) >= b.reconnect.Max {
+			delay, attempt = b.reconnect.Min, 0
+		}
+
+		if err == nil {
+			err = fmt.Errorf("stream is closed by exchange")
+		}
+
+		// Half of delay is random, so brokers do not reconnect all at once.
+		wait := delay/2 + time.Duration(rnd.Int63n(int64(delay/2)+1))
+		attempt++
+
+		b.setStream(name, broker.StreamReconnecting)
+		b.logger.Warn(action, fmt.Sprintf("%s stream is lost: %v, reconnecting in %s", name, err, wait.Round(time.Millisecond)))
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+
+		delay = time.Duration(float64(delay) * b.reconnect.Factor)
+		if delay > b.reconnect.Max {
+			delay = b.reconnect.Max
+		}
+	}
+}
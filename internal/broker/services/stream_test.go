@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/broker"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+func newSupervisor() *brokerService {
+	return &brokerService{
+		logger:    log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean()),
+		reconnect: reconnectConfig(config.Reconnect{Min: time.Hour}),
+		mu:        &sync.RWMutex{},
+		streams:   make(map[string]broker.StreamState),
+	}
+}
+
+// Waits until stream has the state.
+func waitStream(t *testing.T, b *brokerService, want broker.StreamState) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for b.Streams()[tradesStream] != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("stream is %s, want %s", b.Streams()[tradesStream], want)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStreamIsConnectingUntilEstablished(t *testing.T) {
+	b := newSupervisor()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	establish := make(chan struct{})
+	lose := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		b.supervise(ctx, tradesStream, tradesGrpcAction, func(ctx context.Context, connected func()) error {
+			<-establish
+			connected()
+			<-lose
+
+			return nil
+		})
+		close(done)
+	}()
+
+	waitStream(t, b, broker.StreamConnecting)
+
+	close(establish)
+	waitStream(t, b, broker.StreamConnected)
+
+	close(lose)
+	waitStream(t, b, broker.StreamReconnecting)
+
+	cancel()
+	<-done
+
+	if state := b.Streams()[tradesStream]; state != broker.StreamStopped {
+		t.Errorf("stream is %s after stop, want %s", state, broker.StreamStopped)
+	}
+}
+
+func TestReconnectConfigDefaults(t *testing.T) {
+	tests := []struct {
+		in   config.Reconnect
+		want config.Reconnect
+	}{
+		{config.Reconnect{}, config.Reconnect{Min: defaultReconnectMin, Max: defaultReconnectMax, Factor: defaultReconnectFactor}},
+		{config.Reconnect{Min: time.Minute, Max: time.Second, Factor: 1}, config.Reconnect{Min: time.Minute, Max: time.Minute, Factor: 2}},
+		{config.Reconnect{Min: time.Second, Max: time.Minute, Factor: 3}, config.Reconnect{Min: time.Second, Max: time.Minute, Factor: 3}},
+	}
+
+	for _, tt := range tests {
+		if got := reconnectConfig(tt.in); got != tt.want {
+			t.Errorf("reconnectConfig(%+v) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestReconnectBacksOffExponentially(t *testing.T) {
+	b := newSupervisor()
+	b.reconnect = reconnectConfig(config.Reconnect{Min: 20 * time.Millisecond, Max: 80 * time.Millisecond, Factor: 2})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts []time.Time
+
+	done := make(chan struct{})
+
+	go func() {
+		b.supervise(ctx, tradesStream, tradesGrpcAction, func(ctx context.Context, connected func()) error {
+			attempts = append(attempts, time.Now())
+			if len(attempts) == 6 {
+				cancel()
+			}
+
+			return nil
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream is not reconnected")
+	}
+
+	// Half of delay is random, so every wait is at least half of its delay, which doubles up to max.
+	for i, delay := range []time.Duration{20, 40, 80, 80, 80} {
+		delay *= time.Millisecond
+		if wait := attempts[i+1].Sub(attempts[i]); wait < delay/2 {
+			t.Errorf("wait %d is %s, want at least %s", i, wait, delay/2)
+		}
+	}
+}
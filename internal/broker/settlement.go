@@ -0,0 +1,27 @@
+package broker
+
+// Settlement changes made by a result of deal. Fill is applied to deal and position of client,
+// Entries move cash of client, Hold is the rest of deal hold after the fill, it is released when Release is set.
+// Sequence of the fill is stored as the last applied result even when the fill is already applied,
+// so results are resumed after it.
+type Settlement struct {
+	Fill    Deal
+	Entries []Entry
+	Hold    Hold
+	Release bool
+}
+
+// SettlementRepo settlement repository, it changes deals together with their holds.
+// Apply applies settlement atomically and returns false when the fill has already been applied,
+// LastSeq returns sequence of the last applied result.
+// Open adds pending deal with its hold before the deal is sent to exchange, Confirm moves both of them
+// to identifier given by exchange and opens the deal, Abort deletes pending deal with its hold.
+// AbortPending aborts every pending deal, e.g. deals left by a crash, and returns their number.
+type SettlementRepo interface {
+	Apply(settlement Settlement) (bool, error)
+	LastSeq() (int64, error)
+	Open(deal Deal, hold Hold) error
+	Confirm(pendingID, dealID int64) error
+	Abort(pendingID int64) error
+	AbortPending() (int, error)
+}
@@ -1,6 +1,10 @@
 package broker
 
-import "time"
+import (
+	"time"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
 
 // OHLCV statistic.
 type OHLCV struct {
@@ -8,15 +12,15 @@ type OHLCV struct {
 	Ticker   string
 	Time     time.Time
 	Interval time.Duration
-	Open     float64
-	High     float64
-	Low      float64
-	Close    float64
+	Open     decimal.Decimal
+	High     decimal.Decimal
+	Low      decimal.Decimal
+	Close    decimal.Decimal
 	Volume   int32
 }
 
 // StatisticRepo statistic repository.
 type StatisticRepo interface {
 	Add(ohlcv OHLCV) error
-	Get(ticker string) ([]OHLCV, error)
+	Get(ticker string, interval time.Duration) ([]OHLCV, error)
 }
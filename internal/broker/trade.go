@@ -0,0 +1,23 @@
+package broker
+
+import (
+	"time"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Trade anonymous print of an execution on exchange. Type is the side of the aggressor.
+type Trade struct {
+	ID     int64
+	Ticker string
+	Price  decimal.Decimal
+	Amount int32
+	Type   DealType
+	Time   time.Time
+}
+
+// TradeRepo trade tape repository.
+type TradeRepo interface {
+	Add(trade Trade) error
+	Get(ticker string) ([]Trade, error)
+}
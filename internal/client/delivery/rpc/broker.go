@@ -7,30 +7,49 @@ import (
 	"strings"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/marksartdev/trading/internal/broker"
 	"github.com/marksartdev/trading/internal/broker/delivery/rpc"
 	"github.com/marksartdev/trading/internal/client"
+	"github.com/marksartdev/trading/internal/decimal"
 	"github.com/marksartdev/trading/internal/log"
 )
 
 const (
-	timeout    = 5 * time.Second
-	layout     = "Time: %s  Int: %ds  O: %.2f  H: %.2f  L: %.2f  C: %.2f  Val: %d"
-	timeLayout = "15:04"
+	timeout     = 5 * time.Second
+	layout      = "Time: %s  Int: %ds  O: %s  H: %s  L: %s  C: %s  Val: %d"
+	timeLayout  = "15:04"
+	depthLayout = "    %s  %d  (%d)"
+	// Number of the last cash movements shown to user.
+	ledgerSize = 20
 )
 
 const (
 	createAction  log.Action = "create"
 	cancelAction  log.Action = "cancel"
+	modifyAction  log.Action = "modify"
 	profileAction log.Action = "profile"
 	statAction    log.Action = "statistic"
+	depthAction   log.Action = "depth"
+	ledgerAction  log.Action = "ledger"
 )
 
 // BrokerService delivery service, which responses with strings.
 type BrokerService interface {
-	Create(login string, ticker, dealType string, amount int32, price float64) (string, error)
+	Create(
+		login string,
+		ticker, dealType, orderType, timeInForce string,
+		amount int32,
+		price, stopPrice decimal.Decimal,
+	) (string, error)
 	Cancel(login string, dealID int64) (string, error)
+	Modify(login string, dealID int64, amount int32, price decimal.Decimal) (string, error)
 	Profile(login string) (string, error)
 	Statistic(login string, ticker string) (string, error)
+	Depth(login string, ticker string) (string, error)
+	Ledger(login string) (string, error)
 }
 
 // Broker service.
@@ -45,21 +64,35 @@ func NewBrokerService(logger log.Logger, client rpc.BrokerClient) BrokerService
 }
 
 // Create sends deal to broker.
-func (b brokerService) Create(login string, ticker, dealType string, amount int32, price float64) (string, error) {
+func (b brokerService) Create(
+	login string,
+	ticker, dealType, orderType, timeInForce string,
+	amount int32,
+	price, stopPrice decimal.Decimal,
+) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	req := rpc.CreateDeal{
-		Client: &rpc.Client{Login: login},
-		Ticker: ticker,
-		Type:   dealType,
-		Amount: amount,
-		Price:  price,
+		Client:      &rpc.Client{Login: login},
+		Ticker:      ticker,
+		Type:        dealType,
+		OrderType:   orderType,
+		TimeInForce: timeInForce,
+		Amount:      amount,
+		Price:       rpc.NewDecimal(price),
+		StopPrice:   rpc.NewDecimal(stopPrice),
 	}
 
 	resp, err := b.client.Create(ctx, &req)
 	if err != nil {
 		b.logger.Error(createAction, err)
+
+		// Deals rejected by risk checks or by exchange are explained to user.
+		if s, ok := status.FromError(err); ok && s.Code() == codes.FailedPrecondition {
+			return fmt.Sprintf("Сделка отклонена: %s", s.Message()), nil
+		}
+
 		return "", err
 	}
 
@@ -89,6 +122,36 @@ func (b brokerService) Cancel(login string, dealID int64) (string, error) {
 	return fmt.Sprintf("Не удалось отменить заявку №%d", dealID), nil
 }
 
+// Modify sends request to change deal.
+func (b brokerService) Modify(login string, dealID int64, amount int32, price decimal.Decimal) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req := rpc.ModifyDeal{
+		Client: &rpc.Client{Login: login},
+		DealID: &rpc.DealID{ID: dealID},
+		Price:  rpc.NewDecimal(price),
+		Amount: amount,
+	}
+
+	resp, err := b.client.Modify(ctx, &req)
+	if err != nil {
+		b.logger.Error(modifyAction, err)
+
+		if s, ok := status.FromError(err); ok && s.Code() == codes.FailedPrecondition {
+			return fmt.Sprintf("Изменение отклонено: %s", s.Message()), nil
+		}
+
+		return "", err
+	}
+
+	if resp.GetOK() {
+		return fmt.Sprintf("Заявка №%d успешно изменена", dealID), nil
+	}
+
+	return fmt.Sprintf("Не удалось изменить заявку №%d", dealID), nil
+}
+
 // Profile returns client's profile.
 func (b brokerService) Profile(login string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -103,22 +166,62 @@ func (b brokerService) Profile(login string) (string, error) {
 
 	res := []string{
 		"Ваш профиль",
-		fmt.Sprintf("Баланс: %.2f", resp.Balance),
+		fmt.Sprintf("Баланс: %s (доступно %s)", resp.GetBalance().Decimal(), resp.GetAvailable().Decimal()),
 		"",
 		"Активы:",
 	}
 
 	positions := resp.GetPositions()
 	for i := range positions {
-		res = append(res, fmt.Sprintf("    %s: %d", positions[i].Ticker, positions[i].Amount))
+		res = append(res, fmt.Sprintf(
+			"    %s: %d (доступно %d)", positions[i].Ticker, positions[i].Amount, positions[i].Available,
+		))
 	}
 
 	res = append(res, "", "Открытые сделки:")
 
 	deals := resp.GetDeals()
 	for i := range deals {
-		res = append(res, fmt.Sprintf("    %d  %s  %s  %d  %.2f",
-			deals[i].ID, deals[i].Ticker, deals[i].Type, deals[i].Amount, deals[i].Price))
+		res = append(res, fmt.Sprintf("    %d  %s  %s  %s  %s  %d/%d  %s  %s  avg %s",
+			deals[i].ID, deals[i].Ticker, deals[i].Type, deals[i].OrderType, deals[i].TimeInForce,
+			deals[i].Filled, deals[i].Amount, deals[i].Price.Decimal(), deals[i].StopPrice.Decimal(), deals[i].AvgPrice.Decimal()))
+	}
+
+	return strings.Join(res, "\n"), nil
+}
+
+// Ledger returns the last cash movements of client.
+func (b brokerService) Ledger(login string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req := rpc.Client{Login: login}
+	resp, err := b.client.Ledger(ctx, &req)
+	if err != nil {
+		b.logger.Error(ledgerAction, err)
+		return "", err
+	}
+
+	movements := resp.GetMovements()
+	if len(movements) > ledgerSize {
+		movements = movements[len(movements)-ledgerSize:]
+	}
+
+	res := []string{"Движение денежных средств"}
+
+	for _, m := range movements {
+		amount := "+" + m.GetAmount().Decimal().String()
+		if m.GetCredit() == string(broker.AccountCash) {
+			amount = "-" + m.GetAmount().Decimal().String()
+		}
+
+		line := fmt.Sprintf("    %s  %s  %s  = %s",
+			time.Unix(m.GetTime(), 0).Format("02.01 15:04"), m.GetType(), amount, m.GetBalance().Decimal())
+		if m.GetMemo() != "" {
+			line += "  " + m.GetMemo()
+		}
+
+		res = append(res, line)
 	}
 
 	return strings.Join(res, "\n"), nil
@@ -149,19 +252,19 @@ func (b brokerService) Statistic(login string, ticker string) (string, error) {
 		item := data[tm]
 
 		if item.Open == 0 {
-			item.Open = prices[i].GetOpen()
-			item.Low = prices[i].GetLow()
+			item.Open = prices[i].GetOpen().Decimal()
+			item.Low = prices[i].GetLow().Decimal()
 		}
 
-		if item.High < prices[i].GetHigh() {
-			item.High = prices[i].GetHigh()
+		if item.High < prices[i].GetHigh().Decimal() {
+			item.High = prices[i].GetHigh().Decimal()
 		}
 
-		if item.Low > prices[i].GetLow() {
-			item.Low = prices[i].GetLow()
+		if item.Low > prices[i].GetLow().Decimal() {
+			item.Low = prices[i].GetLow().Decimal()
 		}
 
-		item.Close = prices[i].GetClose()
+		item.Close = prices[i].GetClose().Decimal()
 		item.Volume += prices[i].GetVol()
 		item.Interval += int32(time.Duration(prices[i].GetInterval()).Seconds())
 
@@ -196,3 +299,46 @@ func (b brokerService) Statistic(login string, ticker string) (string, error) {
 
 	return strings.Join(res, "\n"), nil
 }
+
+// Depth returns snapshot of ticker market depth.
+func (b brokerService) Depth(login string, ticker string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req := rpc.DepthRequest{
+		Client: &rpc.Client{Login: login},
+		Ticker: ticker,
+	}
+
+	stream, err := b.client.Depth(ctx, &req)
+	if err != nil {
+		b.logger.Error(depthAction, err)
+		return "", err
+	}
+
+	var resp *rpc.MarketDepth
+
+	for resp == nil || !resp.GetSnapshot() {
+		resp, err = stream.Recv()
+		if err != nil {
+			b.logger.Error(depthAction, err)
+			return "", err
+		}
+	}
+
+	res := []string{fmt.Sprintf("Ticker: %s", ticker), "", "Продажа:"}
+
+	asks := resp.GetAsks()
+	for i := len(asks) - 1; i >= 0; i-- {
+		res = append(res, fmt.Sprintf(depthLayout, asks[i].GetPrice().Decimal(), asks[i].GetAmount(), asks[i].GetOrders()))
+	}
+
+	res = append(res, "", "Покупка:")
+
+	bids := resp.GetBids()
+	for i := range bids {
+		res = append(res, fmt.Sprintf(depthLayout, bids[i].GetPrice().Decimal(), bids[i].GetAmount(), bids[i].GetOrders()))
+	}
+
+	return strings.Join(res, "\n"), nil
+}
@@ -5,7 +5,9 @@ type action int
 const (
 	create action = iota
 	cancel
+	modify
 	statistic
+	depth
 )
 
 type actionPlane struct {
@@ -21,8 +23,11 @@ func createPlan() actionPlane {
 		questions: []string{
 			"Введите название тикера",
 			"Введите тип операции (BUY/SELL)",
+			"Введите тип заявки (MARKET/LIMIT/STOP/STOP_LIMIT)",
+			"Введите срок действия заявки (GTC/IOC/FOK/DAY)",
 			"Введите желаемое количество",
-			"Введите желаемую стоимость",
+			"Введите желаемую стоимость (0 для MARKET и STOP)",
+			"Введите стоп-цену (0 для MARKET и LIMIT)",
 		},
 	}
 
@@ -44,6 +49,21 @@ func cancelPlan() actionPlane {
 	return plan
 }
 
+func modifyPlan() actionPlane {
+	plan := actionPlane{
+		action: modify,
+		questions: []string{
+			"Введите идентификатор сделки",
+			"Введите новое количество (0, чтобы не менять)",
+			"Введите новую стоимость (0, чтобы не менять)",
+		},
+	}
+
+	plan.answers = make([]string, len(plan.questions))
+
+	return plan
+}
+
 func statPlan() actionPlane {
 	plan := actionPlane{
 		action: statistic,
@@ -56,3 +76,16 @@ func statPlan() actionPlane {
 
 	return plan
 }
+
+func depthPlan() actionPlane {
+	plan := actionPlane{
+		action: depth,
+		questions: []string{
+			"Введите название тикера",
+		},
+	}
+
+	plan.answers = make([]string, len(plan.questions))
+
+	return plan
+}
@@ -3,11 +3,13 @@ package services
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/marksartdev/trading/internal/client"
 	"github.com/marksartdev/trading/internal/client/delivery/rpc"
 	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
 	"github.com/marksartdev/trading/internal/log"
 )
 
@@ -53,6 +55,11 @@ func (t *telegramBot) Start() error {
 
 		t.logger.Info(botAction, fmt.Sprintf("[%s] %s", update.Message.From.UserName, update.Message.Text))
 
+		if update.Message.Command() == "depth" {
+			t.depth(update.Message.Chat.ID, int64(update.Message.From.ID), update.Message.CommandArguments())
+			continue
+		}
+
 		switch update.Message.Text {
 		case "/create":
 			t.chats[update.Message.Chat.ID] = createPlan()
@@ -60,8 +67,13 @@ func (t *telegramBot) Start() error {
 		case "/cancel":
 			t.chats[update.Message.Chat.ID] = cancelPlan()
 			t.input(update.Message.Chat.ID, "")
+		case "/modify":
+			t.chats[update.Message.Chat.ID] = modifyPlan()
+			t.input(update.Message.Chat.ID, "")
 		case "/profile":
 			t.profile(update.Message.Chat.ID, int64(update.Message.From.ID))
+		case "/ledger":
+			t.ledger(update.Message.Chat.ID, int64(update.Message.From.ID))
 		case "/statistic":
 			t.chats[update.Message.Chat.ID] = statPlan()
 			t.input(update.Message.Chat.ID, "")
@@ -72,8 +84,12 @@ func (t *telegramBot) Start() error {
 					t.create(update.Message.Chat.ID, int64(update.Message.From.ID))
 				case cancel:
 					t.cancel(update.Message.Chat.ID, int64(update.Message.From.ID))
+				case modify:
+					t.modify(update.Message.Chat.ID, int64(update.Message.From.ID))
 				case statistic:
 					t.statistic(update.Message.Chat.ID, int64(update.Message.From.ID))
+				case depth:
+					t.depth(update.Message.Chat.ID, int64(update.Message.From.ID), t.chats[update.Message.Chat.ID].answers[0])
 				}
 			}
 		}
@@ -96,19 +112,41 @@ func (t *telegramBot) create(chatID, userID int64) {
 		return
 	}
 
-	amn, err := strconv.Atoi(chat.answers[2])
+	orderType := chat.answers[2]
+	if orderType != "MARKET" && orderType != "LIMIT" && orderType != "STOP" && orderType != "STOP_LIMIT" {
+		t.sendMsg(chatID, "Вы ввели некорректный тип заявки")
+		t.sendMsg(chatID, "Возможны только MARKET, LIMIT, STOP и STOP_LIMIT")
+		t.sendMsg(chatID, "Придется начать сначала =(")
+		return
+	}
+
+	timeInForce := chat.answers[3]
+	if timeInForce != "GTC" && timeInForce != "IOC" && timeInForce != "FOK" && timeInForce != "DAY" {
+		t.sendMsg(chatID, "Вы ввели некорректный срок действия заявки")
+		t.sendMsg(chatID, "Возможны только GTC, IOC, FOK и DAY")
+		t.sendMsg(chatID, "Придется начать сначала =(")
+		return
+	}
+
+	amn, err := strconv.Atoi(chat.answers[4])
+	if err != nil {
+		t.handleErr(chatID, err)
+		return
+	}
+
+	price, err := decimal.Parse(chat.answers[5])
 	if err != nil {
 		t.handleErr(chatID, err)
 		return
 	}
 
-	price, err := strconv.ParseFloat(chat.answers[3], 64)
+	stopPrice, err := decimal.Parse(chat.answers[6])
 	if err != nil {
 		t.handleErr(chatID, err)
 		return
 	}
 
-	msg, err := t.broker.Create(login, chat.answers[0], chat.answers[1], int32(amn), price)
+	msg, err := t.broker.Create(login, chat.answers[0], dealType, orderType, timeInForce, int32(amn), price, stopPrice)
 	if err != nil {
 		t.handleErr(chatID, err)
 		return
@@ -138,6 +176,39 @@ func (t *telegramBot) cancel(chatID, userID int64) {
 	t.sendMsg(chatID, msg)
 }
 
+func (t *telegramBot) modify(chatID, userID int64) {
+	defer delete(t.chats, chatID)
+	chat := t.chats[chatID]
+
+	login := t.getLogin(userID)
+
+	dealID, err := strconv.ParseInt(chat.answers[0], 10, 64)
+	if err != nil {
+		t.handleErr(chatID, err)
+		return
+	}
+
+	amn, err := strconv.Atoi(chat.answers[1])
+	if err != nil {
+		t.handleErr(chatID, err)
+		return
+	}
+
+	price, err := decimal.Parse(chat.answers[2])
+	if err != nil {
+		t.handleErr(chatID, err)
+		return
+	}
+
+	msg, err := t.broker.Modify(login, dealID, int32(amn), price)
+	if err != nil {
+		t.handleErr(chatID, err)
+		return
+	}
+
+	t.sendMsg(chatID, msg)
+}
+
 func (t *telegramBot) profile(chatID, userID int64) {
 	login := t.getLogin(userID)
 	msg, err := t.broker.Profile(login)
@@ -149,6 +220,17 @@ func (t *telegramBot) profile(chatID, userID int64) {
 	t.sendMsg(chatID, msg)
 }
 
+func (t *telegramBot) ledger(chatID, userID int64) {
+	login := t.getLogin(userID)
+	msg, err := t.broker.Ledger(login)
+	if err != nil {
+		t.handleErr(chatID, err)
+		return
+	}
+
+	t.sendMsg(chatID, msg)
+}
+
 func (t *telegramBot) statistic(chatID, userID int64) {
 	defer delete(t.chats, chatID)
 	chat := t.chats[chatID]
@@ -163,6 +245,25 @@ func (t *telegramBot) statistic(chatID, userID int64) {
 	t.sendMsg(chatID, msg)
 }
 
+func (t *telegramBot) depth(chatID, userID int64, ticker string) {
+	ticker = strings.TrimSpace(ticker)
+	if ticker == "" {
+		t.chats[chatID] = depthPlan()
+		t.input(chatID, "")
+		return
+	}
+	defer delete(t.chats, chatID)
+
+	login := t.getLogin(userID)
+	msg, err := t.broker.Depth(login, ticker)
+	if err != nil {
+		t.handleErr(chatID, err)
+		return
+	}
+
+	t.sendMsg(chatID, msg)
+}
+
 func (t *telegramBot) input(chatID int64, msg string) bool {
 	chat, ok := t.chats[chatID]
 	if !ok {
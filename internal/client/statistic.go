@@ -1,11 +1,13 @@
 package client
 
+import "github.com/marksartdev/trading/internal/decimal"
+
 // OHLCV statistic.
 type OHLCV struct {
 	Interval int32
-	Open     float64
-	High     float64
-	Low      float64
-	Close    float64
+	Open     decimal.Decimal
+	High     decimal.Decimal
+	Low      decimal.Decimal
+	Close    decimal.Decimal
 	Volume   int32
 }
@@ -0,0 +1,65 @@
+package clock
+
+import "time"
+
+// Clock source of time.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker delivers ticks at intervals of clock time.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer delivers single event after a duration of clock time.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// Wall clock.
+type realClock struct{}
+
+// Real returns wall clock.
+func Real() Clock {
+	return realClock{}
+}
+
+// Now returns current time.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker creates new ticker.
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// NewTimer creates new timer.
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// Wrapper on time.Ticker.
+type realTicker struct {
+	*time.Ticker
+}
+
+// C returns channel of ticks.
+func (r realTicker) C() <-chan time.Time {
+	return r.Ticker.C
+}
+
+// Wrapper on time.Timer.
+type realTimer struct {
+	*time.Timer
+}
+
+// C returns channel of event.
+func (r realTimer) C() <-chan time.Time {
+	return r.Timer.C
+}
@@ -0,0 +1,63 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marksartdev/trading/internal/clock"
+)
+
+var start = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func TestVirtualClockFiresDueWaiters(t *testing.T) {
+	clk := clock.NewVirtual(start)
+
+	timer := clk.NewTimer(time.Minute)
+	ticker := clk.NewTicker(time.Second)
+
+	clk.Set(start.Add(30 * time.Second))
+
+	select {
+	case <-timer.C():
+		t.Fatal("timer fires early")
+	case at := <-ticker.C():
+		if !at.Equal(start.Add(time.Second)) {
+			t.Errorf("ticker fires at %s, want %s", at, start.Add(time.Second))
+		}
+	default:
+		t.Fatal("ticker does not fire")
+	}
+
+	clk.Set(start.Add(time.Minute))
+
+	if at := <-timer.C(); !at.Equal(start.Add(time.Minute)) {
+		t.Errorf("timer fires at %s, want %s", at, start.Add(time.Minute))
+	}
+
+	if timer.Stop() {
+		t.Error("fired timer is stopped")
+	}
+
+	clk.Set(start)
+
+	if now := clk.Now(); !now.Equal(start.Add(time.Minute)) {
+		t.Errorf("clock is moved back to %s", now)
+	}
+}
+
+func TestScaledClockRunsFaster(t *testing.T) {
+	clk := clock.NewScaled(start, 36000)
+
+	timer := clk.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Fatal("hour of clock time lasts longer than a second of wall time")
+	}
+
+	if elapsed := clk.Now().Sub(start); elapsed < time.Hour {
+		t.Errorf("clock has moved by %s, want at least an hour", elapsed)
+	}
+}
@@ -0,0 +1,49 @@
+package clock
+
+import "time"
+
+// Clock which starts at a given time and runs faster than wall clock.
+type scaledClock struct {
+	start  time.Time
+	origin time.Time
+	speed  float64
+}
+
+// NewScaled creates clock starting at start time and running speed times faster than wall clock.
+func NewScaled(start time.Time, speed float64) Clock {
+	if speed <= 0 {
+		speed = 1
+	}
+
+	return scaledClock{start: start, origin: time.Now(), speed: speed}
+}
+
+// Now returns current clock time.
+func (s scaledClock) Now() time.Time {
+	return s.start.Add(s.scale(time.Since(s.origin)))
+}
+
+// NewTicker creates new ticker with interval of clock time.
+func (s scaledClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(s.wall(d))}
+}
+
+// NewTimer creates new timer with duration of clock time.
+func (s scaledClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(s.wall(d))}
+}
+
+// Converts wall duration to clock duration.
+func (s scaledClock) scale(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * s.speed)
+}
+
+// Converts clock duration to wall duration.
+func (s scaledClock) wall(d time.Duration) time.Duration {
+	w := time.Duration(float64(d) / s.speed)
+	if w <= 0 && d > 0 {
+		w = 1
+	}
+
+	return w
+}
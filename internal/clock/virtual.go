@@ -0,0 +1,140 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// VirtualClock clock which moves only when it is set.
+type VirtualClock interface {
+	Clock
+	Set(t time.Time)
+}
+
+// Waiter of a virtual timer or ticker.
+type waiter struct {
+	at     time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+// Clock which moves only when it is set.
+type virtualClock struct {
+	mu      *sync.Mutex
+	now     time.Time
+	waiters map[*waiter]struct{}
+}
+
+// NewVirtual creates virtual clock starting at start time.
+func NewVirtual(start time.Time) VirtualClock {
+	return &virtualClock{mu: &sync.Mutex{}, now: start, waiters: make(map[*waiter]struct{})}
+}
+
+// Now returns current clock time.
+func (v *virtualClock) Now() time.Time {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.now
+}
+
+// Set moves clock to a time and fires timers and tickers which are due.
+// Ticks are dropped if nobody receives them, like time.Ticker does.
+func (v *virtualClock) Set(t time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if t.Before(v.now) {
+		return
+	}
+
+	v.now = t
+
+	for w := range v.waiters {
+		if w.at.After(t) {
+			continue
+		}
+
+		select {
+		case w.ch <- w.at:
+		default:
+		}
+
+		if w.period == 0 {
+			delete(v.waiters, w)
+			continue
+		}
+
+		for !w.at.After(t) {
+			w.at = w.at.Add(w.period)
+		}
+	}
+}
+
+// NewTicker creates new ticker with interval of clock time.
+func (v *virtualClock) NewTicker(d time.Duration) Ticker {
+	return virtualTicker{v.add(d, d)}
+}
+
+// NewTimer creates new timer with duration of clock time.
+func (v *virtualClock) NewTimer(d time.Duration) Timer {
+	return virtualTimer{v.add(d, 0)}
+}
+
+// Adds waiter.
+func (v *virtualClock) add(d, period time.Duration) *virtualWaiter {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	w := &waiter{at: v.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	v.waiters[w] = struct{}{}
+
+	return &virtualWaiter{clock: v, waiter: w}
+}
+
+// Removes waiter.
+func (v *virtualClock) remove(w *waiter) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	_, ok := v.waiters[w]
+	delete(v.waiters, w)
+
+	return ok
+}
+
+// Waiter bound to its clock.
+type virtualWaiter struct {
+	clock  *virtualClock
+	waiter *waiter
+}
+
+// Virtual ticker.
+type virtualTicker struct {
+	*virtualWaiter
+}
+
+// C returns channel of ticks.
+func (v virtualTicker) C() <-chan time.Time {
+	return v.waiter.ch
+}
+
+// Stop stops ticker.
+func (v virtualTicker) Stop() {
+	v.clock.remove(v.waiter)
+}
+
+// Virtual timer.
+type virtualTimer struct {
+	*virtualWaiter
+}
+
+// C returns channel of event.
+func (v virtualTimer) C() <-chan time.Time {
+	return v.waiter.ch
+}
+
+// Stop stops timer. It returns false if timer has already fired or been stopped.
+func (v virtualTimer) Stop() bool {
+	return v.clock.remove(v.waiter)
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/imdario/mergo"
 	"gopkg.in/yaml.v3"
+
+	"github.com/marksartdev/trading/internal/decimal"
 )
 
 // Config main config.
@@ -17,17 +19,176 @@ type Config struct {
 	Exchange Exchange `yaml:"exchange"`
 	Broker   Broker   `yaml:"broker"`
 	Client   Client   `yaml:"client"`
+	Backtest Backtest `yaml:"backtest"`
+	Strategy Strategy `yaml:"strategy"`
 }
 
 // Exchange stock exchange service config.
 type Exchange struct {
-	Tickers  []string      `yaml:"tickers"`
+	Tickers      []string              `yaml:"tickers"`
+	Interval     time.Duration         `yaml:"interval"`
+	Intervals    []time.Duration       `yaml:"intervals"`
+	Matching     string                `yaml:"matching"`
+	Session      Session               `yaml:"session"`
+	Breaker      Breaker               `yaml:"breaker"`
+	AdminToken   string                `yaml:"admin_token"`
+	Sources      map[string]TickSource `yaml:"sources"`
+	Replay       Replay                `yaml:"replay"`
+	Depth        Depth                 `yaml:"depth"`
+	Journal      Journal               `yaml:"journal"`
+	ResultBuffer int                   `yaml:"result_buffer"`
+	Fanout       Fanout                `yaml:"fanout"`
+	Liquidity    Liquidity             `yaml:"liquidity"`
+	TickSize     TickSize              `yaml:"tick_size"`
+}
+
+// TickSize price step of tickers. Prices of deals are multiples of the step, fill prices are rounded to it.
+// Default step is overridden by ticker, zero step allows any price.
+type TickSize struct {
+	Size    decimal.Decimal            `yaml:"size"`
+	Tickers map[string]decimal.Decimal `yaml:"tickers"`
+}
+
+// Liquidity liquidity model of exchange. Default model is overridden by ticker.
+type Liquidity struct {
+	TickerLiquidity `yaml:",inline"`
+	Tickers         map[string]TickerLiquidity `yaml:"tickers"`
+}
+
+// TickerLiquidity liquidity of a ticker.
+// Float is initial inventory of exchange, sells are limited by capacity of inventory.
+// Zero capacity limits sells of a tick by its volume.
+// Every tick both sides may take participation share of tick volume, zero participation is unlimited.
+// Fill price moves from tick price by impact of the filled share of tick volume, impact model is linear or sqrt.
+type TickerLiquidity struct {
+	Float         int32   `yaml:"float"`
+	Capacity      int32   `yaml:"capacity"`
+	Participation float64 `yaml:"participation"`
+	Impact        float64 `yaml:"impact"`
+	ImpactModel   string  `yaml:"impact_model"`
+}
+
+// Fanout delivery of streams to subscribers. Default policy is overridden by stream name.
+// Metrics of subscribers which drop messages are logged every report period if it is positive.
+type Fanout struct {
+	Backpressure `yaml:",inline"`
+	Streams      map[string]Backpressure `yaml:"streams"`
+	Report       time.Duration           `yaml:"report"`
+}
+
+// Backpressure policy of slow subscriber: drop_oldest, disconnect or block for timeout.
+type Backpressure struct {
+	Policy  string        `yaml:"policy"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Session trading schedule as offsets from midnight of exchange clock.
+// Deals are accepted from pre-open and filled from open, in continuous mode deals collected before open
+// are uncrossed at a single price at open. From auction they are collected for closing auction,
+// which uncrosses them at a single clearing price at close. Day deals expire at close. Zero close means trading all day.
+type Session struct {
+	PreOpen time.Duration `yaml:"pre_open"`
+	Open    time.Duration `yaml:"open"`
+	Auction time.Duration `yaml:"auction"`
+	Close   time.Duration `yaml:"close"`
+}
+
+// Breaker circuit breaker. It halts ticker for halt period when its price moves more than move percents
+// within window. Zero move disables it.
+type Breaker struct {
+	Move   float64       `yaml:"move"`
+	Window time.Duration `yaml:"window"`
+	Halt   time.Duration `yaml:"halt"`
+}
+
+// Journal durable journal of the book. Journal is enabled by directory.
+type Journal struct {
+	Dir           string `yaml:"dir"`
+	Sync          bool   `yaml:"sync"`
+	SnapshotEvery int64  `yaml:"snapshot_every"`
+}
+
+// Depth market depth streaming config.
+type Depth struct {
+	Levels   int           `yaml:"levels"`
 	Interval time.Duration `yaml:"interval"`
+	Snapshot time.Duration `yaml:"snapshot"`
+}
+
+// Replay historical replay config. Replay is enabled by positive speed.
+type Replay struct {
+	Start time.Time `yaml:"start"`
+	Speed float64   `yaml:"speed"`
+}
+
+// TickSource source of ticks for a ticker.
+// Type is one of csv (default), jsonl or gbm (synthetic geometric Brownian motion).
+type TickSource struct {
+	Type         string        `yaml:"type"`
+	Path         string        `yaml:"path"`
+	Delimiter    string        `yaml:"delimiter"`
+	Header       *bool         `yaml:"header"`
+	TimeColumn   *int          `yaml:"time_column"`
+	PriceColumn  *int          `yaml:"price_column"`
+	VolumeColumn *int          `yaml:"volume_column"`
+	TimeLayout   string        `yaml:"time_layout"`
+	Price        float64       `yaml:"price"`
+	Drift        float64       `yaml:"drift"`
+	Volatility   float64       `yaml:"volatility"`
+	Volume       int32         `yaml:"volume"`
+	Step         time.Duration `yaml:"step"`
+	Seed         int64         `yaml:"seed"`
 }
 
 // Broker broker config.
 type Broker struct {
-	DB DB `yaml:"db"`
+	DB         DB             `yaml:"db"`
+	Statistic  []Subscription `yaml:"statistic"`
+	Reconcile  Reconcile      `yaml:"reconcile"`
+	Reconnect  Reconnect      `yaml:"reconnect"`
+	Risk       Risk           `yaml:"risk"`
+	Fee        float64        `yaml:"fee"`
+	Precision  int            `yaml:"precision"`
+	AdminToken string         `yaml:"admin_token"`
+}
+
+// Risk pre-trade risk limits. Default limits are overridden by client login, zero limit is unlimited.
+// Market buffer is rate by which the last price is raised to value buys which execute at market price.
+type Risk struct {
+	RiskLimits   `yaml:",inline"`
+	MarketBuffer float64               `yaml:"market_buffer"`
+	Clients      map[string]RiskLimits `yaml:"clients"`
+}
+
+// RiskLimits risk limits of a client. Exposure is value of position and open buys of a ticker,
+// it is limited by limit of the ticker or by max exposure.
+type RiskLimits struct {
+	MaxOrderSize int32                      `yaml:"max_order_size"`
+	MaxNotional  decimal.Decimal            `yaml:"max_notional"`
+	MaxExposure  decimal.Decimal            `yaml:"max_exposure"`
+	Exposure     map[string]decimal.Decimal `yaml:"exposure"`
+}
+
+// Reconnect backoff of reconnection to exchange streams.
+// Delay grows by factor from min to max, a random half of it is jittered.
+type Reconnect struct {
+	Min    time.Duration `yaml:"min"`
+	Max    time.Duration `yaml:"max"`
+	Factor float64       `yaml:"factor"`
+}
+
+// Reconcile reconciliation of open deals with exchange. It is enabled by positive interval.
+// Policy is resubmit or cancel, a discrepancy is acted on when it lasts for grace and two runs in a row.
+type Reconcile struct {
+	Interval time.Duration `yaml:"interval"`
+	Grace    time.Duration `yaml:"grace"`
+	Policy   string        `yaml:"policy"`
+}
+
+// Subscription subscription to statistic of a ticker at an interval. Empty ticker means all tickers.
+type Subscription struct {
+	Ticker   string        `yaml:"ticker"`
+	Interval time.Duration `yaml:"interval"`
 }
 
 // Client telegram client config.
@@ -35,6 +196,29 @@ type Client struct {
 	Token string `yaml:"token"`
 }
 
+// Backtest backtesting config.
+type Backtest struct {
+	Start    time.Time       `yaml:"start"`
+	Duration time.Duration   `yaml:"duration"`
+	Interval time.Duration   `yaml:"interval"`
+	Cash     decimal.Decimal `yaml:"cash"`
+	Output   string          `yaml:"output"`
+}
+
+// Strategy automated trading config.
+type Strategy struct {
+	Name      string        `yaml:"name"`
+	Login     string        `yaml:"login"`
+	Tickers   []string      `yaml:"tickers"`
+	Poll      time.Duration `yaml:"poll"`
+	Interval  time.Duration `yaml:"interval"`
+	Amount    int32         `yaml:"amount"`
+	Fast      int           `yaml:"fast"`
+	Slow      int           `yaml:"slow"`
+	Window    int           `yaml:"window"`
+	Threshold float64       `yaml:"threshold"`
+}
+
 // DB Postgres config.
 type DB struct {
 	Host     string `yaml:"host"`
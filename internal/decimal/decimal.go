@@ -0,0 +1,354 @@
+// Package decimal fixed-point decimal numbers for prices and money.
+package decimal
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Places number of fractional digits kept by Decimal.
+const Places = 6
+
+const (
+	scale      = 1000000
+	nanosScale = 1000
+)
+
+// Bounds of decimal, about ±9.2e12 units.
+const (
+	maxUnits  = math.MaxInt64 / scale
+	maxMicros = math.MaxInt64 % scale
+	maxFloat  = float64(math.MaxInt64) / scale
+)
+
+// Decimal fixed-point number with six fractional digits, stored as a number of millionths.
+// Decimals are added, subtracted and compared by usual operators.
+type Decimal int64
+
+// Zero decimal.
+const Zero Decimal = 0
+
+// New returns decimal of integer value.
+func New(n int64) Decimal {
+	return Decimal(n * scale)
+}
+
+// FromFloat returns float rounded to decimal.
+func FromFloat(f float64) Decimal {
+	return Decimal(math.Round(f * scale))
+}
+
+// FromParts returns decimal of whole units and nano units, nanos are rounded to decimal places.
+func FromParts(units int64, nanos int32) Decimal {
+	return New(units) + Decimal(nanos/nanosScale) + roundNanos(nanos%nanosScale)
+}
+
+// Rounds remainder of nanos to a single millionth.
+func roundNanos(rest int32) Decimal {
+	switch {
+	case rest >= nanosScale/2:
+		return 1
+	case rest <= -nanosScale/2:
+		return -1
+	}
+
+	return 0
+}
+
+// Parse parses decimal from its text form. Digits out of decimal places are rounded.
+// Decimals out of about ±9.2e12 are rejected.
+func Parse(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, fmt.Errorf("empty decimal")
+	}
+
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(f) {
+			return Zero, fmt.Errorf("invalid decimal %q", s)
+		}
+
+		if math.Abs(f) >= maxFloat {
+			return Zero, fmt.Errorf("decimal %q is out of range", s)
+		}
+
+		return FromFloat(f), nil
+	}
+
+	digits := s
+	neg := s[0] == '-'
+	if neg || s[0] == '+' {
+		digits = s[1:]
+	}
+
+	whole, frac := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		whole, frac = digits[:i], digits[i+1:]
+	}
+
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return Zero, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return Zero, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	var round bool
+	if len(frac) > Places {
+		round = frac[Places] >= '5'
+		frac = frac[:Places]
+	}
+
+	frac += strings.Repeat("0", Places-len(frac))
+
+	micros, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	if round {
+		micros++
+	}
+
+	if units > maxUnits || (units == maxUnits && micros > maxMicros) {
+		return Zero, fmt.Errorf("decimal %q is out of range", s)
+	}
+
+	d := New(units) + Decimal(micros)
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// MustParse parses decimal and panics on error. It is meant for constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// Parts returns whole units and nano units of decimal.
+func (d Decimal) Parts() (int64, int32) {
+	return int64(d / scale), int32(d%scale) * nanosScale
+}
+
+// Float64 returns decimal as float.
+func (d Decimal) Float64() float64 {
+	return float64(d) / scale
+}
+
+// Mul returns decimal multiplied by an integer.
+func (d Decimal) Mul(n int32) Decimal {
+	return d * Decimal(n)
+}
+
+// MulFloat returns decimal multiplied by a rate and rounded.
+func (d Decimal) MulFloat(f float64) Decimal {
+	return Decimal(math.Round(float64(d) * f))
+}
+
+// Div returns decimal divided by an integer and rounded half away from zero.
+func (d Decimal) Div(n int64) Decimal {
+	if n == 0 {
+		return Zero
+	}
+
+	q, r := d/Decimal(n), d%Decimal(n)
+	if 2*abs(int64(r)) >= abs(n) {
+		if (d < 0) != (n < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+
+	return q
+}
+
+// Round returns decimal rounded half away from zero to places.
+func (d Decimal) Round(places int) Decimal {
+	if places >= Places || places < 0 {
+		return d
+	}
+
+	return d.RoundTo(Decimal(math.Pow10(Places - places)))
+}
+
+// RoundTo returns decimal rounded half away from zero to a multiple of step. Non-positive step keeps decimal.
+func (d Decimal) RoundTo(step Decimal) Decimal {
+	if step <= 0 {
+		return d
+	}
+
+	return d.Div(int64(step)) * step
+}
+
+// Multiple reports whether decimal is a multiple of step. Any decimal is a multiple of non-positive step.
+func (d Decimal) Multiple(step Decimal) bool {
+	return step <= 0 || d%step == 0
+}
+
+// Abs returns absolute value of decimal.
+func (d Decimal) Abs() Decimal {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+// String returns decimal without trailing zeros.
+func (d Decimal) String() string {
+	s := d.StringFixed(Places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	return s
+}
+
+// StringFixed returns decimal rounded to places with exactly that number of fractional digits.
+func (d Decimal) StringFixed(places int) string {
+	if places > Places {
+		places = Places
+	}
+
+	if places < 0 {
+		places = 0
+	}
+
+	r := d.Round(places)
+
+	sign := ""
+	if r < 0 {
+		sign = "-"
+		r = -r
+	}
+
+	units, micros := int64(r/scale), int64(r%scale)
+	if places == 0 {
+		return fmt.Sprintf("%s%d", sign, units)
+	}
+
+	frac := fmt.Sprintf("%06d", micros)[:places]
+
+	return fmt.Sprintf("%s%d.%s", sign, units, frac)
+}
+
+// MarshalJSON writes decimal as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads decimal from a JSON number or string.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
+
+// UnmarshalYAML reads decimal from a YAML scalar.
+func (d *Decimal) UnmarshalYAML(value *yaml.Node) error {
+	v, err := Parse(value.Value)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
+
+// MarshalYAML writes decimal as a YAML scalar.
+func (d Decimal) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// GormDataType returns type of database column.
+func (Decimal) GormDataType() string {
+	return "numeric(24,6)"
+}
+
+// Value returns decimal as a database value.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan reads decimal from a database value.
+func (d *Decimal) Scan(src interface{}) error {
+	var (
+		v   Decimal
+		err error
+	)
+
+	switch s := src.(type) {
+	case nil:
+		v = Zero
+	case []byte:
+		v, err = Parse(string(s))
+	case string:
+		v, err = Parse(s)
+	case float64:
+		v = FromFloat(s)
+	case int64:
+		v = New(s)
+	default:
+		err = fmt.Errorf("unsupported decimal source %T", src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
+
+// Checks that string has only decimal digits.
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns absolute value of integer.
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
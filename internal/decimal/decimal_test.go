@@ -0,0 +1,200 @@
+package decimal_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want decimal.Decimal
+	}{
+		{"0", 0},
+		{"1", 1000000},
+		{"+1", 1000000},
+		{"-1", -1000000},
+		{" 12.5 ", 12500000},
+		{".5", 500000},
+		{"-.5", -500000},
+		{"5.", 5000000},
+		{"0.000001", 1},
+		{"-0.000001", -1},
+		{"1.0000004", 1000000},
+		{"1.0000005", 1000001},
+		{"-1.0000005", -1000001},
+		{"0.9999995", 1000000},
+		{"1e3", 1000000000},
+		{"1.5E-3", 1500},
+		{"9223372036854.775807", math.MaxInt64},
+		{"-9223372036854.775807", -math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		got, err := decimal.Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %d, want %d", tt.in, int64(got), int64(tt.want))
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []string{
+		"",
+		" ",
+		"-",
+		"+",
+		".",
+		"--5",
+		"+-5",
+		"-+5",
+		"++5",
+		"5-",
+		"1.-5",
+		"1.+5",
+		"1..5",
+		"1,5",
+		"abc",
+		"1e",
+		"9223372036854.775808",
+		"-9223372036854.775808",
+		"9223372036855",
+		"99999999999999999999",
+		"9223372036854.7758075",
+		"1e13",
+		"-1e13",
+	}
+
+	for _, in := range tests {
+		if got, err := decimal.Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %s, want error", in, got)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   decimal.Decimal
+		want string
+	}{
+		{0, "0"},
+		{1, "0.000001"},
+		{-1, "-0.000001"},
+		{1000000, "1"},
+		{-1500000, "-1.5"},
+		{123456789, "123.456789"},
+		{math.MaxInt64, "9223372036854.775807"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+
+		if back := decimal.MustParse(tt.want); back != tt.in {
+			t.Errorf("Parse(%q) = %d, want %d", tt.want, int64(back), int64(tt.in))
+		}
+	}
+}
+
+func TestStringFixed(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int
+		want   string
+	}{
+		{"1.005", 2, "1.01"},
+		{"-1.005", 2, "-1.01"},
+		{"1.004", 2, "1.00"},
+		{"2.5", 0, "3"},
+		{"-2.5", 0, "-3"},
+		{"1", 3, "1.000"},
+		{"1.123456", 9, "1.123456"},
+	}
+
+	for _, tt := range tests {
+		if got := decimal.MustParse(tt.in).StringFixed(tt.places); got != tt.want {
+			t.Errorf("%s.StringFixed(%d) = %q, want %q", tt.in, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		got  decimal.Decimal
+		want string
+	}{
+		{"round half up", decimal.MustParse("1.25").Round(1), "1.3"},
+		{"round half away from zero", decimal.MustParse("-1.25").Round(1), "-1.3"},
+		{"round down", decimal.MustParse("1.24").Round(1), "1.2"},
+		{"round to step", decimal.MustParse("100.12").RoundTo(decimal.MustParse("0.25")), "100"},
+		{"round to step up", decimal.MustParse("100.13").RoundTo(decimal.MustParse("0.25")), "100.25"},
+		{"div half away from zero", decimal.MustParse("1").Div(3), "0.333333"},
+		{"div negative", decimal.MustParse("-0.000005").Div(2), "-0.000003"},
+		{"div by zero", decimal.MustParse("1").Div(0), "0"},
+		{"mul float", decimal.MustParse("100").MulFloat(1.0005), "100.05"},
+		{"from float", decimal.FromFloat(0.1 + 0.2), "0.3"},
+	}
+
+	for _, tt := range tests {
+		if want := decimal.MustParse(tt.want); tt.got != want {
+			t.Errorf("%s: got %s, want %s", tt.name, tt.got, want)
+		}
+	}
+}
+
+func TestPartsRoundTrip(t *testing.T) {
+	tests := []struct {
+		in    string
+		units int64
+		nanos int32
+	}{
+		{"0", 0, 0},
+		{"1.5", 1, 500000000},
+		{"-1.5", -1, -500000000},
+		{"-0.000001", 0, -1000},
+		{"123.456789", 123, 456789000},
+		{"9223372036854.775807", 9223372036854, 775807000},
+	}
+
+	for _, tt := range tests {
+		d := decimal.MustParse(tt.in)
+
+		units, nanos := d.Parts()
+		if units != tt.units || nanos != tt.nanos {
+			t.Errorf("%s.Parts() = %d, %d, want %d, %d", tt.in, units, nanos, tt.units, tt.nanos)
+		}
+
+		if back := decimal.FromParts(units, nanos); back != d {
+			t.Errorf("FromParts(%d, %d) = %s, want %s", units, nanos, back, d)
+		}
+	}
+}
+
+func TestFromPartsRoundsNanos(t *testing.T) {
+	tests := []struct {
+		units int64
+		nanos int32
+		want  string
+	}{
+		{1, 499, "1"},
+		{1, 500, "1.000001"},
+		{-1, -500, "-1.000001"},
+		{0, 999999499, "0.999999"},
+		{0, 999999500, "1"},
+	}
+
+	for _, tt := range tests {
+		if got := decimal.FromParts(tt.units, tt.nanos); got != decimal.MustParse(tt.want) {
+			t.Errorf("FromParts(%d, %d) = %s, want %s", tt.units, tt.nanos, got, tt.want)
+		}
+	}
+}
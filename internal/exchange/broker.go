@@ -1,7 +1,19 @@
 package exchange
 
+import "time"
+
 // Broker broker identifier.
 type Broker struct {
 	ID         int64
 	InstanceID int64
 }
+
+// NoReplay sequence of results which asks for no replay. Zero sequence replays all buffered results.
+const NoReplay int64 = -1
+
+// Subscription subscription of a broker to statistic.
+// Empty ticker means all tickers, zero interval means base interval of exchange.
+type Subscription struct {
+	Ticker   string
+	Interval time.Duration
+}
@@ -0,0 +1,14 @@
+package rpc
+
+import "github.com/marksartdev/trading/internal/decimal"
+
+// NewDecimal converts decimal to message.
+func NewDecimal(d decimal.Decimal) *Decimal {
+	units, nanos := d.Parts()
+	return &Decimal{Units: units, Nanos: nanos}
+}
+
+// Decimal converts message to decimal. Missing message is zero.
+func (x *Decimal) Decimal() decimal.Decimal {
+	return decimal.FromParts(x.GetUnits(), x.GetNanos())
+}
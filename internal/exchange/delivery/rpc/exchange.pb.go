@@ -20,26 +20,82 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Decimal fixed-point number as whole units and nano units of the same sign.
+type Decimal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Units int64 `protobuf:"varint,1,opt,name=Units,proto3" json:"Units,omitempty"`
+	Nanos int32 `protobuf:"varint,2,opt,name=Nanos,proto3" json:"Nanos,omitempty"`
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Decimal) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Decimal) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
 type OHLCV struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID       int64   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Time     int64   `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
-	Interval int32   `protobuf:"varint,3,opt,name=Interval,proto3" json:"Interval,omitempty"`
-	Open     float64 `protobuf:"fixed64,4,opt,name=Open,proto3" json:"Open,omitempty"`
-	High     float64 `protobuf:"fixed64,5,opt,name=High,proto3" json:"High,omitempty"`
-	Low      float64 `protobuf:"fixed64,6,opt,name=Low,proto3" json:"Low,omitempty"`
-	Close    float64 `protobuf:"fixed64,7,opt,name=Close,proto3" json:"Close,omitempty"`
-	Volume   int32   `protobuf:"varint,8,opt,name=Volume,proto3" json:"Volume,omitempty"`
-	Ticker   string  `protobuf:"bytes,9,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	ID       int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Time     int64    `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	Interval int64    `protobuf:"varint,3,opt,name=Interval,proto3" json:"Interval,omitempty"`
+	Open     *Decimal `protobuf:"bytes,10,opt,name=Open,proto3" json:"Open,omitempty"`
+	High     *Decimal `protobuf:"bytes,11,opt,name=High,proto3" json:"High,omitempty"`
+	Low      *Decimal `protobuf:"bytes,12,opt,name=Low,proto3" json:"Low,omitempty"`
+	Close    *Decimal `protobuf:"bytes,13,opt,name=Close,proto3" json:"Close,omitempty"`
+	Volume   int32    `protobuf:"varint,8,opt,name=Volume,proto3" json:"Volume,omitempty"`
+	Ticker   string   `protobuf:"bytes,9,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
 }
 
 func (x *OHLCV) Reset() {
 	*x = OHLCV{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_exchange_proto_msgTypes[0]
+		mi := &file_api_exchange_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -52,7 +108,7 @@ func (x *OHLCV) String() string {
 func (*OHLCV) ProtoMessage() {}
 
 func (x *OHLCV) ProtoReflect() protoreflect.Message {
-	mi := &file_api_exchange_proto_msgTypes[0]
+	mi := &file_api_exchange_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -65,7 +121,7 @@ func (x *OHLCV) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OHLCV.ProtoReflect.Descriptor instead.
 func (*OHLCV) Descriptor() ([]byte, []int) {
-	return file_api_exchange_proto_rawDescGZIP(), []int{0}
+	return file_api_exchange_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *OHLCV) GetID() int64 {
@@ -82,39 +138,39 @@ func (x *OHLCV) GetTime() int64 {
 	return 0
 }
 
-func (x *OHLCV) GetInterval() int32 {
+func (x *OHLCV) GetInterval() int64 {
 	if x != nil {
 		return x.Interval
 	}
 	return 0
 }
 
-func (x *OHLCV) GetOpen() float64 {
+func (x *OHLCV) GetOpen() *Decimal {
 	if x != nil {
 		return x.Open
 	}
-	return 0
+	return nil
 }
 
-func (x *OHLCV) GetHigh() float64 {
+func (x *OHLCV) GetHigh() *Decimal {
 	if x != nil {
 		return x.High
 	}
-	return 0
+	return nil
 }
 
-func (x *OHLCV) GetLow() float64 {
+func (x *OHLCV) GetLow() *Decimal {
 	if x != nil {
 		return x.Low
 	}
-	return 0
+	return nil
 }
 
-func (x *OHLCV) GetClose() float64 {
+func (x *OHLCV) GetClose() *Decimal {
 	if x != nil {
 		return x.Close
 	}
-	return 0
+	return nil
 }
 
 func (x *OHLCV) GetVolume() int32 {
@@ -136,20 +192,27 @@ type Deal struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID       int64   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	BrokerID int64   `protobuf:"varint,2,opt,name=BrokerID,proto3" json:"BrokerID,omitempty"`
-	ClientID int64   `protobuf:"varint,3,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
-	Ticker   string  `protobuf:"bytes,4,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
-	Amount   int32   `protobuf:"varint,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
-	Partial  bool    `protobuf:"varint,6,opt,name=Partial,proto3" json:"Partial,omitempty"`
-	Time     int64   `protobuf:"varint,7,opt,name=Time,proto3" json:"Time,omitempty"`
-	Price    float64 `protobuf:"fixed64,8,opt,name=Price,proto3" json:"Price,omitempty"`
+	ID          int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	BrokerID    int64    `protobuf:"varint,2,opt,name=BrokerID,proto3" json:"BrokerID,omitempty"`
+	ClientID    int64    `protobuf:"varint,3,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	Ticker      string   `protobuf:"bytes,4,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Amount      int32    `protobuf:"varint,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Partial     bool     `protobuf:"varint,6,opt,name=Partial,proto3" json:"Partial,omitempty"`
+	Time        int64    `protobuf:"varint,7,opt,name=Time,proto3" json:"Time,omitempty"`
+	Price       *Decimal `protobuf:"bytes,16,opt,name=Price,proto3" json:"Price,omitempty"`
+	Side        string   `protobuf:"bytes,9,opt,name=Side,proto3" json:"Side,omitempty"`
+	Type        string   `protobuf:"bytes,10,opt,name=Type,proto3" json:"Type,omitempty"`
+	StopPrice   *Decimal `protobuf:"bytes,17,opt,name=StopPrice,proto3" json:"StopPrice,omitempty"`
+	TimeInForce string   `protobuf:"bytes,12,opt,name=TimeInForce,proto3" json:"TimeInForce,omitempty"`
+	Status      string   `protobuf:"bytes,13,opt,name=Status,proto3" json:"Status,omitempty"`
+	Seq         int64    `protobuf:"varint,14,opt,name=Seq,proto3" json:"Seq,omitempty"`
+	FillID      int64    `protobuf:"varint,15,opt,name=FillID,proto3" json:"FillID,omitempty"`
 }
 
 func (x *Deal) Reset() {
 	*x = Deal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_exchange_proto_msgTypes[1]
+		mi := &file_api_exchange_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -162,7 +225,7 @@ func (x *Deal) String() string {
 func (*Deal) ProtoMessage() {}
 
 func (x *Deal) ProtoReflect() protoreflect.Message {
-	mi := &file_api_exchange_proto_msgTypes[1]
+	mi := &file_api_exchange_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,7 +238,7 @@ func (x *Deal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Deal.ProtoReflect.Descriptor instead.
 func (*Deal) Descriptor() ([]byte, []int) {
-	return file_api_exchange_proto_rawDescGZIP(), []int{1}
+	return file_api_exchange_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Deal) GetID() int64 {
@@ -227,10 +290,59 @@ func (x *Deal) GetTime() int64 {
 	return 0
 }
 
-func (x *Deal) GetPrice() float64 {
+func (x *Deal) GetPrice() *Decimal {
 	if x != nil {
 		return x.Price
 	}
+	return nil
+}
+
+func (x *Deal) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *Deal) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Deal) GetStopPrice() *Decimal {
+	if x != nil {
+		return x.StopPrice
+	}
+	return nil
+}
+
+func (x *Deal) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *Deal) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Deal) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Deal) GetFillID() int64 {
+	if x != nil {
+		return x.FillID
+	}
 	return 0
 }
 
@@ -246,7 +358,7 @@ type DealID struct {
 func (x *DealID) Reset() {
 	*x = DealID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_exchange_proto_msgTypes[2]
+		mi := &file_api_exchange_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -259,7 +371,7 @@ func (x *DealID) String() string {
 func (*DealID) ProtoMessage() {}
 
 func (x *DealID) ProtoReflect() protoreflect.Message {
-	mi := &file_api_exchange_proto_msgTypes[2]
+	mi := &file_api_exchange_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -272,7 +384,7 @@ func (x *DealID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DealID.ProtoReflect.Descriptor instead.
 func (*DealID) Descriptor() ([]byte, []int) {
-	return file_api_exchange_proto_rawDescGZIP(), []int{2}
+	return file_api_exchange_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DealID) GetID() int64 {
@@ -300,7 +412,7 @@ type BrokerID struct {
 func (x *BrokerID) Reset() {
 	*x = BrokerID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_exchange_proto_msgTypes[3]
+		mi := &file_api_exchange_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -313,7 +425,7 @@ func (x *BrokerID) String() string {
 func (*BrokerID) ProtoMessage() {}
 
 func (x *BrokerID) ProtoReflect() protoreflect.Message {
-	mi := &file_api_exchange_proto_msgTypes[3]
+	mi := &file_api_exchange_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,7 +438,7 @@ func (x *BrokerID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BrokerID.ProtoReflect.Descriptor instead.
 func (*BrokerID) Descriptor() ([]byte, []int) {
-	return file_api_exchange_proto_rawDescGZIP(), []int{3}
+	return file_api_exchange_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *BrokerID) GetID() int64 {
@@ -336,6 +448,171 @@ func (x *BrokerID) GetID() int64 {
 	return 0
 }
 
+type ResultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BrokerID int64 `protobuf:"varint,1,opt,name=BrokerID,proto3" json:"BrokerID,omitempty"`
+	Seq      int64 `protobuf:"varint,2,opt,name=Seq,proto3" json:"Seq,omitempty"`
+}
+
+func (x *ResultsRequest) Reset() {
+	*x = ResultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultsRequest) ProtoMessage() {}
+
+func (x *ResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultsRequest.ProtoReflect.Descriptor instead.
+func (*ResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResultsRequest) GetBrokerID() int64 {
+	if x != nil {
+		return x.BrokerID
+	}
+	return 0
+}
+
+func (x *ResultsRequest) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type Subscription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticker   string `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Interval int64  `protobuf:"varint,2,opt,name=Interval,proto3" json:"Interval,omitempty"`
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Subscription) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Subscription) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+type StatisticRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BrokerID      int64           `protobuf:"varint,1,opt,name=BrokerID,proto3" json:"BrokerID,omitempty"`
+	Subscriptions []*Subscription `protobuf:"bytes,2,rep,name=Subscriptions,proto3" json:"Subscriptions,omitempty"`
+}
+
+func (x *StatisticRequest) Reset() {
+	*x = StatisticRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatisticRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatisticRequest) ProtoMessage() {}
+
+func (x *StatisticRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatisticRequest.ProtoReflect.Descriptor instead.
+func (*StatisticRequest) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StatisticRequest) GetBrokerID() int64 {
+	if x != nil {
+		return x.BrokerID
+	}
+	return 0
+}
+
+func (x *StatisticRequest) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
 type CancelResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -347,7 +624,7 @@ type CancelResult struct {
 func (x *CancelResult) Reset() {
 	*x = CancelResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_exchange_proto_msgTypes[4]
+		mi := &file_api_exchange_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +637,7 @@ func (x *CancelResult) String() string {
 func (*CancelResult) ProtoMessage() {}
 
 func (x *CancelResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_exchange_proto_msgTypes[4]
+	mi := &file_api_exchange_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +650,7 @@ func (x *CancelResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelResult.ProtoReflect.Descriptor instead.
 func (*CancelResult) Descriptor() ([]byte, []int) {
-	return file_api_exchange_proto_rawDescGZIP(), []int{4}
+	return file_api_exchange_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CancelResult) GetSuccess() bool {
@@ -383,98 +660,1134 @@ func (x *CancelResult) GetSuccess() bool {
 	return false
 }
 
-var File_api_exchange_proto protoreflect.FileDescriptor
+type ModifyDeal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_api_exchange_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0xc7,
-	0x01, 0x0a, 0x05, 0x4f, 0x48, 0x4c, 0x43, 0x56, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x4f, 0x70, 0x65, 0x6e,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04,
-	0x48, 0x69, 0x67, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x48, 0x69, 0x67, 0x68,
-	0x12, 0x10, 0x0a, 0x03, 0x4c, 0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4c,
-	0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x6f, 0x6c, 0x75,
-	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x22, 0xc2, 0x01, 0x0a, 0x04, 0x44, 0x65, 0x61,
-	0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49,
-	0x44, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a,
-	0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63,
-	0x6b, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65,
-	0x72, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x72,
-	0x74, 0x69, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x50, 0x61, 0x72, 0x74,
-	0x69, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0x34, 0x0a,
-	0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65,
-	0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65,
-	0x72, 0x49, 0x44, 0x22, 0x1a, 0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12,
-	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x22,
-	0x28, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0xd7, 0x01, 0x0a, 0x08, 0x45, 0x78,
-	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73,
-	0x74, 0x69, 0x63, 0x12, 0x12, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x42,
-	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x2e, 0x4f, 0x48, 0x4c, 0x43, 0x56, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x06,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x06, 0x43, 0x61,
-	0x6e, 0x63, 0x65, 0x6c, 0x12, 0x10, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
-	0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00,
-	0x12, 0x31, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x12, 0x2e, 0x65, 0x78,
-	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x1a,
-	0x0e, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x22,
-	0x00, 0x30, 0x01, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x61, 0x72, 0x74, 0x64, 0x65, 0x76, 0x2f, 0x74, 0x72,
-	0x61, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65,
-	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
-	0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	ID       int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	BrokerID int64    `protobuf:"varint,2,opt,name=BrokerID,proto3" json:"BrokerID,omitempty"`
+	Price    *Decimal `protobuf:"bytes,5,opt,name=Price,proto3" json:"Price,omitempty"`
+	Amount   int32    `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
 }
 
-var (
-	file_api_exchange_proto_rawDescOnce sync.Once
-	file_api_exchange_proto_rawDescData = file_api_exchange_proto_rawDesc
-)
+func (x *ModifyDeal) Reset() {
+	*x = ModifyDeal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_api_exchange_proto_rawDescGZIP() []byte {
-	file_api_exchange_proto_rawDescOnce.Do(func() {
-		file_api_exchange_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_exchange_proto_rawDescData)
-	})
-	return file_api_exchange_proto_rawDescData
+func (x *ModifyDeal) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_api_exchange_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_api_exchange_proto_goTypes = []interface{}{
-	(*OHLCV)(nil),        // 0: exchange.OHLCV
-	(*Deal)(nil),         // 1: exchange.Deal
-	(*DealID)(nil),       // 2: exchange.DealID
-	(*BrokerID)(nil),     // 3: exchange.BrokerID
-	(*CancelResult)(nil), // 4: exchange.CancelResult
+func (*ModifyDeal) ProtoMessage() {}
+
+func (x *ModifyDeal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_api_exchange_proto_depIdxs = []int32{
-	3, // 0: exchange.Exchange.Statistic:input_type -> exchange.BrokerID
-	1, // 1: exchange.Exchange.Create:input_type -> exchange.Deal
-	2, // 2: exchange.Exchange.Cancel:input_type -> exchange.DealID
-	3, // 3: exchange.Exchange.Results:input_type -> exchange.BrokerID
-	0, // 4: exchange.Exchange.Statistic:output_type -> exchange.OHLCV
-	2, // 5: exchange.Exchange.Create:output_type -> exchange.DealID
-	4, // 6: exchange.Exchange.Cancel:output_type -> exchange.CancelResult
-	1, // 7: exchange.Exchange.Results:output_type -> exchange.Deal
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use ModifyDeal.ProtoReflect.Descriptor instead.
+func (*ModifyDeal) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ModifyDeal) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+func (x *ModifyDeal) GetBrokerID() int64 {
+	if x != nil {
+		return x.BrokerID
+	}
+	return 0
+}
+
+func (x *ModifyDeal) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ModifyDeal) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type ModifyResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *ModifyResult) Reset() {
+	*x = ModifyResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyResult) ProtoMessage() {}
+
+func (x *ModifyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyResult.ProtoReflect.Descriptor instead.
+func (*ModifyResult) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ModifyResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type DepthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BrokerID int64  `protobuf:"varint,1,opt,name=BrokerID,proto3" json:"BrokerID,omitempty"`
+	Ticker   string `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Levels   int32  `protobuf:"varint,3,opt,name=Levels,proto3" json:"Levels,omitempty"`
+}
+
+func (x *DepthRequest) Reset() {
+	*x = DepthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthRequest) ProtoMessage() {}
+
+func (x *DepthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthRequest.ProtoReflect.Descriptor instead.
+func (*DepthRequest) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DepthRequest) GetBrokerID() int64 {
+	if x != nil {
+		return x.BrokerID
+	}
+	return 0
+}
+
+func (x *DepthRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *DepthRequest) GetLevels() int32 {
+	if x != nil {
+		return x.Levels
+	}
+	return 0
+}
+
+type Level struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price  *Decimal `protobuf:"bytes,4,opt,name=Price,proto3" json:"Price,omitempty"`
+	Amount int32    `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Orders int32    `protobuf:"varint,3,opt,name=Orders,proto3" json:"Orders,omitempty"`
+}
+
+func (x *Level) Reset() {
+	*x = Level{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Level) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Level) ProtoMessage() {}
+
+func (x *Level) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Level.ProtoReflect.Descriptor instead.
+func (*Level) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Level) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Level) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Level) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type MarketDepth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticker   string   `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Time     int64    `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	Snapshot bool     `protobuf:"varint,3,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`
+	Bids     []*Level `protobuf:"bytes,4,rep,name=Bids,proto3" json:"Bids,omitempty"`
+	Asks     []*Level `protobuf:"bytes,5,rep,name=Asks,proto3" json:"Asks,omitempty"`
+}
+
+func (x *MarketDepth) Reset() {
+	*x = MarketDepth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarketDepth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketDepth) ProtoMessage() {}
+
+func (x *MarketDepth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketDepth.ProtoReflect.Descriptor instead.
+func (*MarketDepth) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MarketDepth) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *MarketDepth) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MarketDepth) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *MarketDepth) GetBids() []*Level {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *MarketDepth) GetAsks() []*Level {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+type Trade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID     int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Ticker string   `protobuf:"bytes,2,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Price  *Decimal `protobuf:"bytes,7,opt,name=Price,proto3" json:"Price,omitempty"`
+	Amount int32    `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Side   string   `protobuf:"bytes,5,opt,name=Side,proto3" json:"Side,omitempty"`
+	Time   int64    `protobuf:"varint,6,opt,name=Time,proto3" json:"Time,omitempty"`
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Trade) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+func (x *Trade) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Trade) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Trade) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Trade) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *Trade) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type OpenDeals struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deals []*Deal `protobuf:"bytes,1,rep,name=Deals,proto3" json:"Deals,omitempty"`
+}
+
+func (x *OpenDeals) Reset() {
+	*x = OpenDeals{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenDeals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenDeals) ProtoMessage() {}
+
+func (x *OpenDeals) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenDeals.ProtoReflect.Descriptor instead.
+func (*OpenDeals) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OpenDeals) GetDeals() []*Deal {
+	if x != nil {
+		return x.Deals
+	}
+	return nil
+}
+
+type Subscriber struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BrokerID     int64  `protobuf:"varint,1,opt,name=BrokerID,proto3" json:"BrokerID,omitempty"`
+	Stream       string `protobuf:"bytes,2,opt,name=Stream,proto3" json:"Stream,omitempty"`
+	Policy       string `protobuf:"bytes,3,opt,name=Policy,proto3" json:"Policy,omitempty"`
+	Depth        int32  `protobuf:"varint,4,opt,name=Depth,proto3" json:"Depth,omitempty"`
+	Capacity     int32  `protobuf:"varint,5,opt,name=Capacity,proto3" json:"Capacity,omitempty"`
+	Sent         int64  `protobuf:"varint,6,opt,name=Sent,proto3" json:"Sent,omitempty"`
+	Dropped      int64  `protobuf:"varint,7,opt,name=Dropped,proto3" json:"Dropped,omitempty"`
+	Disconnected bool   `protobuf:"varint,8,opt,name=Disconnected,proto3" json:"Disconnected,omitempty"`
+}
+
+func (x *Subscriber) Reset() {
+	*x = Subscriber{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subscriber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscriber) ProtoMessage() {}
+
+func (x *Subscriber) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscriber.ProtoReflect.Descriptor instead.
+func (*Subscriber) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Subscriber) GetBrokerID() int64 {
+	if x != nil {
+		return x.BrokerID
+	}
+	return 0
+}
+
+func (x *Subscriber) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *Subscriber) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *Subscriber) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Subscriber) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Subscriber) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *Subscriber) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *Subscriber) GetDisconnected() bool {
+	if x != nil {
+		return x.Disconnected
+	}
+	return false
+}
+
+type Subscribers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscribers []*Subscriber `protobuf:"bytes,1,rep,name=Subscribers,proto3" json:"Subscribers,omitempty"`
+}
+
+func (x *Subscribers) Reset() {
+	*x = Subscribers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subscribers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscribers) ProtoMessage() {}
+
+func (x *Subscribers) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscribers.ProtoReflect.Descriptor instead.
+func (*Subscribers) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Subscribers) GetSubscribers() []*Subscriber {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+type HaltRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticker string `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (x *HaltRequest) Reset() {
+	*x = HaltRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HaltRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaltRequest) ProtoMessage() {}
+
+func (x *HaltRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaltRequest.ProtoReflect.Descriptor instead.
+func (*HaltRequest) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *HaltRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *HaltRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TickerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticker string `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+}
+
+func (x *TickerRequest) Reset() {
+	*x = TickerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickerRequest) ProtoMessage() {}
+
+func (x *TickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickerRequest.ProtoReflect.Descriptor instead.
+func (*TickerRequest) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TickerRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+type TradingStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticker string `protobuf:"bytes,1,opt,name=Ticker,proto3" json:"Ticker,omitempty"`
+	Phase  string `protobuf:"bytes,2,opt,name=Phase,proto3" json:"Phase,omitempty"`
+	Halted bool   `protobuf:"varint,3,opt,name=Halted,proto3" json:"Halted,omitempty"`
+	Reason string `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	Until  int64  `protobuf:"varint,5,opt,name=Until,proto3" json:"Until,omitempty"`
+}
+
+func (x *TradingStatus) Reset() {
+	*x = TradingStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradingStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradingStatus) ProtoMessage() {}
+
+func (x *TradingStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradingStatus.ProtoReflect.Descriptor instead.
+func (*TradingStatus) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TradingStatus) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *TradingStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *TradingStatus) GetHalted() bool {
+	if x != nil {
+		return x.Halted
+	}
+	return false
+}
+
+func (x *TradingStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TradingStatus) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+type TradingStatuses struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Statuses []*TradingStatus `protobuf:"bytes,1,rep,name=Statuses,proto3" json:"Statuses,omitempty"`
+}
+
+func (x *TradingStatuses) Reset() {
+	*x = TradingStatuses{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_exchange_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradingStatuses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradingStatuses) ProtoMessage() {}
+
+func (x *TradingStatuses) ProtoReflect() protoreflect.Message {
+	mi := &file_api_exchange_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradingStatuses.ProtoReflect.Descriptor instead.
+func (*TradingStatuses) Descriptor() ([]byte, []int) {
+	return file_api_exchange_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TradingStatuses) GetStatuses() []*TradingStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+var File_api_exchange_proto protoreflect.FileDescriptor
+
+var file_api_exchange_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x35,
+	0x0a, 0x07, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x22, 0xab, 0x02, 0x0a, 0x05, 0x4f, 0x48, 0x4c, 0x43, 0x56, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12,
+	0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x25, 0x0a, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c,
+	0x52, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x48, 0x69, 0x67, 0x68, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
+	0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x04, 0x48, 0x69, 0x67, 0x68, 0x12, 0x23, 0x0a,
+	0x03, 0x4c, 0x6f, 0x77, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x03, 0x4c,
+	0x6f, 0x77, 0x12, 0x27, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x63,
+	0x69, 0x6d, 0x61, 0x6c, 0x52, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x4a, 0x04, 0x08, 0x04, 0x10,
+	0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x4a, 0x04, 0x08,
+	0x07, 0x10, 0x08, 0x22, 0x9e, 0x03, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08,
+	0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x63,
+	0x69, 0x6d, 0x61, 0x6c, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53,
+	0x69, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x69, 0x64, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x09, 0x53, 0x74, 0x6f, 0x70, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f,
+	0x72, 0x63, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x49,
+	0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x53, 0x65, 0x71, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x53, 0x65, 0x71,
+	0x12, 0x16, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x6c, 0x49, 0x44, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x46, 0x69, 0x6c, 0x6c, 0x49, 0x44, 0x4a, 0x04, 0x08, 0x08, 0x10, 0x09, 0x4a, 0x04,
+	0x08, 0x0b, 0x10, 0x0c, 0x22, 0x34, 0x0a, 0x06, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x12, 0x0e,
+	0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x1a,
+	0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x22, 0x1a, 0x0a, 0x08, 0x42, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x22, 0x3e, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x42, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x53, 0x65, 0x71, 0x22, 0x42, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1a,
+	0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x6c, 0x0a, 0x10, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12, 0x3c, 0x0a, 0x0d, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x28, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x22, 0x7f, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x65, 0x61, 0x6c,
+	0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44,
+	0x12, 0x1a, 0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12, 0x27, 0x0a, 0x05,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x05,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4a, 0x04, 0x08,
+	0x03, 0x10, 0x04, 0x22, 0x28, 0x0a, 0x0c, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x5a, 0x0a,
+	0x0c, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x22, 0x66, 0x0a, 0x05, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x27, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x63,
+	0x69, 0x6d, 0x61, 0x6c, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10,
+	0x02, 0x22, 0x9f, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x70, 0x74,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x42, 0x69, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x42, 0x69, 0x64, 0x73, 0x12, 0x23,
+	0x0a, 0x04, 0x41, 0x73, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x41,
+	0x73, 0x6b, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x64, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16, 0x0a,
+	0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
+	0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x69, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x4a, 0x04,
+	0x08, 0x03, 0x10, 0x04, 0x22, 0x31, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x6e, 0x44, 0x65, 0x61, 0x6c,
+	0x73, 0x12, 0x24, 0x0a, 0x05, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x61, 0x6c,
+	0x52, 0x05, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x22, 0xdc, 0x01, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x43, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x72, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x44, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72,
+	0x52, 0x0b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x22, 0x3d, 0x0a,
+	0x0b, 0x48, 0x61, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x27, 0x0a, 0x0d,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x72, 0x22, 0x83, 0x01, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x48, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x46, 0x0a, 0x0f, 0x54,
+	0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x33,
+	0x0a, 0x08, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x32, 0xb5, 0x05, 0x0a, 0x08, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x3c, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x1a, 0x2e,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x2e, 0x4f, 0x48, 0x4c, 0x43, 0x56, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2c,
+	0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x06,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x10, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x12, 0x14, 0x2e, 0x65,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x44, 0x65,
+	0x61, 0x6c, 0x1a, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x4d, 0x6f,
+	0x64, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x07,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0e, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x61,
+	0x6c, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3a, 0x0a, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x16,
+	0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x70, 0x74, 0x68, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x31, 0x0a, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x12, 0x2e, 0x65, 0x78,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x1a,
+	0x0f, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x65, 0x6e,
+	0x12, 0x12, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x42, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x49, 0x44, 0x1a, 0x13, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
+	0x4f, 0x70, 0x65, 0x6e, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x06, 0x46,
+	0x61, 0x6e, 0x6f, 0x75, 0x74, 0x12, 0x12, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x2e, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x15, 0x2e, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73,
+	0x22, 0x00, 0x12, 0x38, 0x0a, 0x04, 0x48, 0x61, 0x6c, 0x74, 0x12, 0x15, 0x2e, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x48, 0x61, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x17, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69,
+	0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x07, 0x54, 0x72,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x17, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22, 0x00, 0x42, 0x3f, 0x5a, 0x3d, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x61,
+	0x72, 0x74, 0x64, 0x65, 0x76, 0x2f, 0x74, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2f,
+	0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_exchange_proto_rawDescOnce sync.Once
+	file_api_exchange_proto_rawDescData = file_api_exchange_proto_rawDesc
+)
+
+func file_api_exchange_proto_rawDescGZIP() []byte {
+	file_api_exchange_proto_rawDescOnce.Do(func() {
+		file_api_exchange_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_exchange_proto_rawDescData)
+	})
+	return file_api_exchange_proto_rawDescData
+}
+
+var file_api_exchange_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_api_exchange_proto_goTypes = []interface{}{
+	(*Decimal)(nil),          // 0: exchange.Decimal
+	(*OHLCV)(nil),            // 1: exchange.OHLCV
+	(*Deal)(nil),             // 2: exchange.Deal
+	(*DealID)(nil),           // 3: exchange.DealID
+	(*BrokerID)(nil),         // 4: exchange.BrokerID
+	(*ResultsRequest)(nil),   // 5: exchange.ResultsRequest
+	(*Subscription)(nil),     // 6: exchange.Subscription
+	(*StatisticRequest)(nil), // 7: exchange.StatisticRequest
+	(*CancelResult)(nil),     // 8: exchange.CancelResult
+	(*ModifyDeal)(nil),       // 9: exchange.ModifyDeal
+	(*ModifyResult)(nil),     // 10: exchange.ModifyResult
+	(*DepthRequest)(nil),     // 11: exchange.DepthRequest
+	(*Level)(nil),            // 12: exchange.Level
+	(*MarketDepth)(nil),      // 13: exchange.MarketDepth
+	(*Trade)(nil),            // 14: exchange.Trade
+	(*OpenDeals)(nil),        // 15: exchange.OpenDeals
+	(*Subscriber)(nil),       // 16: exchange.Subscriber
+	(*Subscribers)(nil),      // 17: exchange.Subscribers
+	(*HaltRequest)(nil),      // 18: exchange.HaltRequest
+	(*TickerRequest)(nil),    // 19: exchange.TickerRequest
+	(*TradingStatus)(nil),    // 20: exchange.TradingStatus
+	(*TradingStatuses)(nil),  // 21: exchange.TradingStatuses
+}
+var file_api_exchange_proto_depIdxs = []int32{
+	0,  // 0: exchange.OHLCV.Open:type_name -> exchange.Decimal
+	0,  // 1: exchange.OHLCV.High:type_name -> exchange.Decimal
+	0,  // 2: exchange.OHLCV.Low:type_name -> exchange.Decimal
+	0,  // 3: exchange.OHLCV.Close:type_name -> exchange.Decimal
+	0,  // 4: exchange.Deal.Price:type_name -> exchange.Decimal
+	0,  // 5: exchange.Deal.StopPrice:type_name -> exchange.Decimal
+	6,  // 6: exchange.StatisticRequest.Subscriptions:type_name -> exchange.Subscription
+	0,  // 7: exchange.ModifyDeal.Price:type_name -> exchange.Decimal
+	0,  // 8: exchange.Level.Price:type_name -> exchange.Decimal
+	12, // 9: exchange.MarketDepth.Bids:type_name -> exchange.Level
+	12, // 10: exchange.MarketDepth.Asks:type_name -> exchange.Level
+	0,  // 11: exchange.Trade.Price:type_name -> exchange.Decimal
+	2,  // 12: exchange.OpenDeals.Deals:type_name -> exchange.Deal
+	16, // 13: exchange.Subscribers.Subscribers:type_name -> exchange.Subscriber
+	20, // 14: exchange.TradingStatuses.Statuses:type_name -> exchange.TradingStatus
+	7,  // 15: exchange.Exchange.Statistic:input_type -> exchange.StatisticRequest
+	2,  // 16: exchange.Exchange.Create:input_type -> exchange.Deal
+	3,  // 17: exchange.Exchange.Cancel:input_type -> exchange.DealID
+	9,  // 18: exchange.Exchange.Modify:input_type -> exchange.ModifyDeal
+	5,  // 19: exchange.Exchange.Results:input_type -> exchange.ResultsRequest
+	11, // 20: exchange.Exchange.Depth:input_type -> exchange.DepthRequest
+	4,  // 21: exchange.Exchange.Trades:input_type -> exchange.BrokerID
+	4,  // 22: exchange.Exchange.ListOpen:input_type -> exchange.BrokerID
+	4,  // 23: exchange.Exchange.Fanout:input_type -> exchange.BrokerID
+	18, // 24: exchange.Exchange.Halt:input_type -> exchange.HaltRequest
+	19, // 25: exchange.Exchange.Resume:input_type -> exchange.TickerRequest
+	19, // 26: exchange.Exchange.Trading:input_type -> exchange.TickerRequest
+	1,  // 27: exchange.Exchange.Statistic:output_type -> exchange.OHLCV
+	3,  // 28: exchange.Exchange.Create:output_type -> exchange.DealID
+	8,  // 29: exchange.Exchange.Cancel:output_type -> exchange.CancelResult
+	10, // 30: exchange.Exchange.Modify:output_type -> exchange.ModifyResult
+	2,  // 31: exchange.Exchange.Results:output_type -> exchange.Deal
+	13, // 32: exchange.Exchange.Depth:output_type -> exchange.MarketDepth
+	14, // 33: exchange.Exchange.Trades:output_type -> exchange.Trade
+	15, // 34: exchange.Exchange.ListOpen:output_type -> exchange.OpenDeals
+	17, // 35: exchange.Exchange.Fanout:output_type -> exchange.Subscribers
+	20, // 36: exchange.Exchange.Halt:output_type -> exchange.TradingStatus
+	20, // 37: exchange.Exchange.Resume:output_type -> exchange.TradingStatus
+	21, // 38: exchange.Exchange.Trading:output_type -> exchange.TradingStatuses
+	27, // [27:39] is the sub-list for method output_type
+	15, // [15:27] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_api_exchange_proto_init() }
@@ -484,7 +1797,7 @@ func file_api_exchange_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_api_exchange_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OHLCV); i {
+			switch v := v.(*Decimal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -496,7 +1809,7 @@ func file_api_exchange_proto_init() {
 			}
 		}
 		file_api_exchange_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Deal); i {
+			switch v := v.(*OHLCV); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -508,7 +1821,7 @@ func file_api_exchange_proto_init() {
 			}
 		}
 		file_api_exchange_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DealID); i {
+			switch v := v.(*Deal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -520,7 +1833,7 @@ func file_api_exchange_proto_init() {
 			}
 		}
 		file_api_exchange_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BrokerID); i {
+			switch v := v.(*DealID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -532,6 +1845,54 @@ func file_api_exchange_proto_init() {
 			}
 		}
 		file_api_exchange_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BrokerID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subscription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatisticRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CancelResult); i {
 			case 0:
 				return &v.state
@@ -543,6 +1904,162 @@ func file_api_exchange_proto_init() {
 				return nil
 			}
 		}
+		file_api_exchange_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifyDeal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifyResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Level); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarketDepth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenDeals); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subscriber); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subscribers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HaltRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TickerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradingStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_exchange_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradingStatuses); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -550,7 +2067,7 @@ func file_api_exchange_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_exchange_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -18,10 +18,18 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ExchangeClient interface {
-	Statistic(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (Exchange_StatisticClient, error)
+	Statistic(ctx context.Context, in *StatisticRequest, opts ...grpc.CallOption) (Exchange_StatisticClient, error)
 	Create(ctx context.Context, in *Deal, opts ...grpc.CallOption) (*DealID, error)
 	Cancel(ctx context.Context, in *DealID, opts ...grpc.CallOption) (*CancelResult, error)
-	Results(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (Exchange_ResultsClient, error)
+	Modify(ctx context.Context, in *ModifyDeal, opts ...grpc.CallOption) (*ModifyResult, error)
+	Results(ctx context.Context, in *ResultsRequest, opts ...grpc.CallOption) (Exchange_ResultsClient, error)
+	Depth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (Exchange_DepthClient, error)
+	Trades(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (Exchange_TradesClient, error)
+	ListOpen(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (*OpenDeals, error)
+	Fanout(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (*Subscribers, error)
+	Halt(ctx context.Context, in *HaltRequest, opts ...grpc.CallOption) (*TradingStatus, error)
+	Resume(ctx context.Context, in *TickerRequest, opts ...grpc.CallOption) (*TradingStatus, error)
+	Trading(ctx context.Context, in *TickerRequest, opts ...grpc.CallOption) (*TradingStatuses, error)
 }
 
 type exchangeClient struct {
@@ -32,7 +40,7 @@ func NewExchangeClient(cc grpc.ClientConnInterface) ExchangeClient {
 	return &exchangeClient{cc}
 }
 
-func (c *exchangeClient) Statistic(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (Exchange_StatisticClient, error) {
+func (c *exchangeClient) Statistic(ctx context.Context, in *StatisticRequest, opts ...grpc.CallOption) (Exchange_StatisticClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[0], "/exchange.Exchange/Statistic", opts...)
 	if err != nil {
 		return nil, err
@@ -82,7 +90,16 @@ func (c *exchangeClient) Cancel(ctx context.Context, in *DealID, opts ...grpc.Ca
 	return out, nil
 }
 
-func (c *exchangeClient) Results(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (Exchange_ResultsClient, error) {
+func (c *exchangeClient) Modify(ctx context.Context, in *ModifyDeal, opts ...grpc.CallOption) (*ModifyResult, error) {
+	out := new(ModifyResult)
+	err := c.cc.Invoke(ctx, "/exchange.Exchange/Modify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) Results(ctx context.Context, in *ResultsRequest, opts ...grpc.CallOption) (Exchange_ResultsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[1], "/exchange.Exchange/Results", opts...)
 	if err != nil {
 		return nil, err
@@ -114,14 +131,131 @@ func (x *exchangeResultsClient) Recv() (*Deal, error) {
 	return m, nil
 }
 
+func (c *exchangeClient) Depth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (Exchange_DepthClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[2], "/exchange.Exchange/Depth", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &exchangeDepthClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Exchange_DepthClient interface {
+	Recv() (*MarketDepth, error)
+	grpc.ClientStream
+}
+
+type exchangeDepthClient struct {
+	grpc.ClientStream
+}
+
+func (x *exchangeDepthClient) Recv() (*MarketDepth, error) {
+	m := new(MarketDepth)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *exchangeClient) Trades(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (Exchange_TradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[3], "/exchange.Exchange/Trades", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &exchangeTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Exchange_TradesClient interface {
+	Recv() (*Trade, error)
+	grpc.ClientStream
+}
+
+type exchangeTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *exchangeTradesClient) Recv() (*Trade, error) {
+	m := new(Trade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *exchangeClient) ListOpen(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (*OpenDeals, error) {
+	out := new(OpenDeals)
+	err := c.cc.Invoke(ctx, "/exchange.Exchange/ListOpen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) Fanout(ctx context.Context, in *BrokerID, opts ...grpc.CallOption) (*Subscribers, error) {
+	out := new(Subscribers)
+	err := c.cc.Invoke(ctx, "/exchange.Exchange/Fanout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) Halt(ctx context.Context, in *HaltRequest, opts ...grpc.CallOption) (*TradingStatus, error) {
+	out := new(TradingStatus)
+	err := c.cc.Invoke(ctx, "/exchange.Exchange/Halt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) Resume(ctx context.Context, in *TickerRequest, opts ...grpc.CallOption) (*TradingStatus, error) {
+	out := new(TradingStatus)
+	err := c.cc.Invoke(ctx, "/exchange.Exchange/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) Trading(ctx context.Context, in *TickerRequest, opts ...grpc.CallOption) (*TradingStatuses, error) {
+	out := new(TradingStatuses)
+	err := c.cc.Invoke(ctx, "/exchange.Exchange/Trading", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExchangeServer is the server API for Exchange service.
 // All implementations must embed UnimplementedExchangeServer
 // for forward compatibility
 type ExchangeServer interface {
-	Statistic(*BrokerID, Exchange_StatisticServer) error
+	Statistic(*StatisticRequest, Exchange_StatisticServer) error
 	Create(context.Context, *Deal) (*DealID, error)
 	Cancel(context.Context, *DealID) (*CancelResult, error)
-	Results(*BrokerID, Exchange_ResultsServer) error
+	Modify(context.Context, *ModifyDeal) (*ModifyResult, error)
+	Results(*ResultsRequest, Exchange_ResultsServer) error
+	Depth(*DepthRequest, Exchange_DepthServer) error
+	Trades(*BrokerID, Exchange_TradesServer) error
+	ListOpen(context.Context, *BrokerID) (*OpenDeals, error)
+	Fanout(context.Context, *BrokerID) (*Subscribers, error)
+	Halt(context.Context, *HaltRequest) (*TradingStatus, error)
+	Resume(context.Context, *TickerRequest) (*TradingStatus, error)
+	Trading(context.Context, *TickerRequest) (*TradingStatuses, error)
 	mustEmbedUnimplementedExchangeServer()
 }
 
@@ -129,7 +263,7 @@ type ExchangeServer interface {
 type UnimplementedExchangeServer struct {
 }
 
-func (UnimplementedExchangeServer) Statistic(*BrokerID, Exchange_StatisticServer) error {
+func (UnimplementedExchangeServer) Statistic(*StatisticRequest, Exchange_StatisticServer) error {
 	return status.Errorf(codes.Unimplemented, "method Statistic not implemented")
 }
 func (UnimplementedExchangeServer) Create(context.Context, *Deal) (*DealID, error) {
@@ -138,9 +272,33 @@ func (UnimplementedExchangeServer) Create(context.Context, *Deal) (*DealID, erro
 func (UnimplementedExchangeServer) Cancel(context.Context, *DealID) (*CancelResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
-func (UnimplementedExchangeServer) Results(*BrokerID, Exchange_ResultsServer) error {
+func (UnimplementedExchangeServer) Modify(context.Context, *ModifyDeal) (*ModifyResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Modify not implemented")
+}
+func (UnimplementedExchangeServer) Results(*ResultsRequest, Exchange_ResultsServer) error {
 	return status.Errorf(codes.Unimplemented, "method Results not implemented")
 }
+func (UnimplementedExchangeServer) Depth(*DepthRequest, Exchange_DepthServer) error {
+	return status.Errorf(codes.Unimplemented, "method Depth not implemented")
+}
+func (UnimplementedExchangeServer) Trades(*BrokerID, Exchange_TradesServer) error {
+	return status.Errorf(codes.Unimplemented, "method Trades not implemented")
+}
+func (UnimplementedExchangeServer) ListOpen(context.Context, *BrokerID) (*OpenDeals, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOpen not implemented")
+}
+func (UnimplementedExchangeServer) Fanout(context.Context, *BrokerID) (*Subscribers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fanout not implemented")
+}
+func (UnimplementedExchangeServer) Halt(context.Context, *HaltRequest) (*TradingStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Halt not implemented")
+}
+func (UnimplementedExchangeServer) Resume(context.Context, *TickerRequest) (*TradingStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedExchangeServer) Trading(context.Context, *TickerRequest) (*TradingStatuses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Trading not implemented")
+}
 func (UnimplementedExchangeServer) mustEmbedUnimplementedExchangeServer() {}
 
 // UnsafeExchangeServer may be embedded to opt out of forward compatibility for this service.
@@ -155,7 +313,7 @@ func RegisterExchangeServer(s grpc.ServiceRegistrar, srv ExchangeServer) {
 }
 
 func _Exchange_Statistic_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(BrokerID)
+	m := new(StatisticRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -211,8 +369,26 @@ func _Exchange_Cancel_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Exchange_Modify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyDeal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).Modify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/exchange.Exchange/Modify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).Modify(ctx, req.(*ModifyDeal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Exchange_Results_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(BrokerID)
+	m := new(ResultsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -232,6 +408,138 @@ func (x *exchangeResultsServer) Send(m *Deal) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Exchange_Depth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DepthRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExchangeServer).Depth(m, &exchangeDepthServer{stream})
+}
+
+type Exchange_DepthServer interface {
+	Send(*MarketDepth) error
+	grpc.ServerStream
+}
+
+type exchangeDepthServer struct {
+	grpc.ServerStream
+}
+
+func (x *exchangeDepthServer) Send(m *MarketDepth) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Exchange_Trades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BrokerID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExchangeServer).Trades(m, &exchangeTradesServer{stream})
+}
+
+type Exchange_TradesServer interface {
+	Send(*Trade) error
+	grpc.ServerStream
+}
+
+type exchangeTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *exchangeTradesServer) Send(m *Trade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Exchange_ListOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrokerID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).ListOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/exchange.Exchange/ListOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).ListOpen(ctx, req.(*BrokerID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_Fanout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrokerID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).Fanout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/exchange.Exchange/Fanout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).Fanout(ctx, req.(*BrokerID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_Halt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaltRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).Halt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/exchange.Exchange/Halt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).Halt(ctx, req.(*HaltRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TickerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/exchange.Exchange/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).Resume(ctx, req.(*TickerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_Trading_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TickerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).Trading(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/exchange.Exchange/Trading",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).Trading(ctx, req.(*TickerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Exchange_ServiceDesc is the grpc.ServiceDesc for Exchange service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -247,6 +555,30 @@ var Exchange_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Cancel",
 			Handler:    _Exchange_Cancel_Handler,
 		},
+		{
+			MethodName: "Modify",
+			Handler:    _Exchange_Modify_Handler,
+		},
+		{
+			MethodName: "ListOpen",
+			Handler:    _Exchange_ListOpen_Handler,
+		},
+		{
+			MethodName: "Fanout",
+			Handler:    _Exchange_Fanout_Handler,
+		},
+		{
+			MethodName: "Halt",
+			Handler:    _Exchange_Halt_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Exchange_Resume_Handler,
+		},
+		{
+			MethodName: "Trading",
+			Handler:    _Exchange_Trading_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -259,6 +591,16 @@ var Exchange_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Exchange_Results_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Depth",
+			Handler:       _Exchange_Depth_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Trades",
+			Handler:       _Exchange_Trades_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/exchange.proto",
 }
@@ -2,10 +2,13 @@ package rpc
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/marksartdev/trading/internal/exchange"
@@ -14,45 +17,68 @@ import (
 
 const errLimit = 10
 
+// Metadata key of admin token.
+const adminTokenKey = "x-admin-token"
+
+// Stream of subscriber is ended when exchange disconnects it as slow consumer.
+var errSlowConsumer = status.Error(codes.ResourceExhausted, "slow consumer is disconnected")
+
 const gRPC log.Action = "gRPC"
 
 // gRPC server.
 type exchangeServer struct {
-	logger  log.Logger
-	service exchange.ExchangeService
+	logger     log.Logger
+	service    exchange.ExchangeService
+	adminToken string
 	UnimplementedExchangeServer
 }
 
-// NewExchangeServer creates new gRPC server.
-func NewExchangeServer(logger log.Logger, service exchange.ExchangeService) ExchangeServer {
-	return &exchangeServer{logger: logger, service: service}
+// NewExchangeServer creates new gRPC server. Admin requests are available only with admin token.
+func NewExchangeServer(logger log.Logger, service exchange.ExchangeService, adminToken string) ExchangeServer {
+	return &exchangeServer{logger: logger, service: service, adminToken: adminToken}
 }
 
 // Statistic streams statistic.
-func (e exchangeServer) Statistic(brokerID *BrokerID, stream Exchange_StatisticServer) error {
+func (e exchangeServer) Statistic(req *StatisticRequest, stream Exchange_StatisticServer) error {
 	var errCount int
 
 	broker := exchange.Broker{
-		ID:         brokerID.GetID(),
+		ID:         req.GetBrokerID(),
 		InstanceID: time.Now().UnixNano(),
 	}
 
-	e.logger.Info(gRPC, fmt.Sprintf("start streaming statistic for brocker %d", brokerID.GetID()))
-	defer e.logger.Info(gRPC, fmt.Sprintf("stop streaming statistic for brocker %d", brokerID.GetID()))
-	defer e.service.StatisticUnsubscribe(broker)
+	subs := make([]exchange.Subscription, len(req.GetSubscriptions()))
+	for i, sub := range req.GetSubscriptions() {
+		subs[i] = exchange.Subscription{
+			Ticker:   sub.GetTicker(),
+			Interval: time.Duration(sub.GetInterval()),
+		}
+	}
 
 	ch := make(chan exchange.OHLCV, 100)
-	e.service.Statistic(broker, ch)
+	if err := e.service.Statistic(broker, subs, ch); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Header tells broker that the stream is established.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		e.service.StatisticUnsubscribe(broker)
+		return err
+	}
+
+	e.logger.Info(gRPC, fmt.Sprintf("start streaming statistic for brocker %d", req.GetBrokerID()))
+	defer e.logger.Info(gRPC, fmt.Sprintf("stop streaming statistic for brocker %d", req.GetBrokerID()))
+	defer e.service.StatisticUnsubscribe(broker)
 
 	for st := range ch {
 		ohlcv := OHLCV{
 			ID:       st.ID,
 			Time:     st.Time.Unix(),
-			Interval: int32(st.Interval.Nanoseconds()),
-			Open:     st.Open,
-			High:     st.High,
-			Low:      st.Low,
-			Close:    st.Close,
+			Interval: st.Interval.Nanoseconds(),
+			Open:     NewDecimal(st.Open),
+			High:     NewDecimal(st.High),
+			Low:      NewDecimal(st.Low),
+			Close:    NewDecimal(st.Close),
 			Volume:   st.Volume,
 			Ticker:   st.Ticker,
 		}
@@ -73,23 +99,44 @@ func (e exchangeServer) Statistic(brokerID *BrokerID, stream Exchange_StatisticS
 		}
 	}
 
-	return nil
+	return errSlowConsumer
 }
 
 // Create adds a deal to exchange queue.
 func (e exchangeServer) Create(_ context.Context, deal *Deal) (*DealID, error) {
 	d := exchange.Deal{
-		ID:       deal.GetID(),
-		BrokerID: deal.GetBrokerID(),
-		ClientID: deal.GetClientID(),
-		Ticker:   deal.GetTicker(),
-		Amount:   deal.GetAmount(),
-		Partial:  deal.GetPartial(),
-		Time:     time.Unix(deal.GetTime(), 0),
-		Price:    deal.GetPrice(),
+		ID:          deal.GetID(),
+		BrokerID:    deal.GetBrokerID(),
+		ClientID:    deal.GetClientID(),
+		Ticker:      deal.GetTicker(),
+		Side:        exchange.Side(deal.GetSide()),
+		Type:        exchange.OrderType(deal.GetType()),
+		TimeInForce: exchange.TimeInForce(deal.GetTimeInForce()),
+		Amount:      deal.GetAmount(),
+		Partial:     deal.GetPartial(),
+		Time:        time.Unix(deal.GetTime(), 0),
+		Price:       deal.GetPrice().Decimal(),
+		StopPrice:   deal.GetStopPrice().Decimal(),
+	}
+
+	if d.Type == "" {
+		d.Type = exchange.Limit
 	}
 
-	d = e.service.Create(d)
+	if d.TimeInForce == "" {
+		d.TimeInForce = exchange.GTC
+	}
+
+	if err := validate(d); err != nil {
+		e.logger.Error(gRPC, err)
+		return nil, err
+	}
+
+	d, err := e.service.Create(d)
+	if err != nil {
+		e.logger.Error(gRPC, err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 
 	e.logger.Info(gRPC, fmt.Sprintf("%q request from broker %d wath handled", "Create", deal.GetBrokerID()))
 
@@ -98,39 +145,353 @@ func (e exchangeServer) Create(_ context.Context, deal *Deal) (*DealID, error) {
 
 // Cancel remove deal from exchange queue.
 func (e exchangeServer) Cancel(_ context.Context, dealID *DealID) (*CancelResult, error) {
-	ok := e.service.Cancel(dealID.GetID())
+	ok, err := e.service.Cancel(dealID.GetBrokerID(), dealID.GetID())
+	if err != nil {
+		e.logger.Error(gRPC, err)
+		return nil, status.Error(rejectionCode(err), err.Error())
+	}
 
 	e.logger.Info(gRPC, fmt.Sprintf("%q request from broker %d wath handled", "Cancel", dealID.GetBrokerID()))
 
 	return &CancelResult{Success: ok}, nil
 }
 
-// Results streams results of deals.
-func (e exchangeServer) Results(brokerID *BrokerID, stream Exchange_ResultsServer) error {
-	var errCount int
+// Modify changes price and/or amount of a deal in exchange queue.
+func (e exchangeServer) Modify(_ context.Context, deal *ModifyDeal) (*ModifyResult, error) {
+	price := deal.GetPrice().Decimal()
+	if price < 0 || deal.GetAmount() < 0 {
+		err := status.Error(codes.InvalidArgument, "price and amount must not be negative")
+		e.logger.Error(gRPC, err)
+		return nil, err
+	}
+
+	ok, err := e.service.Modify(deal.GetBrokerID(), deal.GetID(), price, deal.GetAmount())
+	if err != nil {
+		e.logger.Error(gRPC, err)
+		return nil, status.Error(rejectionCode(err), err.Error())
+	}
+
+	e.logger.Info(gRPC, fmt.Sprintf("%q request from broker %d wath handled", "Modify", deal.GetBrokerID()))
+
+	return &ModifyResult{Success: ok}, nil
+}
+
+// Returns status code of a rejected change of deal.
+func rejectionCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, exchange.ErrNotAccepted):
+		return codes.FailedPrecondition
+	case errors.Is(err, exchange.ErrForeignDeal):
+		return codes.PermissionDenied
+	default:
+		return codes.Internal
+	}
+}
+
+// ListOpen returns resting deals of a broker.
+func (e exchangeServer) ListOpen(_ context.Context, brokerID *BrokerID) (*OpenDeals, error) {
+	deals := e.service.ListOpen(brokerID.GetID())
+
+	res := OpenDeals{Deals: make([]*Deal, len(deals))}
+	for i, d := range deals {
+		res.Deals[i] = &Deal{
+			ID:          d.ID,
+			BrokerID:    d.BrokerID,
+			ClientID:    d.ClientID,
+			Ticker:      d.Ticker,
+			Amount:      d.Amount,
+			Time:        d.Time.Unix(),
+			Price:       NewDecimal(d.Price),
+			Side:        string(d.Side),
+			Type:        string(d.Type),
+			StopPrice:   NewDecimal(d.StopPrice),
+			TimeInForce: string(d.TimeInForce),
+		}
+	}
+
+	return &res, nil
+}
+
+// Fanout returns metrics of stream subscribers. Zero broker means all brokers.
+func (e exchangeServer) Fanout(_ context.Context, brokerID *BrokerID) (*Subscribers, error) {
+	var res Subscribers
+
+	for _, st := range e.service.Subscribers() {
+		if brokerID.GetID() != 0 && st.BrokerID != brokerID.GetID() {
+			continue
+		}
+
+		res.Subscribers = append(res.Subscribers, &Subscriber{
+			BrokerID:     st.BrokerID,
+			Stream:       st.Stream,
+			Policy:       st.Policy,
+			Depth:        int32(st.Depth),
+			Capacity:     int32(st.Capacity),
+			Sent:         st.Sent,
+			Dropped:      st.Dropped,
+			Disconnected: st.Disconnected,
+		})
+	}
+
+	return &res, nil
+}
 
+// Halt halts trading of a ticker.
+func (e exchangeServer) Halt(ctx context.Context, req *HaltRequest) (*TradingStatus, error) {
+	if err := e.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	st, err := e.service.Halt(req.GetTicker(), req.GetReason())
+	if err != nil {
+		e.logger.Error(gRPC, err)
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	e.logger.Info(gRPC, fmt.Sprintf("%q request for %s wath handled", "Halt", req.GetTicker()))
+
+	return tradingStatus(st), nil
+}
+
+// Resume resumes trading of a halted ticker.
+func (e exchangeServer) Resume(ctx context.Context, req *TickerRequest) (*TradingStatus, error) {
+	if err := e.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	st, err := e.service.Resume(req.GetTicker())
+	if err != nil {
+		e.logger.Error(gRPC, err)
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	e.logger.Info(gRPC, fmt.Sprintf("%q request for %s wath handled", "Resume", req.GetTicker()))
+
+	return tradingStatus(st), nil
+}
+
+// Trading returns trading status of a ticker. Empty ticker means all tickers.
+func (e exchangeServer) Trading(_ context.Context, req *TickerRequest) (*TradingStatuses, error) {
+	var res TradingStatuses
+
+	for _, st := range e.service.Trading(req.GetTicker()) {
+		res.Statuses = append(res.Statuses, tradingStatus(st))
+	}
+
+	return &res, nil
+}
+
+// Checks admin token of request.
+func (e exchangeServer) admin(ctx context.Context) error {
+	if e.adminToken == "" {
+		return status.Error(codes.PermissionDenied, "admin requests are disabled")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, token := range md.Get(adminTokenKey) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(e.adminToken)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.PermissionDenied, "invalid admin token")
+}
+
+// Converts trading status.
+func tradingStatus(st exchange.TradingStatus) *TradingStatus {
+	res := TradingStatus{
+		Ticker: st.Ticker,
+		Phase:  string(st.Phase),
+		Halted: st.Halted,
+		Reason: st.Reason,
+	}
+
+	if !st.Until.IsZero() {
+		res.Until = st.Until.Unix()
+	}
+
+	return &res
+}
+
+// Results streams results of deals. Results after the last seen sequence are replayed first.
+func (e exchangeServer) Results(req *ResultsRequest, stream Exchange_ResultsServer) error {
 	broker := exchange.Broker{
-		ID:         brokerID.GetID(),
+		ID:         req.GetBrokerID(),
 		InstanceID: time.Now().UnixNano(),
 	}
 
-	e.logger.Info(gRPC, fmt.Sprintf("start streaming results for brocker %d", brokerID.GetID()))
-	defer e.logger.Info(gRPC, fmt.Sprintf("stop streaming results for brocker %d", brokerID.GetID()))
+	e.logger.Info(gRPC, fmt.Sprintf("start streaming results for brocker %d from %d", req.GetBrokerID(), req.GetSeq()))
+	defer e.logger.Info(gRPC, fmt.Sprintf("stop streaming results for brocker %d", req.GetBrokerID()))
 	defer e.service.ResultsUnsubscribe(broker)
 
 	ch := make(chan exchange.Deal, 100)
-	e.service.Results(broker, ch)
+	missed := e.service.Results(broker, req.GetSeq(), ch)
+
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
 
-	for r := range ch {
+	send := func(r exchange.Deal) (bool, error) {
 		res := Deal{
-			ID:       r.ID,
-			BrokerID: r.BrokerID,
-			ClientID: r.ClientID,
-			Ticker:   r.Ticker,
-			Amount:   r.Amount,
-			Partial:  r.Partial,
-			Time:     r.Time.Unix(),
-			Price:    r.Price,
+			ID:          r.ID,
+			BrokerID:    r.BrokerID,
+			ClientID:    r.ClientID,
+			Ticker:      r.Ticker,
+			Amount:      r.Amount,
+			Partial:     r.Partial,
+			Time:        r.Time.Unix(),
+			Price:       NewDecimal(r.Price),
+			Side:        string(r.Side),
+			Type:        string(r.Type),
+			StopPrice:   NewDecimal(r.StopPrice),
+			TimeInForce: string(r.TimeInForce),
+			Status:      string(r.Status),
+			Seq:         r.Seq,
+			FillID:      r.FillID,
+		}
+
+		// Result which is not sent is never skipped, broker resubscribes and it is replayed.
+		err := stream.Send(&res)
+		if err != nil {
+			if s, ok := status.FromError(err); ok {
+				if s.Code() == codes.Unavailable {
+					return false, nil
+				}
+			}
+			e.logger.Error(gRPC, err)
+
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	for _, r := range missed {
+		if ok, err := send(r); !ok {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case r, open := <-ch:
+			if !open {
+				return errSlowConsumer
+			}
+
+			if ok, err := send(r); !ok {
+				return err
+			}
+		}
+	}
+}
+
+// Depth streams market depth of a ticker.
+func (e exchangeServer) Depth(req *DepthRequest, stream Exchange_DepthServer) error {
+	var errCount int
+
+	if req.GetTicker() == "" {
+		return status.Error(codes.InvalidArgument, "ticker is required")
+	}
+
+	broker := exchange.Broker{
+		ID:         req.GetBrokerID(),
+		InstanceID: time.Now().UnixNano(),
+	}
+
+	e.logger.Info(gRPC, fmt.Sprintf("start streaming depth of %s for brocker %d", req.GetTicker(), req.GetBrokerID()))
+	defer e.logger.Info(gRPC, fmt.Sprintf("stop streaming depth of %s for brocker %d", req.GetTicker(), req.GetBrokerID()))
+	defer e.service.DepthUnsubscribe(broker)
+
+	ch := make(chan exchange.Depth, 100)
+	e.service.Depth(broker, req.GetTicker(), int(req.GetLevels()), ch)
+
+	for {
+		var (
+			d    exchange.Depth
+			open bool
+		)
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case d, open = <-ch:
+		}
+
+		if !open {
+			return errSlowConsumer
+		}
+
+		res := MarketDepth{
+			Ticker:   d.Ticker,
+			Time:     d.Time.Unix(),
+			Snapshot: d.Snapshot,
+			Bids:     levels(d.Bids),
+			Asks:     levels(d.Asks),
+		}
+
+		err := stream.Send(&res)
+		if err != nil {
+			if s, ok := status.FromError(err); ok {
+				if s.Code() == codes.Unavailable {
+					return nil
+				}
+			}
+			e.logger.Error(gRPC, err)
+
+			errCount++
+			if errCount > errLimit {
+				return err
+			}
+		}
+	}
+}
+
+// Trades streams trade tape.
+func (e exchangeServer) Trades(brokerID *BrokerID, stream Exchange_TradesServer) error {
+	var errCount int
+
+	broker := exchange.Broker{
+		ID:         brokerID.GetID(),
+		InstanceID: time.Now().UnixNano(),
+	}
+
+	e.logger.Info(gRPC, fmt.Sprintf("start streaming trades for brocker %d", brokerID.GetID()))
+	defer e.logger.Info(gRPC, fmt.Sprintf("stop streaming trades for brocker %d", brokerID.GetID()))
+	defer e.service.TradesUnsubscribe(broker)
+
+	ch := make(chan exchange.Trade, 100)
+	e.service.Trades(broker, ch)
+
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		var (
+			t    exchange.Trade
+			open bool
+		)
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case t, open = <-ch:
+		}
+
+		if !open {
+			return errSlowConsumer
+		}
+
+		res := Trade{
+			ID:     t.ID,
+			Ticker: t.Ticker,
+			Price:  NewDecimal(t.Price),
+			Amount: t.Amount,
+			Side:   string(t.Side),
+			Time:   t.Time.Unix(),
 		}
 
 		err := stream.Send(&res)
@@ -148,6 +509,51 @@ func (e exchangeServer) Results(brokerID *BrokerID, stream Exchange_ResultsServe
 			}
 		}
 	}
+}
+
+// Converts price levels.
+func levels(in []exchange.Level) []*Level {
+	res := make([]*Level, len(in))
+	for i := range in {
+		res[i] = &Level{Price: NewDecimal(in[i].Price), Amount: in[i].Amount, Orders: in[i].Orders}
+	}
+
+	return res
+}
+
+// Validates side, type, prices and time in force of a deal.
+func validate(deal exchange.Deal) error {
+	if deal.Side != exchange.Buy && deal.Side != exchange.Sell {
+		return status.Errorf(codes.InvalidArgument, "unknown side %q", deal.Side)
+	}
+
+	if deal.Amount <= 0 {
+		return status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+
+	switch deal.Type {
+	case exchange.Market:
+	case exchange.Limit:
+		if deal.Price <= 0 {
+			return status.Error(codes.InvalidArgument, "limit price must be positive")
+		}
+	case exchange.Stop:
+		if deal.StopPrice <= 0 {
+			return status.Error(codes.InvalidArgument, "stop price must be positive")
+		}
+	case exchange.StopLimit:
+		if deal.Price <= 0 || deal.StopPrice <= 0 {
+			return status.Error(codes.InvalidArgument, "limit and stop prices must be positive")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown order type %q", deal.Type)
+	}
+
+	switch deal.TimeInForce {
+	case exchange.GTC, exchange.IOC, exchange.FOK, exchange.Day:
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown time in force %q", deal.TimeInForce)
+	}
 
 	return nil
 }
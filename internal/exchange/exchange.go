@@ -1,40 +1,223 @@
 package exchange
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+var (
+	// ErrNotAccepted deal is not accepted out of session or while trading of its ticker is halted.
+	ErrNotAccepted = errors.New("deal is not accepted")
+	// ErrForeignDeal deal belongs to another broker.
+	ErrForeignDeal = errors.New("deal belongs to another broker")
+)
 
 // OHLCV statistic.
 type OHLCV struct {
 	ID       int64
 	Time     time.Time
 	Interval time.Duration
-	Open     float64
-	High     float64
-	Low      float64
-	Close    float64
+	Open     decimal.Decimal
+	High     decimal.Decimal
+	Low      decimal.Decimal
+	Close    decimal.Decimal
 	Volume   int32
 	Ticker   string
 }
 
-// Deal purchase/sale of ticker.
-type Deal struct {
-	ID       int64
-	BrokerID int64
-	ClientID int64
+// Trade anonymous print of an execution. Side is the side of the aggressor.
+type Trade struct {
+	ID     int64
+	Ticker string
+	Price  decimal.Decimal
+	Amount int32
+	Side   Side
+	Time   time.Time
+}
+
+// EventType type of journal event.
+type EventType string
+
+const (
+	// EventCreate deal is added to the book.
+	EventCreate EventType = "CREATE"
+	// EventCancel deal is removed from the book without execution.
+	EventCancel EventType = "CANCEL"
+	// EventUpdate deal is changed keeping its priority.
+	EventUpdate EventType = "UPDATE"
+	// EventTrigger stop deal is moved to the book.
+	EventTrigger EventType = "TRIGGER"
+	// EventFill deal is executed, Deal holds its rest.
+	EventFill EventType = "FILL"
+	// EventInventory amount of a ticker available at exchange is changed.
+	EventInventory EventType = "INVENTORY"
+	// EventResult result of a deal is numbered for its broker, Deal holds the result.
+	EventResult EventType = "RESULT"
+)
+
+// Event journal event of the book.
+type Event struct {
+	Seq       int64
+	Type      EventType
+	Time      time.Time
+	Deal      Deal
+	Inventory int32
+}
+
+// Snapshot state of the book at a journal position. LastID is the highest reserved identifier,
+// Results are buffered results of brokers kept for replay.
+type Snapshot struct {
+	Seq       int64
+	Deals     []Deal
+	Inventory map[string]int32
+	LastID    int64
+	Results   map[int64][]Deal
+}
+
+// Level aggregated price level of a book side.
+type Level struct {
+	Price  decimal.Decimal
+	Amount int32
+	Orders int32
+}
+
+// Depth market depth of a ticker. Snapshot contains top levels of both sides,
+// otherwise only changed levels are sent and a level with zero amount is removed.
+type Depth struct {
 	Ticker   string
-	Amount   int32
-	Partial  bool
 	Time     time.Time
-	Price    float64
+	Snapshot bool
+	Bids     []Level
+	Asks     []Level
+}
+
+// Side side of deal.
+type Side string
+
+const (
+	// Buy purchase side.
+	Buy Side = "BUY"
+	// Sell sale side.
+	Sell Side = "SELL"
+)
+
+// OrderType type of order.
+type OrderType string
+
+const (
+	// Market order completed by the best available price.
+	Market OrderType = "MARKET"
+	// Limit order completed by the limit price or better.
+	Limit OrderType = "LIMIT"
+	// Stop order which becomes market order when stop price is reached.
+	Stop OrderType = "STOP"
+	// StopLimit order which becomes limit order when stop price is reached.
+	StopLimit OrderType = "STOP_LIMIT"
+)
+
+// TimeInForce how long deal stays active.
+type TimeInForce string
+
+const (
+	// GTC good till cancelled.
+	GTC TimeInForce = "GTC"
+	// IOC immediate or cancel, the rest of the deal expires right after the first matching.
+	IOC TimeInForce = "IOC"
+	// FOK fill or kill, the deal is completed fully or expires.
+	FOK TimeInForce = "FOK"
+	// Day deal expires at session close, it is not accepted without session schedule.
+	Day TimeInForce = "DAY"
+)
+
+// DealStatus status of deal result.
+type DealStatus string
+
+const (
+	// Filled deal is completed.
+	Filled DealStatus = "FILLED"
+	// Expired deal is removed by its time in force.
+	Expired DealStatus = "EXPIRED"
+)
+
+// Deal purchase/sale of ticker. Amount of a queued deal is its remaining quantity.
+// In results Amount and Price describe a single fill, and Partial marks that the rest of the deal stays in the queue.
+// FillID identifies a result, so receivers may skip results delivered twice.
+type Deal struct {
+	ID          int64
+	BrokerID    int64
+	ClientID    int64
+	Ticker      string
+	Side        Side
+	Type        OrderType
+	TimeInForce TimeInForce
+	Status      DealStatus
+	Amount      int32
+	Partial     bool
+	Time        time.Time
+	Price       decimal.Decimal
+	StopPrice   decimal.Decimal
+	Seq         int64
+	FillID      int64
+}
+
+// Phase trading phase of session.
+type Phase string
+
+const (
+	// PreOpen deals are accepted, but not filled. In continuous mode they are uncrossed at open.
+	PreOpen Phase = "PRE_OPEN"
+	// Continuous deals are accepted and filled.
+	Continuous Phase = "CONTINUOUS"
+	// ClosingAuction deals are accepted and uncrossed at a single clearing price at close.
+	ClosingAuction Phase = "CLOSING_AUCTION"
+	// Closed deals are not accepted.
+	Closed Phase = "CLOSED"
+)
+
+// TradingStatus trading status of a ticker. Halted ticker neither accepts nor fills deals.
+// Halt with zero time lasts until ticker is resumed.
+type TradingStatus struct {
+	Ticker string
+	Phase  Phase
+	Halted bool
+	Reason string
+	Until  time.Time
+}
+
+// SubscriberStats metrics of stream subscriber.
+type SubscriberStats struct {
+	BrokerID     int64
+	Stream       string
+	Policy       string
+	Depth        int
+	Capacity     int
+	Sent         int64
+	Dropped      int64
+	Disconnected bool
 }
 
 // ExchangeService service for exchanging.
 type ExchangeService interface {
+	Recover() error
 	Start()
 	Stop()
-	Statistic(broker Broker, ch chan OHLCV)
+	Statistic(broker Broker, subs []Subscription, ch chan OHLCV) error
 	StatisticUnsubscribe(broker Broker)
-	Create(deal Deal) Deal
-	Cancel(dealID int64) bool
-	Results(broker Broker, ch chan Deal)
+	Create(deal Deal) (Deal, error)
+	Cancel(brokerID, dealID int64) (bool, error)
+	Modify(brokerID, dealID int64, price decimal.Decimal, amount int32) (bool, error)
+	ListOpen(brokerID int64) []Deal
+	Subscribers() []SubscriberStats
+	Halt(ticker, reason string) (TradingStatus, error)
+	Resume(ticker string) (TradingStatus, error)
+	Trading(ticker string) []TradingStatus
+	Process(tick Tick) []Deal
+	Results(broker Broker, seq int64, ch chan Deal) []Deal
 	ResultsUnsubscribe(broker Broker)
+	Depth(broker Broker, ticker string, levels int, ch chan Depth)
+	DepthUnsubscribe(broker Broker)
+	Trades(broker Broker, ch chan Trade)
+	TradesUnsubscribe(broker Broker)
 }
@@ -0,0 +1,217 @@
+package file
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/services"
+)
+
+const (
+	journalName  = "journal.jsonl"
+	snapshotName = "snapshot.json"
+	idsName      = "ids.json"
+)
+
+// Reserved identifiers.
+type reservation struct {
+	LastID int64
+}
+
+// Journal is an append-only file of JSON lines with a snapshot next to it.
+type Journal interface {
+	services.Journal
+	Close() error
+}
+
+// File journal.
+type journal struct {
+	mu    *sync.Mutex
+	dir   string
+	flush bool
+	file  *os.File
+}
+
+// NewJournal opens journal in a directory. With flush every event is synced to disk before return.
+func NewJournal(dir string, flush bool) (Journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, journalName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &journal{mu: &sync.Mutex{}, dir: dir, flush: flush, file: file}, nil
+}
+
+// Append appends event to journal.
+func (j *journal) Append(event exchange.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if j.flush {
+		return j.file.Sync()
+	}
+
+	return nil
+}
+
+// Snapshot atomically replaces snapshot and truncates journal.
+// Events covered by snapshot are skipped on load, so a crash between the two steps is safe.
+func (j *journal) Snapshot(snapshot exchange.Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	tmp := filepath.Join(j.dir, snapshotName+".tmp")
+	if err := writeFile(tmp, data); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, filepath.Join(j.dir, snapshotName)); err != nil {
+		return err
+	}
+
+	return j.file.Truncate(0)
+}
+
+// Reserve atomically stores the highest identifier which may be issued.
+func (j *journal) Reserve(lastID int64) error {
+	data, err := json.Marshal(reservation{LastID: lastID})
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	tmp := filepath.Join(j.dir, idsName+".tmp")
+	if err := writeFile(tmp, data); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, filepath.Join(j.dir, idsName))
+}
+
+// Load returns snapshot and events written after it. Last identifier of snapshot is raised to the reserved one.
+// An incomplete last line left by a crash is cut off.
+func (j *journal) Load() (exchange.Snapshot, []exchange.Event, error) {
+	var snapshot exchange.Snapshot
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(j.dir, snapshotName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return snapshot, nil, err
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return snapshot, nil, fmt.Errorf("invalid snapshot: %w", err)
+		}
+	}
+
+	data, err = os.ReadFile(filepath.Join(j.dir, idsName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return snapshot, nil, err
+	}
+
+	if err == nil {
+		var ids reservation
+		if err := json.Unmarshal(data, &ids); err != nil {
+			return snapshot, nil, fmt.Errorf("invalid reserved identifiers: %w", err)
+		}
+
+		if ids.LastID > snapshot.LastID {
+			snapshot.LastID = ids.LastID
+		}
+	}
+
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return snapshot, nil, err
+	}
+
+	var (
+		events []exchange.Event
+		offset int64
+	)
+
+	reader := bufio.NewReader(j.file)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				return snapshot, events, j.file.Truncate(offset)
+			}
+
+			return snapshot, events, nil
+		}
+
+		if err != nil {
+			return snapshot, nil, err
+		}
+
+		var event exchange.Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return snapshot, nil, fmt.Errorf("invalid journal event at offset %d: %w", offset, err)
+		}
+
+		offset += int64(len(line))
+
+		if event.Seq > snapshot.Seq {
+			events = append(events, event)
+		}
+	}
+}
+
+// Close closes journal file.
+func (j *journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.file.Close()
+}
+
+// Writes file and flushes it to disk.
+func writeFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,387 @@
+package memory
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/services"
+)
+
+// Limit price of market buy deals, which crosses any ask.
+const maxPrice = decimal.Decimal(math.MaxInt64)
+
+// Price level of a book side.
+type level struct {
+	price decimal.Decimal
+	deals []exchange.Deal
+}
+
+// Side of a book. Levels are sorted from the best price to the worst one.
+type side struct {
+	levels []*level
+	better func(a, b decimal.Decimal) bool
+}
+
+// Order book of a ticker.
+type book struct {
+	bids  side
+	asks  side
+	stops []exchange.Deal
+}
+
+// Price-time priority order book.
+type orderBook struct {
+	mu    *sync.RWMutex
+	books map[string]*book
+	index map[int64]exchange.Deal
+}
+
+// NewOrderBook creates new order book.
+func NewOrderBook() services.DealQueue {
+	return &orderBook{
+		mu:    &sync.RWMutex{},
+		books: make(map[string]*book),
+		index: make(map[int64]exchange.Deal),
+	}
+}
+
+// Add adds a deal to the book.
+func (o *orderBook) Add(deal exchange.Deal) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	b, ok := o.books[deal.Ticker]
+	if !ok {
+		b = newBook()
+		o.books[deal.Ticker] = b
+	}
+
+	if deal.Type == exchange.Stop || deal.Type == exchange.StopLimit {
+		b.stops = append(b.stops, deal)
+	} else {
+		b.side(deal).add(deal)
+	}
+
+	o.index[deal.ID] = deal
+}
+
+// Trigger moves stop deals reached by a ticker price to the book and returns them.
+func (o *orderBook) Trigger(ticker string, price decimal.Decimal) []exchange.Deal {
+	var res []exchange.Deal
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	b, ok := o.books[ticker]
+	if !ok {
+		return nil
+	}
+
+	stops := b.stops[:0]
+	for _, deal := range b.stops {
+		if !triggered(deal, price) {
+			stops = append(stops, deal)
+			continue
+		}
+
+		if deal.Type == exchange.Stop {
+			deal.Type = exchange.Market
+		} else {
+			deal.Type = exchange.Limit
+		}
+
+		b.side(deal).add(deal)
+		o.index[deal.ID] = deal
+		res = append(res, deal)
+	}
+	b.stops = stops
+
+	return res
+}
+
+// Get returns deals which can be completed by a ticker price.
+// Purchases come first, then sales, each of them in price-time priority.
+func (o *orderBook) Get(ticker string, price decimal.Decimal) []exchange.Deal {
+	var res []exchange.Deal
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	b, ok := o.books[ticker]
+	if !ok {
+		return nil
+	}
+
+	res = b.bids.crossed(res, price)
+	res = b.asks.crossed(res, price)
+
+	return res
+}
+
+// Match returns resting deals of the opposite side which are crossed by a deal limit price.
+func (o *orderBook) Match(deal exchange.Deal) []exchange.Deal {
+	var res []exchange.Deal
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	b, ok := o.books[deal.Ticker]
+	if !ok {
+		return nil
+	}
+
+	if deal.Side == exchange.Sell {
+		return b.bids.crossed(res, limit(deal))
+	}
+
+	return b.asks.crossed(res, limit(deal))
+}
+
+// Update replaces a resting deal keeping its priority.
+func (o *orderBook) Update(deal exchange.Deal) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	old, ok := o.index[deal.ID]
+	if !ok || old.Side != deal.Side || old.Type != deal.Type || limit(old) != limit(deal) {
+		return false
+	}
+
+	b := o.books[deal.Ticker]
+
+	var replaced bool
+	if old.Type == exchange.Stop || old.Type == exchange.StopLimit {
+		replaced = b.replaceStop(deal)
+	} else {
+		replaced = b.side(deal).replace(deal)
+	}
+
+	if replaced {
+		o.index[deal.ID] = deal
+	}
+
+	return replaced
+}
+
+// Find returns deal of the book.
+func (o *orderBook) Find(dealID int64) (exchange.Deal, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	deal, ok := o.index[dealID]
+
+	return deal, ok
+}
+
+// List returns all deals of the book.
+func (o *orderBook) List() []exchange.Deal {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	res := make([]exchange.Deal, 0, len(o.index))
+	for _, deal := range o.index {
+		res = append(res, deal)
+	}
+
+	return res
+}
+
+// Delete removes deal from the book and returns it.
+func (o *orderBook) Delete(dealID int64) (exchange.Deal, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	deal, ok := o.index[dealID]
+	if !ok {
+		return exchange.Deal{}, false
+	}
+
+	delete(o.index, dealID)
+
+	b := o.books[deal.Ticker]
+	if deal.Type == exchange.Stop || deal.Type == exchange.StopLimit {
+		b.removeStop(dealID)
+	} else {
+		b.side(deal).remove(deal)
+	}
+
+	return deal, true
+}
+
+// Depth returns top price levels of both sides of a ticker book. Market deals are not shown.
+func (o *orderBook) Depth(ticker string, levels int) ([]exchange.Level, []exchange.Level) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	b, ok := o.books[ticker]
+	if !ok {
+		return nil, nil
+	}
+
+	return b.bids.depth(levels), b.asks.depth(levels)
+}
+
+// Creates empty book.
+func newBook() *book {
+	return &book{
+		bids: side{better: func(a, b decimal.Decimal) bool { return a > b }},
+		asks: side{better: func(a, b decimal.Decimal) bool { return a < b }},
+	}
+}
+
+// Returns side of the book for a deal.
+func (b *book) side(deal exchange.Deal) *side {
+	if deal.Side == exchange.Sell {
+		return &b.asks
+	}
+
+	return &b.bids
+}
+
+// Replaces waiting stop deal.
+func (b *book) replaceStop(deal exchange.Deal) bool {
+	for i := range b.stops {
+		if b.stops[i].ID == deal.ID {
+			b.stops[i] = deal
+			return true
+		}
+	}
+
+	return false
+}
+
+// Removes waiting stop deal.
+func (b *book) removeStop(dealID int64) {
+	for i := range b.stops {
+		if b.stops[i].ID == dealID {
+			b.stops = append(b.stops[:i], b.stops[i+1:]...)
+			return
+		}
+	}
+}
+
+// Returns limit price of a deal. Market deals cross any price of the opposite side.
+func limit(deal exchange.Deal) decimal.Decimal {
+	if deal.Type != exchange.Market {
+		return deal.Price
+	}
+
+	if deal.Side == exchange.Sell {
+		return 0
+	}
+
+	return maxPrice
+}
+
+// Checks if a ticker price reaches stop price of a deal.
+func triggered(deal exchange.Deal, price decimal.Decimal) bool {
+	if deal.Side == exchange.Sell {
+		return price <= deal.StopPrice
+	}
+
+	return price >= deal.StopPrice
+}
+
+// Returns index of the first level which is not better than a price.
+func (s *side) search(price decimal.Decimal) int {
+	return sort.Search(len(s.levels), func(i int) bool {
+		return !s.better(s.levels[i].price, price)
+	})
+}
+
+// Adds deal to the end of its price level.
+func (s *side) add(deal exchange.Deal) {
+	price := limit(deal)
+	idx := s.search(price)
+
+	if idx < len(s.levels) && s.levels[idx].price == price {
+		s.levels[idx].deals = append(s.levels[idx].deals, deal)
+		return
+	}
+
+	s.levels = append(s.levels, nil)
+	copy(s.levels[idx+1:], s.levels[idx:])
+	s.levels[idx] = &level{price: price, deals: []exchange.Deal{deal}}
+}
+
+// Removes deal from its price level.
+func (s *side) remove(deal exchange.Deal) {
+	price := limit(deal)
+	idx := s.search(price)
+
+	if idx == len(s.levels) || s.levels[idx].price != price {
+		return
+	}
+
+	lvl := s.levels[idx]
+	for i := range lvl.deals {
+		if lvl.deals[i].ID == deal.ID {
+			lvl.deals = append(lvl.deals[:i], lvl.deals[i+1:]...)
+			break
+		}
+	}
+
+	if len(lvl.deals) == 0 {
+		s.levels = append(s.levels[:idx], s.levels[idx+1:]...)
+	}
+}
+
+// Replaces deal in its price level.
+func (s *side) replace(deal exchange.Deal) bool {
+	price := limit(deal)
+	idx := s.search(price)
+
+	if idx == len(s.levels) || s.levels[idx].price != price {
+		return false
+	}
+
+	lvl := s.levels[idx]
+	for i := range lvl.deals {
+		if lvl.deals[i].ID == deal.ID {
+			lvl.deals[i] = deal
+			return true
+		}
+	}
+
+	return false
+}
+
+// Appends deals which are crossed by a ticker price.
+func (s *side) crossed(res []exchange.Deal, price decimal.Decimal) []exchange.Deal {
+	for _, lvl := range s.levels {
+		if s.better(price, lvl.price) {
+			break
+		}
+
+		res = append(res, lvl.deals...)
+	}
+
+	return res
+}
+
+// Returns top aggregated price levels.
+func (s *side) depth(levels int) []exchange.Level {
+	res := make([]exchange.Level, 0, levels)
+
+	for _, lvl := range s.levels {
+		if len(res) == levels {
+			break
+		}
+
+		if lvl.price == maxPrice || lvl.price == 0 {
+			continue
+		}
+
+		item := exchange.Level{Price: lvl.price, Orders: int32(len(lvl.deals))}
+		for _, deal := range lvl.deals {
+			item.Amount += deal.Amount
+		}
+
+		res = append(res, item)
+	}
+
+	return res
+}
@@ -0,0 +1,194 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/repository/memory"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+var start = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func limitDeal(id int64, side exchange.Side, price int64) exchange.Deal {
+	return exchange.Deal{
+		ID:          id,
+		BrokerID:    1,
+		Ticker:      "A",
+		Side:        side,
+		Type:        exchange.Limit,
+		TimeInForce: exchange.GTC,
+		Amount:      10,
+		Price:       decimal.New(price),
+		Time:        start.Add(time.Duration(id)),
+	}
+}
+
+func ids(deals []exchange.Deal) []int64 {
+	res := make([]int64, len(deals))
+	for i := range deals {
+		res[i] = deals[i].ID
+	}
+
+	return res
+}
+
+func equal(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestGetKeepsPriceTimePriority(t *testing.T) {
+	book := memory.NewOrderBook()
+	book.Add(limitDeal(1, exchange.Buy, 10))
+	book.Add(limitDeal(2, exchange.Buy, 11))
+	book.Add(limitDeal(3, exchange.Buy, 11))
+	book.Add(limitDeal(4, exchange.Sell, 12))
+	book.Add(limitDeal(5, exchange.Sell, 13))
+
+	if got := ids(book.Get("A", decimal.New(10))); !equal(got, []int64{2, 3, 1}) {
+		t.Errorf("deals %v at 10, want [2 3 1]", got)
+	}
+
+	if got := ids(book.Get("A", decimal.New(13))); !equal(got, []int64{4, 5}) {
+		t.Errorf("deals %v at 13, want [4 5]", got)
+	}
+}
+
+func TestMatchCrossesOppositeSide(t *testing.T) {
+	book := memory.NewOrderBook()
+	book.Add(limitDeal(1, exchange.Sell, 12))
+	book.Add(limitDeal(2, exchange.Sell, 11))
+	book.Add(limitDeal(3, exchange.Sell, 13))
+	book.Add(limitDeal(4, exchange.Buy, 10))
+
+	if got := ids(book.Match(limitDeal(5, exchange.Buy, 12))); !equal(got, []int64{2, 1}) {
+		t.Errorf("matched %v, want [2 1]", got)
+	}
+}
+
+func TestUpdateKeepsPriority(t *testing.T) {
+	book := memory.NewOrderBook()
+	book.Add(limitDeal(1, exchange.Buy, 10))
+	book.Add(limitDeal(2, exchange.Buy, 10))
+
+	deal := limitDeal(1, exchange.Buy, 10)
+	deal.Amount = 4
+
+	if !book.Update(deal) {
+		t.Fatal("deal is not updated")
+	}
+
+	if found, _ := book.Find(1); found.Amount != 4 {
+		t.Errorf("found amount %d, want 4", found.Amount)
+	}
+
+	if got := book.Get("A", decimal.New(10)); got[0].ID != 1 || got[0].Amount != 4 {
+		t.Errorf("first deal %d of %d, want 1 of 4", got[0].ID, got[0].Amount)
+	}
+
+	repriced := limitDeal(2, exchange.Buy, 11)
+	if book.Update(repriced) {
+		t.Error("deal is repriced by update")
+	}
+
+	if found, _ := book.Find(2); found.Price != decimal.New(10) {
+		t.Errorf("index keeps price %s, want 10", found.Price)
+	}
+}
+
+func TestDeleteRemovesDeal(t *testing.T) {
+	book := memory.NewOrderBook()
+	book.Add(limitDeal(1, exchange.Buy, 10))
+
+	if _, ok := book.Delete(1); !ok {
+		t.Fatal("deal is not deleted")
+	}
+
+	if _, ok := book.Find(1); ok || len(book.Get("A", decimal.New(10))) > 0 {
+		t.Error("deleted deal stays in the book")
+	}
+}
+
+// Fills the book with resting deals on both sides around 1000, bids below and asks above.
+func fill(book services.DealQueue, n int) {
+	for i := 1; i <= n; i++ {
+		if i%2 == 0 {
+			book.Add(limitDeal(int64(i), exchange.Buy, int64(900+i%100)))
+		} else {
+			book.Add(limitDeal(int64(i), exchange.Sell, int64(1001+i%100)))
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	book := memory.NewOrderBook()
+	fill(book, 100000)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		book.Get("A", decimal.New(int64(995+i%10)))
+	}
+}
+
+func BenchmarkProcess(b *testing.B) {
+	book := memory.NewOrderBook()
+	fill(book, 100000)
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "bench", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}}
+	service := services.NewExchangeService(logger, clock.NewVirtual(start), book, nil, nil, cfg)
+
+	// The tick crosses no deal, so it times the idle path of a tick over the same book.
+	tick := exchange.Tick{Ticker: "A", Price: decimal.New(1000), Vol: 100}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		service.Process(tick)
+	}
+}
+
+func BenchmarkProcessCrossing(b *testing.B) {
+	book := memory.NewOrderBook()
+	fill(book, 100000)
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "bench", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}}
+	service := services.NewExchangeService(logger, clock.NewVirtual(start), book, nil, nil, cfg)
+
+	// The tick crosses five levels of asks, about 2500 deals, which are put back after every run.
+	tick := exchange.Tick{Ticker: "A", Price: decimal.New(1010), Vol: 100}
+	crossed := book.Get("A", tick.Price)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if fills := service.Process(tick); len(fills) != len(crossed) {
+			b.Fatalf("%d fills, want %d", len(fills), len(crossed))
+		}
+
+		b.StopTimer()
+		for _, deal := range crossed {
+			book.Add(deal)
+		}
+		b.StartTimer()
+	}
+}
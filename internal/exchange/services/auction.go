@@ -0,0 +1,242 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Volumes of an auction at a price.
+type auctionVolume struct {
+	price decimal.Decimal
+	buy   int32
+	sell  int32
+}
+
+// Returns volume which is executed at the price.
+func (v auctionVolume) executed() int32 {
+	return min32(v.buy, v.sell)
+}
+
+// Returns volume which is left unmatched at the price.
+func (v auctionVolume) imbalance() int32 {
+	if v.buy > v.sell {
+		return v.buy - v.sell
+	}
+
+	return v.sell - v.buy
+}
+
+// Runs auction of ticker. Deals collected in the book are uncrossed at a single clearing price,
+// which executes the most volume. The rest which is marketable at that price is filled by liquidity
+// of the last tick at the same price. Returns fills and trades of the auction.
+// Stop and fill-or-kill deals take no part in the uncross. Caller holds book lock.
+func (e *exchangeService) uncross(ticker string) ([]exchange.Deal, []exchange.Trade) {
+	tick, ticked := e.lastTick[ticker]
+
+	buys, sells := e.auctionDeals(ticker)
+
+	price, ok := e.clearingPrice(buys, sells, tick.Price, ticked)
+	if !ok {
+		return nil, nil
+	}
+
+	vol := auctionVolumeAt(buys, sells, price)
+
+	var (
+		fills  []exchange.Deal
+		trades []exchange.Trade
+	)
+
+	if executed := vol.executed(); executed > 0 {
+		// Identifiers of every fill and the trade are taken before the book is changed.
+		ids, err := e.nextIDs(len(buys) + len(sells) + 1)
+		if err != nil {
+			e.logger.Error(sessionAction, err)
+			return nil, nil
+		}
+
+		fills = e.allocate(fills, buys, executed, price, ids)
+		fills = e.allocate(fills, sells, executed, price, ids[len(buys):])
+
+		// The auction has no aggressor, so its trade is marked by the side with more volume at the clearing price.
+		side := exchange.Sell
+		if vol.buy >= vol.sell {
+			side = exchange.Buy
+		}
+
+		trades = append(trades, exchange.Trade{
+			ID:     ids[len(ids)-1],
+			Ticker: ticker,
+			Price:  price,
+			Amount: executed,
+			Side:   side,
+			Time:   e.clock.Now(),
+		})
+	}
+
+	if !ticked {
+		return fills, trades
+	}
+
+	budget := e.budget(tick)
+
+	for _, deal := range e.dealQueue.Get(ticker, price) {
+		amount := e.available(deal, budget)
+		if amount <= 0 || (deal.TimeInForce == exchange.FOK && amount < deal.Amount) {
+			continue
+		}
+
+		fill, err := e.complete(deal, amount, price, &budget)
+		if err != nil {
+			e.logger.Error(sessionAction, err)
+			break
+		}
+
+		fills = append(fills, fill)
+
+		if trade, err := e.tickTrade(fill); err != nil {
+			e.logger.Error(sessionAction, err)
+		} else {
+			trades = append(trades, trade)
+		}
+	}
+
+	return fills, trades
+}
+
+// Returns deals of ticker which take part in the uncross in priority order:
+// market deals first, then by price and by arrival.
+func (e *exchangeService) auctionDeals(ticker string) (buys, sells []exchange.Deal) {
+	for _, deal := range e.dealQueue.List() {
+		if deal.Ticker != ticker || deal.TimeInForce == exchange.FOK {
+			continue
+		}
+
+		switch {
+		case deal.Type != exchange.Market && deal.Type != exchange.Limit:
+		case deal.Side == exchange.Buy:
+			buys = append(buys, deal)
+		default:
+			sells = append(sells, deal)
+		}
+	}
+
+	sortAuction(buys, func(a, b decimal.Decimal) bool { return a > b })
+	sortAuction(sells, func(a, b decimal.Decimal) bool { return a < b })
+
+	return buys, sells
+}
+
+// Sorts deals of one side by priority. Better is the price order of the side.
+func sortAuction(deals []exchange.Deal, better func(a, b decimal.Decimal) bool) {
+	sort.Slice(deals, func(i, j int) bool {
+		a, b := deals[i], deals[j]
+		if (a.Type == exchange.Market) != (b.Type == exchange.Market) {
+			return a.Type == exchange.Market
+		}
+
+		if a.Price != b.Price {
+			return better(a.Price, b.Price)
+		}
+
+		return a.ID < b.ID
+	})
+}
+
+// Returns clearing price of the auction. Candidates are limit prices of deals and the last tick price.
+// The price executes the most volume, then leaves the least imbalance, then is the nearest to the last tick.
+func (e *exchangeService) clearingPrice(buys, sells []exchange.Deal, ref decimal.Decimal, ticked bool) (decimal.Decimal, bool) {
+	var candidates []decimal.Decimal
+
+	if ticked {
+		candidates = append(candidates, ref)
+	}
+
+	for _, side := range [][]exchange.Deal{buys, sells} {
+		for _, deal := range side {
+			if deal.Type == exchange.Limit {
+				candidates = append(candidates, deal.Price)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	best := auctionVolumeAt(buys, sells, candidates[0])
+
+	for _, price := range candidates[1:] {
+		vol := auctionVolumeAt(buys, sells, price)
+
+		switch {
+		case vol.executed() != best.executed():
+			if vol.executed() > best.executed() {
+				best = vol
+			}
+		case vol.imbalance() != best.imbalance():
+			if vol.imbalance() < best.imbalance() {
+				best = vol
+			}
+		case ticked && distance(vol.price, ref) != distance(best.price, ref):
+			if distance(vol.price, ref) < distance(best.price, ref) {
+				best = vol
+			}
+		case vol.price < best.price:
+			best = vol
+		}
+	}
+
+	return best.price, true
+}
+
+// Returns volumes of deals which accept the price.
+func auctionVolumeAt(buys, sells []exchange.Deal, price decimal.Decimal) auctionVolume {
+	vol := auctionVolume{price: price}
+
+	for _, deal := range buys {
+		if deal.Type == exchange.Market || deal.Price >= price {
+			vol.buy += deal.Amount
+		}
+	}
+
+	for _, deal := range sells {
+		if deal.Type == exchange.Market || deal.Price <= price {
+			vol.sell += deal.Amount
+		}
+	}
+
+	return vol
+}
+
+// Fills deals of one side in priority order until the volume is executed. Fills take identifiers in order.
+func (e *exchangeService) allocate(
+	fills, deals []exchange.Deal, volume int32, price decimal.Decimal, ids []int64,
+) []exchange.Deal {
+	for i, deal := range deals {
+		if volume == 0 {
+			break
+		}
+
+		amount := min32(deal.Amount, volume)
+		volume -= amount
+		deal.Amount -= amount
+
+		e.settle(deal)
+
+		fills = append(fills, e.fill(deal, amount, price, ids[i]))
+	}
+
+	return fills
+}
+
+// Returns distance between prices.
+func distance(a, b decimal.Decimal) decimal.Decimal {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/repository/memory"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+// Creates exchange of ticker A in its closing auction with a subscriber of trade tape.
+func newAuction(t *testing.T) (exchange.ExchangeService, chan exchange.Trade) {
+	t.Helper()
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	clk := clock.NewVirtual(time.Date(2021, 3, 1, 17, 30, 0, 0, time.UTC))
+	cfg := config.Exchange{
+		Tickers: []string{"A"},
+		Session: config.Session{Open: 10 * time.Hour, Auction: 17 * time.Hour, Close: 18 * time.Hour},
+	}
+
+	e := services.NewExchangeService(logger, clk, memory.NewOrderBook(), nil, nil, cfg)
+
+	trades := make(chan exchange.Trade, 10)
+	e.Trades(exchange.Broker{ID: 1}, trades)
+
+	return e, trades
+}
+
+func auctionDeal(t *testing.T, e exchange.ExchangeService, side exchange.Side, amount int32, price string) exchange.Deal {
+	t.Helper()
+
+	deal, err := e.Create(exchange.Deal{
+		BrokerID:    1,
+		Ticker:      "A",
+		Side:        side,
+		Type:        exchange.Limit,
+		TimeInForce: exchange.GTC,
+		Amount:      amount,
+		Price:       decimal.MustParse(price),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return deal
+}
+
+func TestAuctionUncrossesAtSinglePrice(t *testing.T) {
+	e, trades := newAuction(t)
+
+	auctionDeal(t, e, exchange.Buy, 10, "101")
+	rest := auctionDeal(t, e, exchange.Buy, 5, "99")
+	auctionDeal(t, e, exchange.Sell, 4, "98")
+	partial := auctionDeal(t, e, exchange.Sell, 8, "100")
+
+	services.CloseSession(e)
+
+	trade := <-trades
+	if trade.Price != decimal.MustParse("100") || trade.Amount != 10 || trade.Side != exchange.Sell {
+		t.Fatalf("got trade %d at %s by %s, want 10 at 100 by SELL", trade.Amount, trade.Price, trade.Side)
+	}
+
+	select {
+	case trade := <-trades:
+		t.Fatalf("unexpected trade %d at %s", trade.Amount, trade.Price)
+	default:
+	}
+
+	open := make(map[int64]int32)
+	for _, deal := range e.ListOpen(1) {
+		open[deal.ID] = deal.Amount
+	}
+
+	if len(open) != 2 || open[rest.ID] != 5 || open[partial.ID] != 2 {
+		t.Errorf("open deals %v, want %d: 5 and %d: 2", open, rest.ID, partial.ID)
+	}
+}
+
+func TestAuctionFillsRestByLastTick(t *testing.T) {
+	e, trades := newAuction(t)
+	e.Process(exchange.Tick{Ticker: "A", Price: decimal.MustParse("100"), Vol: 100})
+
+	auctionDeal(t, e, exchange.Buy, 5, "101")
+	auctionDeal(t, e, exchange.Buy, 5, "99")
+
+	services.CloseSession(e)
+
+	trade := <-trades
+	if trade.Price != decimal.MustParse("100") || trade.Amount != 5 {
+		t.Fatalf("got trade %d at %s, want 5 at 100", trade.Amount, trade.Price)
+	}
+
+	if open := e.ListOpen(1); len(open) != 1 || open[0].Price != decimal.MustParse("99") {
+		t.Errorf("open deals %v, want the buy at 99", open)
+	}
+}
+
+func TestForeignDealIsNotChanged(t *testing.T) {
+	e, _ := newAuction(t)
+	deal := auctionDeal(t, e, exchange.Buy, 5, "99")
+
+	if ok, err := e.Cancel(2, deal.ID); ok || !errors.Is(err, exchange.ErrForeignDeal) {
+		t.Errorf("cancel by another broker: %t, %v", ok, err)
+	}
+
+	if ok, err := e.Modify(2, deal.ID, decimal.New(98), 0); ok || !errors.Is(err, exchange.ErrForeignDeal) {
+		t.Errorf("modification by another broker: %t, %v", ok, err)
+	}
+
+	if open := e.ListOpen(1); len(open) != 1 || open[0].Price != decimal.New(99) {
+		t.Errorf("open deals %v, want the buy at 99", open)
+	}
+}
+
+func TestModifyOfHaltedTickerIsRejected(t *testing.T) {
+	e, _ := newAuction(t)
+	deal := auctionDeal(t, e, exchange.Buy, 5, "99")
+
+	if _, err := e.Halt("A", "news"); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := e.Modify(1, deal.ID, decimal.New(98), 0); ok || !errors.Is(err, exchange.ErrNotAccepted) {
+		t.Errorf("modification of halted ticker: %t, %v", ok, err)
+	}
+}
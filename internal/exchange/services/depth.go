@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Default market depth settings.
+const (
+	defaultDepthLevels   = 10
+	defaultDepthInterval = time.Second
+	defaultDepthSnapshot = 30 * time.Second
+)
+
+// Observer of market depth. Last sent levels are used to build incremental updates.
+type depthObserver struct {
+	ticker   string
+	levels   int
+	sub      *subscriber
+	bids     []exchange.Level
+	asks     []exchange.Level
+	snapshot time.Time
+}
+
+// Fills defaults of market depth config.
+func depthConfig(cfg config.Depth) config.Depth {
+	if cfg.Levels <= 0 {
+		cfg.Levels = defaultDepthLevels
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultDepthInterval
+	}
+
+	if cfg.Snapshot <= 0 {
+		cfg.Snapshot = defaultDepthSnapshot
+	}
+
+	return cfg
+}
+
+// Depth adds observer for market depth of a ticker. Zero levels means default number of levels.
+func (e *exchangeService) Depth(broker exchange.Broker, ticker string, levels int, ch chan exchange.Depth) {
+	if levels <= 0 || levels > e.depth.Levels {
+		levels = e.depth.Levels
+	}
+
+	e.mu.Lock()
+	e.depthObs[broker] = &depthObserver{ticker: ticker, levels: levels, sub: e.subscriber(broker, depthStream, depthQueue(ch))}
+	e.mu.Unlock()
+}
+
+// DepthUnsubscribe removes observer for market depth.
+func (e *exchangeService) DepthUnsubscribe(broker exchange.Broker) {
+	e.mu.Lock()
+	obs, ok := e.depthObs[broker]
+	delete(e.depthObs, broker)
+	e.mu.Unlock()
+
+	if ok {
+		obs.sub.unsubscribe()
+	}
+}
+
+// Sends market depth to subscribers.
+// The first message and every message after snapshot interval are snapshots, others are updates.
+func (e *exchangeService) sendDepth(ctx context.Context) {
+	t := e.clock.NewTicker(e.depth.Interval)
+	defer t.Stop()
+
+	e.logger.Info(depthAction, "started")
+	defer e.logger.Info(depthAction, "stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C():
+			var observers []*depthObserver
+
+			e.mu.RLock()
+			for _, obs := range e.depthObs {
+				observers = append(observers, obs)
+			}
+			e.mu.RUnlock()
+
+			for _, obs := range observers {
+				depth, ok := e.nextDepth(obs)
+				if ok && !e.deliver(obs.sub, depth) {
+					// Updates are incremental, so after any loss the next message is a snapshot.
+					obs.snapshot = time.Time{}
+				}
+			}
+		}
+	}
+}
+
+// Returns next message for observer. Nothing is sent when the book is not changed.
+func (e *exchangeService) nextDepth(obs *depthObserver) (exchange.Depth, bool) {
+	now := e.clock.Now()
+	bids, asks := e.dealQueue.Depth(obs.ticker, obs.levels)
+
+	depth := exchange.Depth{Ticker: obs.ticker, Time: now}
+
+	if obs.snapshot.IsZero() || now.Sub(obs.snapshot) >= e.depth.Snapshot {
+		depth.Snapshot = true
+		depth.Bids, depth.Asks = bids, asks
+		obs.snapshot = now
+	} else {
+		depth.Bids, depth.Asks = diffLevels(obs.bids, bids), diffLevels(obs.asks, asks)
+	}
+
+	obs.bids, obs.asks = bids, asks
+
+	return depth, depth.Snapshot || len(depth.Bids) > 0 || len(depth.Asks) > 0
+}
+
+// Returns changed and removed levels.
+func diffLevels(prev, cur []exchange.Level) []exchange.Level {
+	var res []exchange.Level
+
+	old := make(map[decimal.Decimal]exchange.Level, len(prev))
+	for _, lvl := range prev {
+		old[lvl.Price] = lvl
+	}
+
+	for _, lvl := range cur {
+		if old[lvl.Price] != lvl {
+			res = append(res, lvl)
+		}
+
+		delete(old, lvl.Price)
+	}
+
+	for _, lvl := range prev {
+		if _, ok := old[lvl.Price]; ok {
+			res = append(res, exchange.Level{Price: lvl.Price})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,120 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/repository/memory"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+func levels(lvls []exchange.Level) string {
+	var s string
+	for _, lvl := range lvls {
+		s += fmt.Sprintf("%s:%d ", lvl.Price, lvl.Amount)
+	}
+
+	return s
+}
+
+func TestDepthSendsSnapshotsAndChanges(t *testing.T) {
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	clk := clock.NewVirtual(start)
+	cfg := config.Exchange{Tickers: []string{"A"}, Depth: config.Depth{Interval: time.Second, Snapshot: 10 * time.Second}}
+	service := services.NewExchangeService(logger, clk, memory.NewOrderBook(), nil, nil, cfg)
+
+	var rest exchange.Deal
+
+	for i, deal := range []exchange.Deal{
+		limit(exchange.Buy, 5, "99"),
+		limit(exchange.Buy, 3, "98"),
+		limit(exchange.Sell, 4, "101"),
+	} {
+		created, err := service.Create(deal)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 1 {
+			rest = created
+		}
+	}
+
+	ch := make(chan exchange.Depth, 10)
+	service.Depth(exchange.Broker{ID: 1}, "A", 0, ch)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		services.SendDepth(ctx, service)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	now := start
+
+	// Moves clock by interval of depth and returns the message sent at it.
+	next := func() (exchange.Depth, bool) {
+		now = now.Add(time.Second)
+		clk.Set(now)
+
+		select {
+		case depth := <-ch:
+			return depth, true
+		case <-time.After(50 * time.Millisecond):
+			return exchange.Depth{}, false
+		}
+	}
+
+	// Sender sets its ticker concurrently, so the clock is moved until the first message.
+	depth, ok := next()
+	for i := 0; !ok && i < 5; i++ {
+		depth, ok = next()
+	}
+
+	if !ok || !depth.Snapshot || levels(depth.Bids) != "99:5 98:3 " || levels(depth.Asks) != "101:4 " {
+		t.Fatalf("got %v bids %s asks %s, want snapshot of bids 99:5 98:3 and asks 101:4",
+			depth.Snapshot, levels(depth.Bids), levels(depth.Asks))
+	}
+
+	if _, ok := next(); ok {
+		t.Fatal("depth is sent without changes")
+	}
+
+	if ok, err := service.Cancel(1, rest.ID); !ok || err != nil {
+		t.Fatalf("deal is not canceled: %v", err)
+	}
+
+	if _, err := service.Create(limit(exchange.Buy, 2, "99")); err != nil {
+		t.Fatal(err)
+	}
+
+	depth, ok = next()
+	if !ok || depth.Snapshot || levels(depth.Bids) != "99:7 98:0 " || len(depth.Asks) != 0 {
+		t.Fatalf("got %v bids %s asks %s, want update of bids 99:7 98:0",
+			depth.Snapshot, levels(depth.Bids), levels(depth.Asks))
+	}
+
+	for i := 0; i < 10; i++ {
+		if depth, ok = next(); ok {
+			break
+		}
+	}
+
+	if !ok || !depth.Snapshot || levels(depth.Bids) != "99:7 " {
+		t.Fatalf("got %v bids %s, want snapshot of bids 99:7 after snapshot interval", depth.Snapshot, levels(depth.Bids))
+	}
+}
@@ -3,68 +3,157 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
 	"github.com/marksartdev/trading/internal/exchange"
 	"github.com/marksartdev/trading/internal/log"
 )
 
-const baseAmount = 1000
+// Continuous matching mode, in which deals are also matched against each other.
+const matchingContinuous = "continuous"
+
+// Number of identifiers reserved in the journal at once.
+const idBlock = 10000
 
 const (
 	mainAction       log.Action = "main"
 	retransmitAction log.Action = "retransmit"
 	statAction       log.Action = "statistic"
 	dealsAction      log.Action = "deals"
+	matchAction      log.Action = "matching"
+	sessionAction    log.Action = "session"
+	depthAction      log.Action = "depth"
+	journalAction    log.Action = "journal"
+	resultsAction    log.Action = "results"
+	fanoutAction     log.Action = "fanout"
 )
 
-// DealQueue queue of deals.
+// DealQueue queue of deals ordered by price-time priority.
 type DealQueue interface {
 	Add(deal exchange.Deal)
-	Get(ticker string, price float64) []exchange.Deal
-	Delete(dealID int64) bool
+	Get(ticker string, price decimal.Decimal) []exchange.Deal
+	Trigger(ticker string, price decimal.Decimal) []exchange.Deal
+	Match(deal exchange.Deal) []exchange.Deal
+	Update(deal exchange.Deal) bool
+	Find(dealID int64) (exchange.Deal, bool)
+	List() []exchange.Deal
+	Delete(dealID int64) (exchange.Deal, bool)
+	Depth(ticker string, levels int) (bids, asks []exchange.Level)
+}
+
+// Journal durable journal of book events. Reserve durably stores the highest identifier which may be issued.
+type Journal interface {
+	Append(event exchange.Event) error
+	Snapshot(snapshot exchange.Snapshot) error
+	Reserve(lastID int64) error
+	Load() (exchange.Snapshot, []exchange.Event, error)
 }
 
 // Service for exchanging.
 type exchangeService struct {
 	mu          *sync.RWMutex
+	bookMu      *sync.Mutex
 	logger      log.Logger
+	clock       clock.Clock
 	dealQueue   DealQueue
 	tickService exchange.TickService
+	journal     Journal
+	seq         int64
+	snapSeq     int64
+	snapEvery   int64
 	tickers     []string
 	interval    time.Duration
+	intervals   []time.Duration
+	continuous  bool
+	incoming    chan exchange.Deal
+	pending     map[int64]exchange.Deal
+	immediate   map[string][]int64
+	session     config.Session
+	breaker     config.Breaker
+	halts       map[string]exchange.TradingStatus
+	moves       map[string][]pricePoint
+	lastTick    map[string]exchange.Tick
 	tickerAmt   map[string]int32
-	statObs     map[exchange.Broker]chan exchange.OHLCV
-	dealsObs    map[exchange.Broker]chan exchange.Deal
+	liquidity   map[string]config.TickerLiquidity
+	tickSize    map[string]decimal.Decimal
+	lastID      int64
+	idCeil      int64
+	depth       config.Depth
+	statObs     map[exchange.Broker]statObserver
+	depthObs    map[exchange.Broker]*depthObserver
+	tradesObs   map[exchange.Broker]*subscriber
+	dealsObs    map[exchange.Broker]*resultObserver
+	fanout      config.Fanout
+	resultMu    *sync.Mutex
+	resultSeq   map[int64]int64
+	resultBuf   map[int64][]exchange.Deal
+	resultSent  map[int64]int64
+	resultCap   int
 	cancel      context.CancelFunc
+	done        chan struct{}
 }
 
-// NewExchangeService creates new exchange service.
+// NewExchangeService creates new exchange service. Journal is optional.
 func NewExchangeService(
 	logger log.Logger,
+	clk clock.Clock,
 	dealQueue DealQueue,
 	tickService exchange.TickService,
-	tickers []string,
-	interval time.Duration,
+	journal Journal,
+	cfg config.Exchange,
 ) exchange.ExchangeService {
 	tickerAmn := make(map[string]int32)
-	for _, ticker := range tickers {
-		tickerAmn[ticker] = baseAmount
+	liquidity := make(map[string]config.TickerLiquidity)
+	tickSize := make(map[string]decimal.Decimal)
+	for _, ticker := range cfg.Tickers {
+		liquidity[ticker] = tickerLiquidity(cfg.Liquidity, ticker)
+		tickerAmn[ticker] = liquidity[ticker].Float
+		tickSize[ticker] = tickerTickSize(cfg.TickSize, ticker)
 	}
 
 	return &exchangeService{
 		mu:          &sync.RWMutex{},
+		bookMu:      &sync.Mutex{},
 		logger:      logger,
+		clock:       clk,
 		dealQueue:   dealQueue,
 		tickService: tickService,
-		tickers:     tickers,
-		interval:    interval,
+		journal:     journal,
+		snapEvery:   cfg.Journal.SnapshotEvery,
+		tickers:     cfg.Tickers,
+		interval:    cfg.Interval,
+		intervals:   statIntervals(cfg),
+		continuous:  cfg.Matching == matchingContinuous,
+		incoming:    make(chan exchange.Deal, 100),
+		pending:     make(map[int64]exchange.Deal),
+		immediate:   make(map[string][]int64),
+		session:     sessionConfig(cfg.Session),
+		breaker:     breakerConfig(cfg.Breaker),
+		halts:       make(map[string]exchange.TradingStatus),
+		moves:       make(map[string][]pricePoint),
+		lastTick:    make(map[string]exchange.Tick),
 		tickerAmt:   tickerAmn,
-		statObs:     make(map[exchange.Broker]chan exchange.OHLCV),
-		dealsObs:    make(map[exchange.Broker]chan exchange.Deal),
+		liquidity:   liquidity,
+		tickSize:    tickSize,
+		depth:       depthConfig(cfg.Depth),
+		statObs:     make(map[exchange.Broker]statObserver),
+		depthObs:    make(map[exchange.Broker]*depthObserver),
+		tradesObs:   make(map[exchange.Broker]*subscriber),
+		dealsObs:    make(map[exchange.Broker]*resultObserver),
+		fanout:      cfg.Fanout,
+		resultMu:    &sync.Mutex{},
+		resultSeq:   make(map[int64]int64),
+		resultBuf:   make(map[int64][]exchange.Deal),
+		resultSent:  make(map[int64]int64),
+		resultCap:   resultCapacity(cfg.ResultBuffer),
+		done:        make(chan struct{}),
 	}
 }
 
@@ -81,6 +170,12 @@ func (e *exchangeService) Start() {
 
 	g := &errgroup.Group{}
 
+	g.Go(func() error {
+		<-ctx.Done()
+		close(e.done)
+		return nil
+	})
+
 	g.Go(func() error {
 		e.retransmitTick(ctx, in, out1, out2)
 		return nil
@@ -96,6 +191,32 @@ func (e *exchangeService) Start() {
 		return nil
 	})
 
+	g.Go(func() error {
+		e.sendDepth(ctx)
+		return nil
+	})
+
+	if e.fanout.Report > 0 {
+		g.Go(func() error {
+			e.reportFanout(ctx)
+			return nil
+		})
+	}
+
+	if e.continuous {
+		g.Go(func() error {
+			e.matchDeals(ctx)
+			return nil
+		})
+	}
+
+	if e.session.Close > 0 {
+		g.Go(func() error {
+			e.runSession(ctx)
+			return nil
+		})
+	}
+
 	for _, ticker := range e.tickers {
 		ticker := ticker
 		g.Go(func() error {
@@ -110,6 +231,10 @@ func (e *exchangeService) Start() {
 		e.logger.Error(mainAction, err)
 	}
 
+	e.bookMu.Lock()
+	e.snapshot()
+	e.bookMu.Unlock()
+
 	e.logger.Info(mainAction, "stopped")
 }
 
@@ -123,45 +248,183 @@ func (e *exchangeService) Stop() {
 	e.logger.Error(mainAction, fmt.Errorf("cancel func dose not initialized"))
 }
 
-// Statistic adds observer for statistic.
-func (e *exchangeService) Statistic(broker exchange.Broker, ch chan exchange.OHLCV) {
-	e.mu.Lock()
-	e.statObs[broker] = ch
-	e.mu.Unlock()
+// Create adds a deal to queue. In continuous mode the deal is matched against resting deals first.
+// Deal is rejected when ticker is halted, session is closed, its prices are off tick size or it is not journaled.
+// Day deal is rejected without session close, which expires it.
+func (e *exchangeService) Create(deal exchange.Deal) (exchange.Deal, error) {
+	if err := e.accepting(deal.Ticker); err != nil {
+		return exchange.Deal{}, err
+	}
+
+	if err := e.checkTimeInForce(deal); err != nil {
+		return exchange.Deal{}, err
+	}
+
+	if err := e.checkPrices(deal); err != nil {
+		return exchange.Deal{}, err
+	}
+
+	id, err := e.nextID()
+	if err != nil {
+		return exchange.Deal{}, err
+	}
+
+	deal.ID = id
+	deal.Time = e.clock.Now()
+
+	e.bookMu.Lock()
+
+	if err := e.record(exchange.EventCreate, deal); err != nil {
+		e.bookMu.Unlock()
+		return exchange.Deal{}, err
+	}
+
+	if e.continuous {
+		e.pending[deal.ID] = deal
+		e.bookMu.Unlock()
+		e.enqueue(deal)
+
+		return deal, nil
+	}
+
+	e.dealQueue.Add(deal)
+	e.track(deal)
+	e.bookMu.Unlock()
+
+	return deal, nil
 }
 
-// StatisticUnsubscribe removes observer for statistic.
-func (e *exchangeService) StatisticUnsubscribe(broker exchange.Broker) {
-	e.mu.Lock()
-	delete(e.statObs, broker)
-	e.mu.Unlock()
+// Cancel removes deal of the broker from queue. Deal of another broker is rejected.
+func (e *exchangeService) Cancel(brokerID, dealID int64) (bool, error) {
+	e.bookMu.Lock()
+	defer e.bookMu.Unlock()
+
+	deal, ok := e.dealQueue.Find(dealID)
+	if !ok {
+		return false, nil
+	}
+
+	if deal.BrokerID != brokerID {
+		return false, fmt.Errorf("cancel of deal %d is rejected: %w", dealID, exchange.ErrForeignDeal)
+	}
+
+	if err := e.record(exchange.EventCancel, deal); err != nil {
+		return false, err
+	}
+
+	e.dealQueue.Delete(dealID)
+
+	return true, nil
 }
 
-// Create adds a deal to queue.
-func (e *exchangeService) Create(deal exchange.Deal) exchange.Deal {
-	deal.ID = time.Now().UnixNano()
+// Modify changes price and/or remaining amount of a queued deal, zero values are kept.
+// The deal keeps its priority on amount reduction and loses it on price change or amount increase.
+// Price which is off tick size is not accepted, neither is a deal of halted ticker or out of session.
+// Deal of another broker is rejected.
+func (e *exchangeService) Modify(brokerID, dealID int64, price decimal.Decimal, amount int32) (bool, error) {
+	e.bookMu.Lock()
+
+	deal, ok := e.dealQueue.Find(dealID)
+	if !ok {
+		e.bookMu.Unlock()
+		return false, nil
+	}
+
+	if deal.BrokerID != brokerID {
+		e.bookMu.Unlock()
+		return false, fmt.Errorf("modification of deal %d is rejected: %w", dealID, exchange.ErrForeignDeal)
+	}
+
+	if err := e.accepting(deal.Ticker); err != nil {
+		e.bookMu.Unlock()
+		return false, fmt.Errorf("modification of deal %d is rejected: %w", dealID, err)
+	}
+
+	if price == 0 || deal.Type == exchange.Market {
+		price = deal.Price
+	}
+
+	if !price.Multiple(e.tickSize[deal.Ticker]) {
+		e.bookMu.Unlock()
+		return false, nil
+	}
+
+	if amount == 0 {
+		amount = deal.Amount
+	}
+
+	if price == deal.Price && amount <= deal.Amount {
+		deal.Amount = amount
+		if err := e.record(exchange.EventUpdate, deal); err != nil {
+			e.bookMu.Unlock()
+			return false, err
+		}
+
+		ok = e.dealQueue.Update(deal)
+		e.bookMu.Unlock()
+
+		return ok, nil
+	}
+
+	if err := e.record(exchange.EventCancel, deal); err != nil {
+		e.bookMu.Unlock()
+		return false, err
+	}
+
+	e.dealQueue.Delete(dealID)
+
+	deal.Price = price
+	deal.Amount = amount
+	deal.Time = e.clock.Now()
+
+	if err := e.record(exchange.EventCreate, deal); err != nil {
+		e.bookMu.Unlock()
+		return false, fmt.Errorf("deal %d is canceled: %w", dealID, err)
+	}
+
+	if e.continuous {
+		e.pending[deal.ID] = deal
+		e.bookMu.Unlock()
+		e.enqueue(deal)
+
+		return true, nil
+	}
+
 	e.dealQueue.Add(deal)
+	e.bookMu.Unlock()
 
-	return deal
+	return true, nil
 }
 
-// Cancel removes deal from queue.
-func (e *exchangeService) Cancel(dealID int64) bool {
-	return e.dealQueue.Delete(dealID)
+// Queues pending deal for matching. Deal which is not queued before shutdown stays pending,
+// so it is kept as a resting one by the final snapshot.
+func (e *exchangeService) enqueue(deal exchange.Deal) {
+	select {
+	case e.incoming <- deal:
+	case <-e.done:
+		e.logger.Warn(mainAction, fmt.Sprintf("deal %d is not matched before shutdown", deal.ID))
+	}
 }
 
-// Results adds observer for deals.
-func (e *exchangeService) Results(broker exchange.Broker, ch chan exchange.Deal) {
-	e.mu.Lock()
-	e.dealsObs[broker] = ch
-	e.mu.Unlock()
-}
+// ListOpen returns resting deals of a broker ordered by identifier.
+func (e *exchangeService) ListOpen(brokerID int64) []exchange.Deal {
+	var res []exchange.Deal
 
-// ResultsUnsubscribe removes observer for deals.
-func (e *exchangeService) ResultsUnsubscribe(broker exchange.Broker) {
-	e.mu.Lock()
-	delete(e.dealsObs, broker)
-	e.mu.Unlock()
+	e.bookMu.Lock()
+	deals := e.dealQueue.List()
+	e.bookMu.Unlock()
+
+	for _, deal := range deals {
+		if deal.BrokerID == brokerID {
+			res = append(res, deal)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
 }
 
 // Retransmits ticks to other channels.
@@ -175,144 +438,392 @@ func (e *exchangeService) retransmitTick(ctx context.Context, in chan exchange.T
 			return
 		case tick := <-in:
 			for _, ch := range out {
-				ch <- tick
+				select {
+				case ch <- tick:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}
 }
 
-// Sends a statistic to all subscribers.
-func (e *exchangeService) sendStatistic(ctx context.Context, in chan exchange.Tick) {
-	var (
-		ohlcv      exchange.OHLCV
-		closePrice float64
-	)
-
-	t := time.NewTicker(e.interval)
-	defer t.Stop()
-
-	e.logger.Info(statAction, "started")
-	defer e.logger.Info(statAction, "stopped")
+// Completes deals.
+func (e *exchangeService) completeDeals(ctx context.Context, in chan exchange.Tick) {
+	e.logger.Info(dealsAction, "started")
+	defer e.logger.Info(dealsAction, "stopped")
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case tick := <-in:
-			if ohlcv.ID == 0 {
-				ohlcv = exchange.OHLCV{
-					ID:       time.Now().UnixNano(),
-					Time:     time.Now(),
-					Interval: e.interval,
-					Open:     tick.Price,
-					High:     tick.Price,
-					Low:      tick.Price,
-					Ticker:   tick.Ticker,
+			for _, deal := range e.Process(tick) {
+				e.notify(deal)
+
+				if deal.Status == exchange.Filled {
+					e.publishTrade(e.tickTrade(deal))
 				}
 			}
+		}
+	}
+}
 
-			if tick.Price > ohlcv.High {
-				ohlcv.High = tick.Price
-			}
+// Process completes deals by a tick and returns results without sending them to observers.
+// Deals are completed only in continuous phase of session while ticker is not halted.
+func (e *exchangeService) Process(tick exchange.Tick) []exchange.Deal {
+	e.bookMu.Lock()
+	defer e.bookMu.Unlock()
 
-			if tick.Price < ohlcv.Low {
-				ohlcv.Low = tick.Price
-			}
+	e.lastTick[tick.Ticker] = tick
 
-			ohlcv.Volume += tick.Vol
-			closePrice = tick.Price
-		case <-t.C:
-			if ohlcv.ID == 0 {
-				continue
-			}
+	if !e.filling(tick.Ticker) || e.tripBreaker(tick) {
+		return nil
+	}
 
-			ohlcv.Close = closePrice
+	return e.process(tick)
+}
 
-			var observers []chan exchange.OHLCV
+// Completes deals by a tick. Caller holds book lock.
+func (e *exchangeService) process(tick exchange.Tick) []exchange.Deal {
+	var completed []exchange.Deal
 
-			e.mu.RLock()
-			for _, ch := range e.statObs {
-				observers = append(observers, ch)
-			}
-			e.mu.RUnlock()
+	for _, deal := range e.dealQueue.Trigger(tick.Ticker, tick.Price) {
+		e.note(exchange.EventTrigger, deal)
+		e.track(deal)
+	}
 
-			for _, obs := range observers {
-				obs <- ohlcv
-			}
+	budget := e.budget(tick)
 
-			ohlcv = exchange.OHLCV{}
+	deals := e.dealQueue.Get(tick.Ticker, tick.Price)
+	for _, deal := range deals {
+		amount := e.available(deal, budget)
+		if amount <= 0 || (deal.TimeInForce == exchange.FOK && amount < deal.Amount) {
+			continue
 		}
+
+		fill, err := e.complete(deal, amount, e.impact(deal, amount, tick), &budget)
+		if err != nil {
+			e.logger.Error(dealsAction, err)
+			break
+		}
+
+		completed = append(completed, fill)
 	}
+
+	return append(completed, e.expireImmediate(tick.Ticker)...)
 }
 
-// Completes deals.
-func (e *exchangeService) completeDeals(ctx context.Context, in chan exchange.Tick) {
-	e.logger.Info(dealsAction, "started")
-	defer e.logger.Info(dealsAction, "stopped")
+// Matches incoming deals against resting deals of the opposite side.
+func (e *exchangeService) matchDeals(ctx context.Context) {
+	e.logger.Info(matchAction, "started")
+	defer e.logger.Info(matchAction, "stopped")
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case tick := <-in:
-			deals := e.dealQueue.Get(tick.Ticker, tick.Price)
-			for _, deal := range deals {
-				var completed bool
-
-				e.mu.Lock()
-				if deal.Price > 0 && e.tickerAmt[deal.Ticker] > 0 {
-					e.completePurchase(&deal, tick.Price)
-					completed = true
+		case deal := <-e.incoming:
+			var fills []exchange.Deal
+
+			e.bookMu.Lock()
+			delete(e.pending, deal.ID)
+			amount := deal.Amount
+
+			if deal.Type != exchange.Stop && deal.Type != exchange.StopLimit && e.filling(deal.Ticker) {
+				if deal.TimeInForce != exchange.FOK || e.fillable(deal) {
+					fills = e.match(&deal)
 				}
 
-				if deal.Price < 0 {
-					e.completeSale(&deal, tick.Price)
-					completed = true
+				if deal.Amount > 0 && (deal.TimeInForce == exchange.IOC || deal.TimeInForce == exchange.FOK) {
+					// Without identifier of the result the deal rests and expires after the next tick.
+					if id, err := e.nextID(); err != nil {
+						e.logger.Error(matchAction, err)
+					} else {
+						fills = append(fills, e.expired(deal, id))
+						deal.Amount = 0
+					}
 				}
-				e.mu.Unlock()
+			}
 
-				if completed {
-					var observers []chan exchange.Deal
+			// The deal is journaled as created, so the journal gets the rest of it.
+			switch {
+			case deal.Amount == 0:
+				e.note(exchange.EventCancel, deal)
+			case deal.Amount < amount:
+				e.note(exchange.EventUpdate, deal)
+			}
 
-					e.mu.RLock()
-					for broker, ch := range e.dealsObs {
-						if broker.ID == deal.BrokerID {
-							observers = append(observers, ch)
-						}
-					}
-					e.mu.RUnlock()
+			// Immediate deals which are not matched out of continuous phase wait for the next tick.
+			if deal.Amount > 0 {
+				e.dealQueue.Add(deal)
+				e.track(deal)
+			}
+			e.bookMu.Unlock()
 
-					for _, obs := range observers {
-						obs <- deal
-					}
+			for _, fill := range fills {
+				e.notify(fill)
+
+				// Every execution has one fill of the incoming deal, which is the aggressor.
+				if fill.ID == deal.ID && fill.Status == exchange.Filled {
+					e.publishTrade(e.trade(fill, fill.Side))
 				}
 			}
 		}
 	}
 }
 
-// Completes purchase.
-func (e *exchangeService) completePurchase(deal *exchange.Deal, price float64) {
-	if e.tickerAmt[deal.Ticker] < deal.Amount {
-		deal.Amount = e.tickerAmt[deal.Ticker]
-		deal.Partial = true
-		e.tickerAmt[deal.Ticker] = 0
-	} else {
-		e.tickerAmt[deal.Ticker] -= deal.Amount
+// Matches deal against resting deals at their prices and returns fills of both sides.
+// Identifiers of the fills are taken before the book is changed, without them the deal is not matched.
+func (e *exchangeService) match(deal *exchange.Deal) []exchange.Deal {
+	var fills []exchange.Deal
+
+	crossed := e.dealQueue.Match(*deal)
+
+	n := 0
+	for rest := deal.Amount; n < len(crossed) && rest > 0; n++ {
+		if crossed[n].Type != exchange.Market || deal.Type != exchange.Market {
+			rest -= crossed[n].Amount
+		}
+	}
+
+	ids, err := e.nextIDs(2 * n)
+	if err != nil {
+		e.logger.Error(matchAction, err)
+		return nil
+	}
+
+	for i, resting := range crossed[:n] {
+		if deal.Amount == 0 {
+			break
+		}
+
+		// Two market deals have no price to trade at, so they wait for a tick.
+		price := resting.Price
+		if resting.Type == exchange.Market {
+			if deal.Type == exchange.Market {
+				continue
+			}
+
+			price = deal.Price
+		}
+
+		amount := resting.Amount
+		if deal.Amount < amount {
+			amount = deal.Amount
+		}
+
+		resting.Amount -= amount
+		deal.Amount -= amount
+
+		e.settle(resting)
+
+		fills = append(fills, e.fill(resting, amount, price, ids[2*i]), e.fill(*deal, amount, price, ids[2*i+1]))
+	}
+
+	return fills
+}
+
+// Checks if resting deals are enough to complete a deal fully.
+func (e *exchangeService) fillable(deal exchange.Deal) bool {
+	var amount int32
+
+	for _, resting := range e.dealQueue.Match(deal) {
+		if resting.Type == exchange.Market && deal.Type == exchange.Market {
+			continue
+		}
+
+		amount += resting.Amount
+		if amount >= deal.Amount {
+			return true
+		}
 	}
 
+	return false
+}
+
+// Builds fill of a deal with its identifier. The rest of the deal is marked as partial.
+func (e *exchangeService) fill(deal exchange.Deal, amount int32, price decimal.Decimal, id int64) exchange.Deal {
+	deal.Status = exchange.Filled
+	deal.FillID = id
+	deal.Time = e.clock.Now()
+	deal.Partial = deal.Amount > 0
+	deal.Amount = amount
 	deal.Price = price
-	e.dealQueue.Delete(deal.ID)
+
+	return e.result(deal)
+}
+
+// Builds result of an expired deal with its identifier.
+func (e *exchangeService) expired(deal exchange.Deal, id int64) exchange.Deal {
+	deal.Status = exchange.Expired
+	deal.FillID = id
+	deal.Time = e.clock.Now()
+	deal.Partial = false
+
+	return e.result(deal)
+}
+
+// Tracks immediate deals of the book to expire them after the next tick.
+func (e *exchangeService) track(deal exchange.Deal) {
+	if deal.Type == exchange.Stop || deal.Type == exchange.StopLimit {
+		return
+	}
+
+	if deal.TimeInForce == exchange.IOC || deal.TimeInForce == exchange.FOK {
+		e.immediate[deal.Ticker] = append(e.immediate[deal.Ticker], deal.ID)
+	}
+}
+
+// Removes immediate deals of a ticker which were not completed by a tick.
+// Deals left without identifier of the result expire after the next tick.
+func (e *exchangeService) expireImmediate(ticker string) []exchange.Deal {
+	var res []exchange.Deal
+
+	immediate := e.immediate[ticker]
+	delete(e.immediate, ticker)
+
+	for i, dealID := range immediate {
+		deal, ok := e.dealQueue.Find(dealID)
+		if !ok {
+			continue
+		}
+
+		id, err := e.nextID()
+		if err != nil {
+			e.logger.Error(dealsAction, err)
+			e.immediate[ticker] = immediate[i:]
+
+			break
+		}
+
+		e.note(exchange.EventCancel, deal)
+		e.dealQueue.Delete(dealID)
+		res = append(res, e.expired(deal, id))
+	}
+
+	return res
+}
+
+// Trades adds observer for trade tape.
+func (e *exchangeService) Trades(broker exchange.Broker, ch chan exchange.Trade) {
+	e.mu.Lock()
+	e.tradesObs[broker] = e.subscriber(broker, tradesStream, tradeQueue(ch))
+	e.mu.Unlock()
+}
+
+// TradesUnsubscribe removes observer for trade tape.
+func (e *exchangeService) TradesUnsubscribe(broker exchange.Broker) {
+	e.mu.Lock()
+	sub, ok := e.tradesObs[broker]
+	delete(e.tradesObs, broker)
+	e.mu.Unlock()
+
+	if ok {
+		sub.unsubscribe()
+	}
+}
+
+// Sends a trade to all observers. Trade without identifier is not sent.
+func (e *exchangeService) publishTrade(trade exchange.Trade, err error) {
+	if err != nil {
+		e.logger.Error(dealsAction, err)
+		return
+	}
+
+	e.publish(trade)
+}
+
+// Sends a trade to all observers.
+func (e *exchangeService) publish(trade exchange.Trade) {
+	var observers []*subscriber
+
+	e.mu.RLock()
+	for _, sub := range e.tradesObs {
+		observers = append(observers, sub)
+	}
+	e.mu.RUnlock()
+
+	for _, sub := range observers {
+		e.deliver(sub, trade)
+	}
 }
 
-// Completes sale.
-func (e *exchangeService) completeSale(deal *exchange.Deal, price float64) {
-	e.tickerAmt[deal.Ticker] += deal.Amount
-	deal.Price = -price
-	e.dealQueue.Delete(deal.ID)
+// Builds trade of a fill completed by a tick.
+// Market deals take the tick price, other deals are taken by the opposite side.
+func (e *exchangeService) tickTrade(fill exchange.Deal) (exchange.Trade, error) {
+	if fill.Type == exchange.Market {
+		return e.trade(fill, fill.Side)
+	}
+
+	if fill.Side == exchange.Buy {
+		return e.trade(fill, exchange.Sell)
+	}
+
+	return e.trade(fill, exchange.Buy)
 }
 
-// Wraps message.
-func (e *exchangeService) wrapMsg(detail, msg string) string {
-	return fmt.Sprintf("Exchange service (%s): %s", detail, msg)
+// Builds trade of a fill.
+func (e *exchangeService) trade(fill exchange.Deal, aggressor exchange.Side) (exchange.Trade, error) {
+	id, err := e.nextID()
+	if err != nil {
+		return exchange.Trade{}, err
+	}
+
+	return exchange.Trade{
+		ID:     id,
+		Ticker: fill.Ticker,
+		Price:  fill.Price,
+		Amount: fill.Amount,
+		Side:   aggressor,
+		Time:   fill.Time,
+	}, nil
+}
+
+// Removes completed deal from the queue or keeps the rest of it.
+func (e *exchangeService) settle(deal exchange.Deal) {
+	e.note(exchange.EventFill, deal)
+
+	if deal.Amount == 0 {
+		e.dealQueue.Delete(deal.ID)
+		return
+	}
+
+	e.dealQueue.Update(deal)
+}
+
+// Returns next identifier of deals, fills, trades and bars.
+func (e *exchangeService) nextID() (int64, error) {
+	ids, err := e.nextIDs(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return ids[0], nil
+}
+
+// Returns next identifiers of deals, fills, trades and bars. Identifiers are reserved in the journal by blocks,
+// so a restarted exchange never issues an identifier twice. No identifier is issued above the reserved one:
+// when reservation fails, none of them is issued and the reservation is retried by the next call.
+func (e *exchangeService) nextIDs(n int) ([]int64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	last := e.lastID + int64(n)
+
+	if e.journal != nil && last > e.idCeil {
+		if err := e.journal.Reserve(last + idBlock); err != nil {
+			return nil, fmt.Errorf("identifiers are not reserved: %w", err)
+		}
+
+		e.idCeil = last + idBlock
+	}
+
+	ids := make([]int64, n)
+	for i := range ids {
+		e.lastID++
+		ids[i] = e.lastID
+	}
+
+	return ids, nil
 }
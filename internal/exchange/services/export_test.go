@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Match matches incoming deals of exchange in continuous mode until context is done.
+func Match(ctx context.Context, service exchange.ExchangeService) {
+	service.(*exchangeService).matchDeals(ctx)
+}
+
+// OpenSession opens session of exchange as its schedule does at open.
+func OpenSession(service exchange.ExchangeService) {
+	service.(*exchangeService).openSession()
+}
+
+// CloseSession closes session of exchange as its schedule does at close.
+func CloseSession(service exchange.ExchangeService) {
+	service.(*exchangeService).closeSession()
+}
+
+// Notify sends result of a deal to observers of its broker.
+func Notify(service exchange.ExchangeService, deal exchange.Deal) {
+	service.(*exchangeService).notify(deal)
+}
+
+// Shutdown signals shutdown of exchange as its stop does.
+func Shutdown(service exchange.ExchangeService) {
+	close(service.(*exchangeService).done)
+}
+
+// SendStatistic builds bars of ticks and sends them to observers until context is done.
+func SendStatistic(ctx context.Context, service exchange.ExchangeService, in chan exchange.Tick) {
+	service.(*exchangeService).sendStatistic(ctx, in)
+}
+
+// SendDepth sends market depth to observers at every interval until context is done.
+func SendDepth(ctx context.Context, service exchange.ExchangeService) {
+	service.(*exchangeService).sendDepth(ctx)
+}
+
+// CompleteDeals completes deals by ticks and sends their results and trades until context is done.
+func CompleteDeals(ctx context.Context, service exchange.ExchangeService, in chan exchange.Tick) {
+	service.(*exchangeService).completeDeals(ctx, in)
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Backpressure policies of subscribers.
+const (
+	policyDropOldest = "drop_oldest"
+	policyDisconnect = "disconnect"
+	policyBlock      = "block"
+)
+
+const defaultBlockTimeout = time.Second
+
+// Streams of subscribers.
+const (
+	statStream    = "statistic"
+	depthStream   = "depth"
+	tradesStream  = "trades"
+	resultsStream = "results"
+)
+
+// Subscriber of a stream. Messages are delivered without blocking publisher longer than backpressure policy allows.
+// Queue is closed when slow subscriber is disconnected, done is closed when it unsubscribes.
+type subscriber struct {
+	mu           *sync.Mutex
+	broker       exchange.Broker
+	stream       string
+	policy       config.Backpressure
+	queue        queue
+	done         chan struct{}
+	once         *sync.Once
+	closed       bool
+	sent         int64
+	dropped      int64
+	disconnected int32
+}
+
+// Returns backpressure policy of a stream with defaults.
+// Results are replayed after reconnect, so slow brokers are disconnected from them by default.
+func backpressure(cfg config.Fanout, stream string) config.Backpressure {
+	policy, ok := cfg.Streams[stream]
+	if !ok {
+		policy = cfg.Backpressure
+		if policy.Policy == "" && stream == resultsStream {
+			policy.Policy = policyDisconnect
+		}
+	}
+
+	if policy.Policy == "" {
+		policy.Policy = policyDropOldest
+	}
+
+	if policy.Timeout <= 0 {
+		policy.Timeout = defaultBlockTimeout
+	}
+
+	return policy
+}
+
+// Creates subscriber of a stream.
+func (e *exchangeService) subscriber(broker exchange.Broker, stream string, q queue) *subscriber {
+	return &subscriber{
+		mu:     &sync.Mutex{},
+		broker: broker,
+		stream: stream,
+		policy: backpressure(e.fanout, stream),
+		queue:  q,
+		done:   make(chan struct{}),
+		once:   &sync.Once{},
+	}
+}
+
+// Delivers message by backpressure policy. Returns false when any message was dropped.
+func (e *exchangeService) deliver(s *subscriber, msg interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+
+	if s.queue.offer(msg) {
+		atomic.AddInt64(&s.sent, 1)
+		return true
+	}
+
+	switch s.policy.Policy {
+	case policyDisconnect:
+		atomic.AddInt64(&s.dropped, 1)
+		atomic.StoreInt32(&s.disconnected, 1)
+		s.closed = true
+		s.queue.close()
+
+		e.logger.Warn(fanoutAction, fmt.Sprintf(
+			"broker %d is disconnected from %s stream as slow consumer", s.broker.ID, s.stream,
+		))
+
+		return false
+	case policyBlock:
+		t := time.NewTimer(s.policy.Timeout)
+		defer t.Stop()
+
+		if s.queue.wait(msg, t.C, s.done) {
+			atomic.AddInt64(&s.sent, 1)
+			return true
+		}
+
+		atomic.AddInt64(&s.dropped, 1)
+
+		return false
+	default:
+		if s.queue.evict() {
+			atomic.AddInt64(&s.dropped, 1)
+		}
+
+		if s.queue.offer(msg) {
+			atomic.AddInt64(&s.sent, 1)
+		} else {
+			atomic.AddInt64(&s.dropped, 1)
+		}
+
+		return false
+	}
+}
+
+// Stops delivery to subscriber which has unsubscribed.
+func (s *subscriber) unsubscribe() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// Returns metrics of subscriber.
+func (s *subscriber) stats() exchange.SubscriberStats {
+	return exchange.SubscriberStats{
+		BrokerID:     s.broker.ID,
+		Stream:       s.stream,
+		Policy:       s.policy.Policy,
+		Depth:        s.queue.len(),
+		Capacity:     s.queue.cap(),
+		Sent:         atomic.LoadInt64(&s.sent),
+		Dropped:      atomic.LoadInt64(&s.dropped),
+		Disconnected: atomic.LoadInt32(&s.disconnected) == 1,
+	}
+}
+
+// Subscribers returns metrics of current subscribers ordered by broker and stream.
+func (e *exchangeService) Subscribers() []exchange.SubscriberStats {
+	var subs []*subscriber
+
+	e.mu.RLock()
+	for _, obs := range e.statObs {
+		subs = append(subs, obs.sub)
+	}
+	for _, obs := range e.depthObs {
+		subs = append(subs, obs.sub)
+	}
+	for _, sub := range e.tradesObs {
+		subs = append(subs, sub)
+	}
+	for _, obs := range e.dealsObs {
+		subs = append(subs, obs.sub)
+	}
+	e.mu.RUnlock()
+
+	stats := make([]exchange.SubscriberStats, len(subs))
+	for i, sub := range subs {
+		stats[i] = sub.stats()
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].BrokerID != stats[j].BrokerID {
+			return stats[i].BrokerID < stats[j].BrokerID
+		}
+
+		return stats[i].Stream < stats[j].Stream
+	})
+
+	return stats
+}
+
+// Periodically logs metrics of subscribers which have dropped messages.
+func (e *exchangeService) reportFanout(ctx context.Context) {
+	t := time.NewTicker(e.fanout.Report)
+	defer t.Stop()
+
+	dropped := make(map[string]int64)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			for _, st := range e.Subscribers() {
+				key := fmt.Sprintf("%d/%s", st.BrokerID, st.Stream)
+				if st.Dropped == dropped[key] {
+					continue
+				}
+
+				e.logger.Warn(fanoutAction, fmt.Sprintf(
+					"broker %d %s stream: %d dropped, %d sent, queue %d/%d",
+					st.BrokerID, st.Stream, st.Dropped, st.Sent, st.Depth, st.Capacity,
+				))
+				dropped[key] = st.Dropped
+			}
+		}
+	}
+}
+
+// Queue of a subscriber over its typed channel. Typed constructors below keep messages of the channel type.
+type queue struct {
+	ch reflect.Value
+}
+
+// Queue of statistic subscriber.
+func statQueue(ch chan exchange.OHLCV) queue { return queue{ch: reflect.ValueOf(ch)} }
+
+// Queue of market depth subscriber.
+func depthQueue(ch chan exchange.Depth) queue { return queue{ch: reflect.ValueOf(ch)} }
+
+// Queue of trade tape subscriber.
+func tradeQueue(ch chan exchange.Trade) queue { return queue{ch: reflect.ValueOf(ch)} }
+
+// Queue of deal results subscriber.
+func resultQueue(ch chan exchange.Deal) queue { return queue{ch: reflect.ValueOf(ch)} }
+
+// Puts message into queue if it has room.
+func (q queue) offer(msg interface{}) bool {
+	return q.ch.TrySend(reflect.ValueOf(msg))
+}
+
+// Waits for room in queue until timeout or done.
+func (q queue) wait(msg interface{}, timeout <-chan time.Time, done <-chan struct{}) bool {
+	chosen, _, _ := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: q.ch, Send: reflect.ValueOf(msg)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timeout)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+	})
+
+	return chosen == 0
+}
+
+// Removes the oldest message of queue.
+func (q queue) evict() bool {
+	_, ok := q.ch.TryRecv()
+	return ok
+}
+
+func (q queue) close()   { q.ch.Close() }
+func (q queue) len() int { return q.ch.Len() }
+func (q queue) cap() int { return q.ch.Cap() }
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+// Returns subscriber of trade tape with a channel of one message under a policy.
+func tradeSubscriber(policy string) (*exchangeService, *subscriber, chan exchange.Trade) {
+	e := &exchangeService{
+		logger: log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean()),
+		fanout: config.Fanout{Backpressure: config.Backpressure{Policy: policy, Timeout: 10 * time.Millisecond}},
+	}
+	ch := make(chan exchange.Trade, 1)
+
+	return e, e.subscriber(exchange.Broker{ID: 1}, tradesStream, tradeQueue(ch)), ch
+}
+
+func TestDropOldestKeepsNewest(t *testing.T) {
+	e, sub, ch := tradeSubscriber(policyDropOldest)
+
+	e.deliver(sub, exchange.Trade{ID: 1})
+	e.deliver(sub, exchange.Trade{ID: 2})
+
+	if trade := <-ch; trade.ID != 2 {
+		t.Errorf("trade %d is kept, want 2", trade.ID)
+	}
+
+	if st := sub.stats(); st.Sent != 2 || st.Dropped != 1 {
+		t.Errorf("sent %d and dropped %d, want 2 and 1", st.Sent, st.Dropped)
+	}
+}
+
+func TestDisconnectClosesQueue(t *testing.T) {
+	e, sub, ch := tradeSubscriber(policyDisconnect)
+
+	e.deliver(sub, exchange.Trade{ID: 1})
+
+	if e.deliver(sub, exchange.Trade{ID: 2}) {
+		t.Fatal("trade is delivered to full queue")
+	}
+
+	<-ch
+	if _, ok := <-ch; ok {
+		t.Error("queue of slow consumer is not closed")
+	}
+
+	if e.deliver(sub, exchange.Trade{ID: 3}) || !sub.stats().Disconnected {
+		t.Error("trade is delivered to disconnected consumer")
+	}
+}
+
+func TestBlockWaitsForRoom(t *testing.T) {
+	e, sub, ch := tradeSubscriber(policyBlock)
+
+	e.deliver(sub, exchange.Trade{ID: 1})
+
+	if e.deliver(sub, exchange.Trade{ID: 2}) {
+		t.Error("trade is delivered to full queue after timeout")
+	}
+
+	go func() {
+		time.Sleep(time.Millisecond)
+		<-ch
+	}()
+
+	sub.policy.Timeout = time.Second
+	if !e.deliver(sub, exchange.Trade{ID: 3}) {
+		t.Error("trade is not delivered when queue has room")
+	}
+
+	if trade := <-ch; trade.ID != 3 {
+		t.Errorf("trade %d is delivered, want 3", trade.ID)
+	}
+}
+
+func TestSlowBrokerHoldsOnlyItsResults(t *testing.T) {
+	e := &exchangeService{
+		mu:         &sync.RWMutex{},
+		resultMu:   &sync.Mutex{},
+		logger:     log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean()),
+		fanout:     config.Fanout{Backpressure: config.Backpressure{Policy: policyBlock, Timeout: time.Second}},
+		dealsObs:   make(map[exchange.Broker]*resultObserver),
+		resultSeq:  make(map[int64]int64),
+		resultBuf:  make(map[int64][]exchange.Deal),
+		resultSent: make(map[int64]int64),
+		resultCap:  defaultResultCap,
+	}
+
+	slow, fast := exchange.Broker{ID: 1}, exchange.Broker{ID: 2}
+	e.Results(slow, exchange.NoReplay, make(chan exchange.Deal))
+
+	ch := make(chan exchange.Deal, 10)
+	e.Results(fast, exchange.NoReplay, ch)
+
+	defer e.ResultsUnsubscribe(slow)
+	defer e.ResultsUnsubscribe(fast)
+
+	start := time.Now()
+
+	for seq := int64(1); seq <= 3; seq++ {
+		for _, broker := range []exchange.Broker{slow, fast} {
+			deal := exchange.Deal{BrokerID: broker.ID, Seq: seq}
+
+			e.resultMu.Lock()
+			e.keepResult(deal)
+			e.resultMu.Unlock()
+
+			e.notify(deal)
+		}
+	}
+
+	for seq := int64(1); seq <= 3; seq++ {
+		if deal := <-ch; deal.Seq != seq {
+			t.Fatalf("result %d is delivered, want %d", deal.Seq, seq)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > e.fanout.Backpressure.Timeout/2 {
+		t.Errorf("results are delivered in %s behind slow broker", elapsed)
+	}
+}
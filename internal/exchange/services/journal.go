@@ -0,0 +1,201 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Recover rebuilds the book and inventory from snapshot and journal. It must be called before start.
+// Identifiers continue from the reserved one. Without reserved identifier, i.e. without journal or on the first start,
+// they start from wall time, above identifiers of earlier runs.
+func (e *exchangeService) Recover() error {
+	if e.journal == nil {
+		e.seedID(0)
+		return nil
+	}
+
+	snapshot, events, err := e.journal.Load()
+	if err != nil {
+		return err
+	}
+
+	e.bookMu.Lock()
+	defer e.bookMu.Unlock()
+
+	for _, deal := range snapshot.Deals {
+		e.dealQueue.Add(deal)
+	}
+
+	for ticker, amount := range snapshot.Inventory {
+		e.tickerAmt[ticker] = amount
+	}
+
+	e.resultMu.Lock()
+	defer e.resultMu.Unlock()
+
+	for _, results := range snapshot.Results {
+		for _, deal := range results {
+			e.keepResult(deal)
+		}
+	}
+
+	e.seq, e.snapSeq = snapshot.Seq, snapshot.Seq
+
+	for _, event := range events {
+		if err := e.apply(event); err != nil {
+			return err
+		}
+
+		e.seq = event.Seq
+	}
+
+	deals := e.dealQueue.List()
+
+	// Results numbered before restart are replayed to brokers by their sequence.
+	for brokerID, seq := range e.resultSeq {
+		e.resultSent[brokerID] = seq
+	}
+
+	lastID := snapshot.LastID
+	for _, deal := range deals {
+		// Immediate deals were not completed before restart, they expire after the next tick.
+		e.track(deal)
+
+		if deal.ID > lastID {
+			lastID = deal.ID
+		}
+	}
+
+	e.seedID(lastID)
+
+	e.logger.Info(journalAction, fmt.Sprintf("recovered %d deals at position %d", len(deals), e.seq))
+
+	return nil
+}
+
+// Sets the last issued identifier. Zero identifier is replaced by wall time.
+func (e *exchangeService) seedID(lastID int64) {
+	if lastID == 0 {
+		lastID = time.Now().UnixNano()
+	}
+
+	e.mu.Lock()
+	e.lastID, e.idCeil = lastID, lastID
+	e.mu.Unlock()
+}
+
+// Applies journal event to the book.
+func (e *exchangeService) apply(event exchange.Event) error {
+	deal := event.Deal
+
+	switch event.Type {
+	case exchange.EventCreate:
+		e.dealQueue.Add(deal)
+	case exchange.EventCancel:
+		e.dealQueue.Delete(deal.ID)
+	case exchange.EventUpdate:
+		e.dealQueue.Update(deal)
+	case exchange.EventTrigger:
+		e.dealQueue.Delete(deal.ID)
+		e.dealQueue.Add(deal)
+	case exchange.EventFill:
+		if deal.Amount == 0 {
+			e.dealQueue.Delete(deal.ID)
+		} else {
+			e.dealQueue.Update(deal)
+		}
+	case exchange.EventInventory:
+		e.tickerAmt[deal.Ticker] = event.Inventory
+	case exchange.EventResult:
+		e.keepResult(deal)
+	default:
+		return fmt.Errorf("unknown journal event %q at position %d", event.Type, event.Seq)
+	}
+
+	return nil
+}
+
+// Appends event of a deal to journal before the book is changed by it. Caller must hold the book lock.
+// Snapshot is written before the event, when the book reflects every appended event.
+func (e *exchangeService) record(eventType exchange.EventType, deal exchange.Deal) error {
+	if e.journal == nil {
+		return nil
+	}
+
+	if e.snapEvery > 0 && e.seq-e.snapSeq >= e.snapEvery {
+		e.snapshot()
+	}
+
+	event := exchange.Event{
+		Seq:       e.seq + 1,
+		Type:      eventType,
+		Time:      e.clock.Now(),
+		Deal:      deal,
+		Inventory: e.tickerAmt[deal.Ticker],
+	}
+
+	if err := e.journal.Append(event); err != nil {
+		return fmt.Errorf("journal %s of deal %d: %w", eventType, deal.ID, err)
+	}
+
+	e.seq = event.Seq
+
+	return nil
+}
+
+// Appends event of a change which is already made, e.g. a fill of a tick. Failed append is logged.
+// Caller must hold the book lock.
+func (e *exchangeService) note(eventType exchange.EventType, deal exchange.Deal) {
+	if err := e.record(eventType, deal); err != nil {
+		e.logger.Error(journalAction, err)
+	}
+}
+
+// Writes snapshot of the book. Caller must hold the book lock.
+// Deals are stored in time priority, so the book is rebuilt in the same order.
+func (e *exchangeService) snapshot() {
+	if e.journal == nil || e.seq == e.snapSeq {
+		return
+	}
+
+	// Deals which are queued for matching are journaled, so they are kept as resting ones.
+	deals := e.dealQueue.List()
+	for _, deal := range e.pending {
+		deals = append(deals, deal)
+	}
+
+	sort.Slice(deals, func(i, j int) bool {
+		if !deals[i].Time.Equal(deals[j].Time) {
+			return deals[i].Time.Before(deals[j].Time)
+		}
+
+		return deals[i].ID < deals[j].ID
+	})
+
+	inventory := make(map[string]int32, len(e.tickerAmt))
+	for ticker, amount := range e.tickerAmt {
+		inventory[ticker] = amount
+	}
+
+	e.mu.RLock()
+	lastID := e.idCeil
+	e.mu.RUnlock()
+
+	e.resultMu.Lock()
+	results := make(map[int64][]exchange.Deal, len(e.resultBuf))
+	for brokerID, buf := range e.resultBuf {
+		results[brokerID] = append([]exchange.Deal(nil), buf...)
+	}
+	e.resultMu.Unlock()
+
+	snapshot := exchange.Snapshot{Seq: e.seq, Deals: deals, Inventory: inventory, LastID: lastID, Results: results}
+	if err := e.journal.Snapshot(snapshot); err != nil {
+		e.logger.Error(journalAction, err)
+		return
+	}
+
+	e.snapSeq = e.seq
+}
@@ -0,0 +1,220 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/repository/file"
+	"github.com/marksartdev/trading/internal/exchange/repository/memory"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+var start = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// Creates exchange of ticker A with a journal in a directory.
+func newJournaled(t *testing.T, dir string) (exchange.ExchangeService, file.Journal) {
+	t.Helper()
+
+	journal, err := file.NewJournal(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = journal.Close() })
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}}
+	service := services.NewExchangeService(logger, clock.NewVirtual(start), memory.NewOrderBook(), nil, journal, cfg)
+
+	if err := service.Recover(); err != nil {
+		t.Fatal(err)
+	}
+
+	return service, journal
+}
+
+func limitBuy(price string) exchange.Deal {
+	return exchange.Deal{
+		BrokerID:    1,
+		Ticker:      "A",
+		Side:        exchange.Buy,
+		Type:        exchange.Limit,
+		TimeInForce: exchange.GTC,
+		Amount:      10,
+		Price:       decimal.MustParse(price),
+	}
+}
+
+func TestRecoverRestoresBook(t *testing.T) {
+	dir := t.TempDir()
+
+	first, journal := newJournaled(t, dir)
+
+	created := make(map[int64]bool)
+	for _, price := range []string{"10", "11", "12"} {
+		deal, err := first.Create(limitBuy(price))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		created[deal.ID] = true
+	}
+
+	if ok, err := first.Cancel(1, firstKey(created)); err != nil || !ok {
+		t.Fatalf("deal is not canceled: %v", err)
+	}
+
+	_ = journal.Close()
+
+	second, _ := newJournaled(t, dir)
+
+	open := second.ListOpen(1)
+	if len(open) != 2 {
+		t.Fatalf("recovered %d deals, want 2", len(open))
+	}
+
+	for _, deal := range open {
+		if !created[deal.ID] {
+			t.Errorf("unknown deal %d is recovered", deal.ID)
+		}
+	}
+}
+
+func TestRecoverNeverReusesIdentifiers(t *testing.T) {
+	dir := t.TempDir()
+
+	first, journal := newJournaled(t, dir)
+
+	var last int64
+	for i := 0; i < 3; i++ {
+		deal, err := first.Create(limitBuy("10"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		last = deal.ID
+	}
+
+	// Fill identifiers are issued without book events, they are reserved as well.
+	fills := first.Process(exchange.Tick{Ticker: "A", Price: decimal.New(9), Vol: 100})
+	for _, fill := range fills {
+		if fill.FillID > last {
+			last = fill.FillID
+		}
+	}
+
+	_ = journal.Close()
+
+	second, _ := newJournaled(t, dir)
+
+	deal, err := second.Create(limitBuy("10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deal.ID <= last {
+		t.Errorf("identifier %d is issued again after restart, last issued %d", deal.ID, last)
+	}
+}
+
+func TestRequestFailsWhenJournalFails(t *testing.T) {
+	service, journal := newJournaled(t, t.TempDir())
+
+	deal, err := service.Create(limitBuy("10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = journal.Close()
+
+	if _, err := service.Create(limitBuy("11")); err == nil {
+		t.Error("deal is created without journal")
+	}
+
+	if ok, err := service.Cancel(1, deal.ID); err == nil || ok {
+		t.Error("deal is canceled without journal")
+	}
+
+	if ok, err := service.Modify(1, deal.ID, decimal.New(12), 0); err == nil || ok {
+		t.Error("deal is modified without journal")
+	}
+
+	open := service.ListOpen(1)
+	if len(open) != 1 || open[0].ID != deal.ID || open[0].Price != deal.Price {
+		t.Errorf("book is changed without journal: %+v", open)
+	}
+}
+
+// Journal whose reservation of identifiers fails on demand.
+type reserveJournal struct {
+	file.Journal
+	fail     bool
+	reserved int64
+}
+
+func (j *reserveJournal) Reserve(lastID int64) error {
+	if j.fail {
+		return errors.New("disk is full")
+	}
+
+	j.reserved = lastID
+
+	return j.Journal.Reserve(lastID)
+}
+
+func TestNoIdentifierAboveReservation(t *testing.T) {
+	inner, err := file.NewJournal(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = inner.Close() })
+
+	journal := &reserveJournal{Journal: inner, fail: true}
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}}
+	service := services.NewExchangeService(logger, clock.NewVirtual(start), memory.NewOrderBook(), nil, journal, cfg)
+
+	if err := service.Recover(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Create(limitBuy("10")); err == nil {
+		t.Fatal("deal is created without reserved identifier")
+	}
+
+	if open := service.ListOpen(1); len(open) != 0 {
+		t.Fatalf("book is changed without reserved identifier: %+v", open)
+	}
+
+	journal.fail = false
+
+	deal, err := service.Create(limitBuy("10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deal.ID > journal.reserved {
+		t.Errorf("identifier %d is issued above reserved %d", deal.ID, journal.reserved)
+	}
+}
+
+// Returns the smallest key of a set.
+func firstKey(set map[int64]bool) int64 {
+	var res int64
+	for key := range set {
+		if res == 0 || key < res {
+			res = key
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"math"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+const defaultFloat = 1000
+
+// Impact model in which slippage grows by square root of filled share.
+const impactSqrt = "sqrt"
+
+// Returns liquidity of ticker with defaults.
+func tickerLiquidity(cfg config.Liquidity, ticker string) config.TickerLiquidity {
+	liq, ok := cfg.Tickers[ticker]
+	if !ok {
+		liq = cfg.TickerLiquidity
+	}
+
+	if liq.Float <= 0 {
+		liq.Float = defaultFloat
+	}
+
+	return liq
+}
+
+// Volume of a tick available to each side.
+type tickBudget struct {
+	buy  int32
+	sell int32
+}
+
+// Returns volume of tick available to each side. Negative volume is unlimited.
+// Without participation buys are limited by inventory and sells by capacity,
+// sells without capacity are limited by volume of the tick.
+func (e *exchangeService) budget(tick exchange.Tick) tickBudget {
+	liq := e.liquidity[tick.Ticker]
+	if liq.Participation <= 0 {
+		if liq.Capacity <= 0 {
+			return tickBudget{buy: -1, sell: tick.Vol}
+		}
+
+		return tickBudget{buy: -1, sell: -1}
+	}
+
+	volume := int32(float64(tick.Vol) * liq.Participation)
+
+	return tickBudget{buy: volume, sell: volume}
+}
+
+// Returns amount of deal which may be filled by a tick.
+// Buys are limited by inventory, sells by its capacity, both sides by volume of tick.
+func (e *exchangeService) available(deal exchange.Deal, budget tickBudget) int32 {
+	liq := e.liquidity[deal.Ticker]
+	amount := deal.Amount
+
+	limit := budget.sell
+	if deal.Side == exchange.Buy {
+		limit = budget.buy
+		amount = min32(amount, e.tickerAmt[deal.Ticker])
+	} else if liq.Capacity > 0 {
+		amount = min32(amount, liq.Capacity-e.tickerAmt[deal.Ticker])
+	}
+
+	if limit >= 0 {
+		amount = min32(amount, limit)
+	}
+
+	return amount
+}
+
+// Completes amount of deal by a tick at a price, moves inventory and returns the fill.
+// The rest of the deal stays in the queue. Without identifier of the fill nothing is changed.
+func (e *exchangeService) complete(
+	deal exchange.Deal, amount int32, price decimal.Decimal, budget *tickBudget,
+) (exchange.Deal, error) {
+	id, err := e.nextID()
+	if err != nil {
+		return exchange.Deal{}, err
+	}
+
+	if deal.Side == exchange.Buy {
+		e.tickerAmt[deal.Ticker] -= amount
+		if budget.buy >= 0 {
+			budget.buy -= amount
+		}
+	} else {
+		e.tickerAmt[deal.Ticker] += amount
+		if budget.sell >= 0 {
+			budget.sell -= amount
+		}
+	}
+
+	e.note(exchange.EventInventory, deal)
+	deal.Amount -= amount
+
+	e.settle(deal)
+
+	return e.fill(deal, amount, price, id), nil
+}
+
+// Returns fill price moved from tick price against the deal by market impact and rounded to tick size.
+// Limit deals are never filled worse than their price.
+func (e *exchangeService) impact(deal exchange.Deal, amount int32, tick exchange.Tick) decimal.Decimal {
+	liq := e.liquidity[deal.Ticker]
+	size := e.tickSize[deal.Ticker]
+	if liq.Impact <= 0 {
+		return tick.Price.RoundTo(size)
+	}
+
+	share := float64(amount) / math.Max(float64(tick.Vol), 1)
+	if liq.ImpactModel == impactSqrt {
+		share = math.Sqrt(share)
+	}
+
+	limited := deal.Type == exchange.Limit || deal.Type == exchange.StopLimit
+
+	if deal.Side == exchange.Buy {
+		price := tick.Price.MulFloat(1 + liq.Impact*share).RoundTo(size)
+		if limited && price > deal.Price {
+			price = deal.Price
+		}
+
+		return price
+	}
+
+	price := tick.Price.MulFloat(1 - liq.Impact*share).RoundTo(size)
+	if limited && price < deal.Price {
+		price = deal.Price
+	}
+
+	return price
+}
+
+func min32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
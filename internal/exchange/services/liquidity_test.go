@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/repository/memory"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+// Creates exchange of ticker A which fills deals by ticks.
+func newLiquid(t *testing.T, liq config.TickerLiquidity) exchange.ExchangeService {
+	t.Helper()
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}, Liquidity: config.Liquidity{TickerLiquidity: liq}}
+
+	return services.NewExchangeService(logger, clock.NewVirtual(time.Now()), memory.NewOrderBook(), nil, nil, cfg)
+}
+
+func TestSellsWithoutCapacityAreLimitedByTickVolume(t *testing.T) {
+	e := newLiquid(t, config.TickerLiquidity{})
+
+	if _, err := e.Create(limit(exchange.Sell, 500, "95")); err != nil {
+		t.Fatal(err)
+	}
+
+	fills := e.Process(exchange.Tick{Ticker: "A", Price: decimal.New(100), Vol: 100})
+	if len(fills) != 1 || fills[0].Amount != 100 || !fills[0].Partial {
+		t.Fatalf("got fills %v, want a partial fill of 100", fills)
+	}
+
+	if open := e.ListOpen(1); len(open) != 1 || open[0].Amount != 400 {
+		t.Errorf("open deals %v, want the rest of 400", open)
+	}
+}
+
+func TestImpactMovesFillPriceAgainstDeal(t *testing.T) {
+	market := func(side exchange.Side) exchange.Deal {
+		deal := limit(side, 10, "0")
+		deal.Type = exchange.Market
+
+		return deal
+	}
+
+	tests := []struct {
+		name  string
+		model string
+		deal  exchange.Deal
+		want  string
+	}{
+		{"linear buy", "", market(exchange.Buy), "101"},
+		{"linear sell", "", market(exchange.Sell), "99"},
+		{"sqrt buy is rounded to tick size", "sqrt", market(exchange.Buy), "103.15"},
+		{"limit buy is not filled above its price", "sqrt", limit(exchange.Buy, 10, "102"), "102"},
+		{"limit sell is not filled below its price", "sqrt", limit(exchange.Sell, 10, "98"), "98"},
+	}
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+
+	for _, tt := range tests {
+		cfg := config.Exchange{
+			Tickers:   []string{"A"},
+			Liquidity: config.Liquidity{TickerLiquidity: config.TickerLiquidity{Impact: 0.1, ImpactModel: tt.model}},
+			TickSize:  config.TickSize{Size: decimal.MustParse("0.05")},
+		}
+		e := services.NewExchangeService(logger, clock.NewVirtual(start), memory.NewOrderBook(), nil, nil, cfg)
+
+		if _, err := e.Create(tt.deal); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		fills := e.Process(exchange.Tick{Ticker: "A", Price: decimal.New(100), Vol: 100})
+		if len(fills) != 1 || fills[0].Price != decimal.MustParse(tt.want) {
+			t.Errorf("%s: got fills %v, want one at %s", tt.name, fills, tt.want)
+		}
+	}
+}
+
+func TestBuysAreLimitedByInventory(t *testing.T) {
+	e := newLiquid(t, config.TickerLiquidity{Float: 30})
+
+	if _, err := e.Create(limit(exchange.Buy, 50, "100")); err != nil {
+		t.Fatal(err)
+	}
+
+	fills := e.Process(exchange.Tick{Ticker: "A", Price: decimal.New(100), Vol: 100})
+	if len(fills) != 1 || fills[0].Amount != 30 {
+		t.Fatalf("got fills %v, want 30 of inventory", fills)
+	}
+
+	if fills := e.Process(exchange.Tick{Ticker: "A", Price: decimal.New(100), Vol: 100}); len(fills) != 0 {
+		t.Errorf("got fills %v from empty inventory", fills)
+	}
+}
@@ -0,0 +1,212 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/repository/memory"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+// Creates exchange of ticker A with continuous matching and a subscriber of trade tape.
+func newContinuous(t *testing.T, clk clock.Clock, session config.Session) (exchange.ExchangeService, chan exchange.Trade) {
+	t.Helper()
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}, Matching: "continuous", Session: session}
+	service := services.NewExchangeService(logger, clk, memory.NewOrderBook(), nil, nil, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		services.Match(ctx, service)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	trades := make(chan exchange.Trade, 10)
+	service.Trades(exchange.Broker{ID: 1}, trades)
+
+	return service, trades
+}
+
+// Waits until broker has a number of resting deals.
+func waitOpen(t *testing.T, service exchange.ExchangeService, n int) []exchange.Deal {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		open := service.ListOpen(1)
+		if len(open) == n {
+			return open
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("%d deals rest, want %d", len(open), n)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Waits for the next trade of tape.
+func nextTrade(t *testing.T, trades chan exchange.Trade) exchange.Trade {
+	t.Helper()
+
+	select {
+	case trade := <-trades:
+		return trade
+	case <-time.After(time.Second):
+		t.Fatal("no trade is printed")
+	}
+
+	return exchange.Trade{}
+}
+
+func limit(side exchange.Side, amount int32, price string) exchange.Deal {
+	return exchange.Deal{
+		BrokerID:    1,
+		Ticker:      "A",
+		Side:        side,
+		Type:        exchange.Limit,
+		TimeInForce: exchange.GTC,
+		Amount:      amount,
+		Price:       decimal.MustParse(price),
+	}
+}
+
+func TestDealsCrossedBeforeOpenAreUncrossedAtOpen(t *testing.T) {
+	clk := clock.NewVirtual(time.Date(2021, 3, 1, 9, 30, 0, 0, time.UTC))
+	session := config.Session{PreOpen: 9 * time.Hour, Open: 10 * time.Hour, Auction: 17 * time.Hour, Close: 18 * time.Hour}
+	service, trades := newContinuous(t, clk, session)
+
+	for _, deal := range []exchange.Deal{limit(exchange.Buy, 5, "101"), limit(exchange.Sell, 5, "100")} {
+		if _, err := service.Create(deal); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitOpen(t, service, 2)
+
+	services.OpenSession(service)
+
+	if trade := nextTrade(t, trades); trade.Amount != 5 || trade.Price != decimal.New(100) {
+		t.Errorf("got trade %d at %s, want 5 at 100", trade.Amount, trade.Price)
+	}
+
+	if open := service.ListOpen(1); len(open) != 0 {
+		t.Errorf("%d deals rest after open, want none", len(open))
+	}
+}
+
+func TestCreateIsNotBlockedAtShutdown(t *testing.T) {
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}, Matching: "continuous"}
+	service := services.NewExchangeService(logger, clock.NewVirtual(time.Now()), memory.NewOrderBook(), nil, nil, cfg)
+
+	services.Shutdown(service)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// Nobody matches, so deals beyond the queue of matching are left pending.
+		for i := 0; i < 200; i++ {
+			if _, err := service.Create(limit(exchange.Buy, 1, "100")); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("create is blocked after shutdown")
+	}
+}
+
+func TestDayDealIsRejectedWithoutSessionClose(t *testing.T) {
+	service, _ := newContinuous(t, clock.NewVirtual(time.Now()), config.Session{})
+
+	deal := limit(exchange.Buy, 1, "100")
+	deal.TimeInForce = exchange.Day
+
+	if _, err := service.Create(deal); !errors.Is(err, exchange.ErrNotAccepted) {
+		t.Errorf("day deal without session close: %v", err)
+	}
+}
+
+func TestIncomingDealMatchesByPriceAndTime(t *testing.T) {
+	service, trades := newContinuous(t, clock.NewVirtual(time.Now()), config.Session{})
+
+	var sells []exchange.Deal
+
+	for _, deal := range []exchange.Deal{
+		limit(exchange.Sell, 3, "101"),
+		limit(exchange.Sell, 3, "100"),
+		limit(exchange.Sell, 3, "100"),
+	} {
+		created, err := service.Create(deal)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sells = append(sells, created)
+	}
+
+	waitOpen(t, service, 3)
+
+	if _, err := service.Create(limit(exchange.Buy, 4, "101")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []int32{3, 1} {
+		trade := nextTrade(t, trades)
+		if trade.Amount != want || trade.Price != decimal.New(100) || trade.Side != exchange.Buy {
+			t.Errorf("got trade %d at %s by %s, want %d at 100 by BUY", trade.Amount, trade.Price, trade.Side, want)
+		}
+	}
+
+	open := make(map[int64]int32)
+	for _, deal := range waitOpen(t, service, 2) {
+		open[deal.ID] = deal.Amount
+	}
+
+	if open[sells[0].ID] != 3 || open[sells[2].ID] != 2 {
+		t.Errorf("open deals %v, want %d: 3 and %d: 2", open, sells[0].ID, sells[2].ID)
+	}
+}
+
+func TestDealWhichDoesNotCrossRests(t *testing.T) {
+	service, trades := newContinuous(t, clock.NewVirtual(time.Now()), config.Session{})
+
+	for _, deal := range []exchange.Deal{limit(exchange.Sell, 3, "101"), limit(exchange.Buy, 3, "100")} {
+		if _, err := service.Create(deal); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitOpen(t, service, 2)
+
+	select {
+	case trade := <-trades:
+		t.Errorf("unexpected trade %d at %s", trade.Amount, trade.Price)
+	default:
+	}
+}
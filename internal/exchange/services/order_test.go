@@ -0,0 +1,203 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/services"
+)
+
+func stop(side exchange.Side, amount int32, stopPrice, price string) exchange.Deal {
+	deal := limit(side, amount, "0")
+	deal.Type = exchange.Stop
+	deal.StopPrice = decimal.MustParse(stopPrice)
+
+	if price != "" {
+		deal.Type = exchange.StopLimit
+		deal.Price = decimal.MustParse(price)
+	}
+
+	return deal
+}
+
+func tick(price string) exchange.Tick {
+	return exchange.Tick{Ticker: "A", Price: decimal.MustParse(price), Vol: 100}
+}
+
+func TestStopIsFilledAtMarketAfterTrigger(t *testing.T) {
+	e := newLiquid(t, config.TickerLiquidity{})
+
+	if _, err := e.Create(stop(exchange.Buy, 5, "105", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	if fills := e.Process(tick("104")); len(fills) != 0 {
+		t.Fatalf("stop is filled before trigger: %v", fills)
+	}
+
+	fills := e.Process(tick("106"))
+	if len(fills) != 1 || fills[0].Amount != 5 || fills[0].Price != decimal.New(106) {
+		t.Fatalf("got fills %v, want 5 at 106", fills)
+	}
+}
+
+func TestStopLimitRestsAtItsPriceAfterTrigger(t *testing.T) {
+	e := newLiquid(t, config.TickerLiquidity{})
+
+	if _, err := e.Create(stop(exchange.Sell, 5, "95", "94")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stop is triggered, but the tick is below its limit price.
+	if fills := e.Process(tick("93")); len(fills) != 0 {
+		t.Fatalf("stop limit is filled below its price: %v", fills)
+	}
+
+	open := e.ListOpen(1)
+	if len(open) != 1 || open[0].Type != exchange.Limit || open[0].Price != decimal.New(94) {
+		t.Fatalf("open deals %v, want a limit sell at 94", open)
+	}
+
+	fills := e.Process(tick("94.5"))
+	if len(fills) != 1 || fills[0].Amount != 5 || fills[0].Price != decimal.MustParse("94.5") {
+		t.Fatalf("got fills %v, want 5 at 94.5", fills)
+	}
+}
+
+// Subscribes broker 1 to results of its deals.
+func subscribeResults(t *testing.T, service exchange.ExchangeService) chan exchange.Deal {
+	t.Helper()
+
+	results := make(chan exchange.Deal, 10)
+	service.Results(exchange.Broker{ID: 1}, exchange.NoReplay, results)
+	t.Cleanup(func() { service.ResultsUnsubscribe(exchange.Broker{ID: 1}) })
+
+	return results
+}
+
+// Waits for the next result of deal.
+func nextResult(t *testing.T, results chan exchange.Deal, dealID int64) exchange.Deal {
+	t.Helper()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case res := <-results:
+			if res.ID == dealID {
+				return res
+			}
+		case <-timeout:
+			t.Fatalf("no result of deal %d", dealID)
+		}
+	}
+}
+
+func withTimeInForce(deal exchange.Deal, tif exchange.TimeInForce) exchange.Deal {
+	deal.TimeInForce = tif
+	return deal
+}
+
+func TestImmediateOrCancelExpiresItsRest(t *testing.T) {
+	service, _ := newContinuous(t, clock.NewVirtual(time.Now()), config.Session{})
+	results := subscribeResults(t, service)
+
+	if _, err := service.Create(limit(exchange.Sell, 3, "100")); err != nil {
+		t.Fatal(err)
+	}
+
+	waitOpen(t, service, 1)
+
+	ioc, err := service.Create(withTimeInForce(limit(exchange.Buy, 5, "100"), exchange.IOC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res := nextResult(t, results, ioc.ID); res.Status != exchange.Filled || res.Amount != 3 {
+		t.Errorf("got %s of %d, want fill of 3", res.Status, res.Amount)
+	}
+
+	if res := nextResult(t, results, ioc.ID); res.Status != exchange.Expired || res.Amount != 2 {
+		t.Errorf("got %s of %d, want expiration of 2", res.Status, res.Amount)
+	}
+
+	waitOpen(t, service, 0)
+}
+
+func TestFillOrKillIsNotFilledPartially(t *testing.T) {
+	service, trades := newContinuous(t, clock.NewVirtual(time.Now()), config.Session{})
+	results := subscribeResults(t, service)
+
+	if _, err := service.Create(limit(exchange.Sell, 3, "100")); err != nil {
+		t.Fatal(err)
+	}
+
+	waitOpen(t, service, 1)
+
+	fok, err := service.Create(withTimeInForce(limit(exchange.Buy, 5, "100"), exchange.FOK))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res := nextResult(t, results, fok.ID); res.Status != exchange.Expired || res.Amount != 5 {
+		t.Errorf("got %s of %d, want expiration of 5", res.Status, res.Amount)
+	}
+
+	if open := waitOpen(t, service, 1); open[0].Amount != 3 {
+		t.Errorf("resting sell has %d, want 3", open[0].Amount)
+	}
+
+	select {
+	case trade := <-trades:
+		t.Errorf("unexpected trade %d at %s", trade.Amount, trade.Price)
+	default:
+	}
+}
+
+func TestDayDealExpiresAtClose(t *testing.T) {
+	e, _ := newAuction(t)
+	results := subscribeResults(t, e)
+
+	day, err := e.Create(withTimeInForce(limit(exchange.Buy, 5, "99"), exchange.Day))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gtc := auctionDeal(t, e, exchange.Buy, 5, "98")
+
+	services.CloseSession(e)
+
+	if res := nextResult(t, results, day.ID); res.Status != exchange.Expired || res.Amount != 5 {
+		t.Errorf("got %s of %d, want expiration of 5", res.Status, res.Amount)
+	}
+
+	if open := e.ListOpen(1); len(open) != 1 || open[0].ID != gtc.ID {
+		t.Errorf("open deals %v, want the GTC deal only", open)
+	}
+}
+
+func TestPartialFillKeepsTheRestInBook(t *testing.T) {
+	e := newLiquid(t, config.TickerLiquidity{Participation: 0.5})
+
+	deal, err := e.Create(limit(exchange.Buy, 80, "100"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []struct {
+		amount  int32
+		partial bool
+	}{{50, true}, {30, false}} {
+		fills := e.Process(tick("99"))
+		if len(fills) != 1 || fills[0].ID != deal.ID || fills[0].Amount != want.amount || fills[0].Partial != want.partial {
+			t.Fatalf("tick %d: got fills %v, want %d with partial %v", i, fills, want.amount, want.partial)
+		}
+	}
+
+	if open := e.ListOpen(1); len(open) != 0 {
+		t.Errorf("open deals %v, want none", open)
+	}
+}
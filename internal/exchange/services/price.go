@@ -0,0 +1,33 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Returns tick size of ticker with default.
+func tickerTickSize(cfg config.TickSize, ticker string) decimal.Decimal {
+	if size, ok := cfg.Tickers[ticker]; ok {
+		return size
+	}
+
+	return cfg.Size
+}
+
+// Checks that limit and stop prices of a deal are multiples of tick size of its ticker.
+func (e *exchangeService) checkPrices(deal exchange.Deal) error {
+	size := e.tickSize[deal.Ticker]
+
+	if !deal.Price.Multiple(size) {
+		return fmt.Errorf("price %s of %s is not a multiple of tick size %s", deal.Price, deal.Ticker, size)
+	}
+
+	if !deal.StopPrice.Multiple(size) {
+		return fmt.Errorf("stop price %s of %s is not a multiple of tick size %s", deal.StopPrice, deal.Ticker, size)
+	}
+
+	return nil
+}
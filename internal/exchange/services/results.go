@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+const defaultResultCap = 1000
+
+// Returns size of replay buffer.
+func resultCapacity(size int) int {
+	if size <= 0 {
+		return defaultResultCap
+	}
+
+	return size
+}
+
+// Observer of results of a broker. Its own goroutine sends results in order of their sequence,
+// so a slow observer holds neither matching nor other brokers.
+type resultObserver struct {
+	sub  *subscriber
+	wake chan struct{}
+	seq  int64
+}
+
+// Results adds observer for deals and returns buffered results after the last seen sequence.
+// Returned results precede every result sent to the channel. Zero sequence replays all buffered results,
+// which a broker has not applied yet, negative one means no replay.
+func (e *exchangeService) Results(broker exchange.Broker, seq int64, ch chan exchange.Deal) []exchange.Deal {
+	e.resultMu.Lock()
+	defer e.resultMu.Unlock()
+
+	last := e.resultSent[broker.ID]
+
+	obs := &resultObserver{
+		sub:  e.subscriber(broker, resultsStream, resultQueue(ch)),
+		wake: make(chan struct{}, 1),
+		seq:  last,
+	}
+
+	e.mu.Lock()
+	e.dealsObs[broker] = obs
+	e.mu.Unlock()
+
+	go e.sendResults(obs)
+
+	if seq < 0 {
+		return nil
+	}
+
+	// Results are numbered anew when exchange is restarted without journal, all of them are replayed.
+	if seq > last {
+		e.logger.Warn(resultsAction, fmt.Sprintf(
+			"broker %d has seen result %d, but the last one is %d, replaying all buffered results", broker.ID, seq, last,
+		))
+		seq = 0
+	}
+
+	buf := e.resultBuf[broker.ID]
+	if len(buf) > 0 && buf[0].Seq > seq+1 {
+		e.logger.Warn(resultsAction, fmt.Sprintf(
+			"results %d-%d of broker %d are out of buffer", seq+1, buf[0].Seq-1, broker.ID,
+		))
+	}
+
+	var missed []exchange.Deal
+
+	for _, deal := range buf {
+		if deal.Seq > seq && deal.Seq <= last {
+			missed = append(missed, deal)
+		}
+	}
+
+	return missed
+}
+
+// ResultsUnsubscribe removes observer for deals.
+func (e *exchangeService) ResultsUnsubscribe(broker exchange.Broker) {
+	e.mu.Lock()
+	obs, ok := e.dealsObs[broker]
+	delete(e.dealsObs, broker)
+	e.mu.Unlock()
+
+	if ok {
+		obs.sub.unsubscribe()
+	}
+}
+
+// Numbers result of a deal for its broker, journals it and keeps it for replay.
+// Caller holds book lock, so results are numbered in order of the journal.
+func (e *exchangeService) result(deal exchange.Deal) exchange.Deal {
+	e.resultMu.Lock()
+	deal.Seq = e.resultSeq[deal.BrokerID] + 1
+	e.resultMu.Unlock()
+
+	e.note(exchange.EventResult, deal)
+
+	e.resultMu.Lock()
+	e.keepResult(deal)
+	e.resultMu.Unlock()
+
+	return deal
+}
+
+// Keeps numbered result in replay buffer of its broker. Caller holds result lock.
+func (e *exchangeService) keepResult(deal exchange.Deal) {
+	e.resultSeq[deal.BrokerID] = deal.Seq
+
+	buf := append(e.resultBuf[deal.BrokerID], deal)
+	if len(buf) > e.resultCap {
+		buf = buf[len(buf)-e.resultCap:]
+	}
+	e.resultBuf[deal.BrokerID] = buf
+}
+
+// Marks numbered result and every result of its broker numbered before it as sent and wakes observers
+// of the broker. Each result is marked once, even when results are completed by different goroutines.
+func (e *exchangeService) notify(deal exchange.Deal) {
+	e.resultMu.Lock()
+	if deal.Seq <= e.resultSent[deal.BrokerID] {
+		e.resultMu.Unlock()
+		return
+	}
+
+	e.resultSent[deal.BrokerID] = deal.Seq
+	e.resultMu.Unlock()
+
+	e.mu.RLock()
+	for broker, obs := range e.dealsObs {
+		if broker.ID == deal.BrokerID {
+			select {
+			case obs.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+	e.mu.RUnlock()
+}
+
+// Sends results marked as sent to observer until it unsubscribes.
+func (e *exchangeService) sendResults(obs *resultObserver) {
+	for {
+		select {
+		case <-obs.sub.done:
+			return
+		case <-obs.wake:
+		}
+
+		for _, res := range e.unsent(obs) {
+			e.deliver(obs.sub, res)
+		}
+	}
+}
+
+// Returns buffered results which are marked as sent, but not sent to observer yet.
+func (e *exchangeService) unsent(obs *resultObserver) []exchange.Deal {
+	e.resultMu.Lock()
+	defer e.resultMu.Unlock()
+
+	var res []exchange.Deal
+
+	last := e.resultSent[obs.sub.broker.ID]
+	for _, deal := range e.resultBuf[obs.sub.broker.ID] {
+		if deal.Seq > obs.seq && deal.Seq <= last {
+			res = append(res, deal)
+		}
+	}
+
+	obs.seq = last
+
+	return res
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/services"
+)
+
+func TestResultsAreReplayedAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	first, journal := newJournaled(t, dir)
+
+	for _, price := range []string{"10", "11"} {
+		if _, err := first.Create(limitBuy(price)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := first.Process(exchange.Tick{Ticker: "A", Price: decimal.New(9), Vol: 100})
+	if len(results) != 2 {
+		t.Fatalf("%d results, want 2", len(results))
+	}
+
+	for i, res := range results {
+		if res.Seq != int64(i+1) {
+			t.Fatalf("result %d has sequence %d", i, res.Seq)
+		}
+	}
+
+	_ = journal.Close()
+
+	second, _ := newJournaled(t, dir)
+
+	broker := exchange.Broker{ID: 1, InstanceID: 1}
+	missed := second.Results(broker, 1, make(chan exchange.Deal, 10))
+
+	if len(missed) != 1 || missed[0].FillID != results[1].FillID || missed[0].Seq != 2 {
+		t.Fatalf("replayed %+v, want the second result", missed)
+	}
+
+	second.ResultsUnsubscribe(broker)
+}
+
+func TestResultsMissedBeforeFirstAreReplayed(t *testing.T) {
+	service, _ := newJournaled(t, t.TempDir())
+
+	broker := exchange.Broker{ID: 1, InstanceID: 1}
+	service.Results(broker, 0, make(chan exchange.Deal, 10))
+	service.ResultsUnsubscribe(broker)
+
+	for _, price := range []string{"10", "11"} {
+		if _, err := service.Create(limitBuy(price)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := service.Process(exchange.Tick{Ticker: "A", Price: decimal.New(9), Vol: 100})
+	for _, res := range results {
+		services.Notify(service, res)
+	}
+
+	if missed := service.Results(broker, exchange.NoReplay, make(chan exchange.Deal, 10)); len(missed) != 0 {
+		t.Errorf("replayed %d results without replay", len(missed))
+	}
+
+	missed := service.Results(broker, 0, make(chan exchange.Deal, 10))
+	if len(missed) != len(results) {
+		t.Fatalf("replayed %d results to broker which has applied none, want %d", len(missed), len(results))
+	}
+
+	for i, res := range missed {
+		if res.FillID != results[i].FillID {
+			t.Errorf("result %d is %d, want %d", i, res.FillID, results[i].FillID)
+		}
+	}
+
+	service.ResultsUnsubscribe(broker)
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Default circuit breaker settings.
+const (
+	defaultBreakerWindow = 5 * time.Minute
+	defaultBreakerHalt   = 5 * time.Minute
+)
+
+// Price of a ticker at a moment, it is kept for circuit breaker.
+type pricePoint struct {
+	time  time.Time
+	price decimal.Decimal
+}
+
+// Orders phases of session schedule. Zero close disables schedule.
+func sessionConfig(cfg config.Session) config.Session {
+	if cfg.Close <= 0 {
+		return config.Session{}
+	}
+
+	if cfg.Auction <= 0 || cfg.Auction > cfg.Close {
+		cfg.Auction = cfg.Close
+	}
+
+	if cfg.Open > cfg.Auction {
+		cfg.Open = cfg.Auction
+	}
+
+	if cfg.PreOpen <= 0 || cfg.PreOpen > cfg.Open {
+		cfg.PreOpen = cfg.Open
+	}
+
+	return cfg
+}
+
+// Fills defaults of circuit breaker config.
+func breakerConfig(cfg config.Breaker) config.Breaker {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultBreakerWindow
+	}
+
+	if cfg.Halt <= 0 {
+		cfg.Halt = defaultBreakerHalt
+	}
+
+	return cfg
+}
+
+// Returns start of the day.
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// Returns phase of session at a moment.
+func (e *exchangeService) phase(now time.Time) exchange.Phase {
+	s := e.session
+	if s.Close <= 0 {
+		return exchange.Continuous
+	}
+
+	t := now.Sub(midnight(now))
+
+	switch {
+	case t >= s.Close:
+		return exchange.Closed
+	case t >= s.Auction:
+		return exchange.ClosingAuction
+	case t >= s.Open:
+		return exchange.Continuous
+	case t >= s.PreOpen:
+		return exchange.PreOpen
+	default:
+		return exchange.Closed
+	}
+}
+
+// Returns the next change of phase and its offset from midnight.
+func (e *exchangeService) nextPhase(now time.Time) (time.Time, time.Duration) {
+	s := e.session
+	day := midnight(now)
+
+	for {
+		for _, offset := range []time.Duration{s.PreOpen, s.Open, s.Auction, s.Close} {
+			if at := day.Add(offset); at.After(now) {
+				return at, offset
+			}
+		}
+
+		day = day.AddDate(0, 0, 1)
+	}
+}
+
+// Runs session schedule. In continuous mode deals collected before open are uncrossed at every open.
+// Closing auction and expiration of day deals run at every close.
+func (e *exchangeService) runSession(ctx context.Context) {
+	e.logger.Info(sessionAction, "started")
+	defer e.logger.Info(sessionAction, "stopped")
+
+	e.logger.Info(sessionAction, fmt.Sprintf("session phase is %s", e.phase(e.clock.Now())))
+
+	for {
+		now := e.clock.Now()
+		at, offset := e.nextPhase(now)
+
+		timer := e.clock.NewTimer(at.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+			e.logger.Info(sessionAction, fmt.Sprintf("session phase is %s", e.phase(at)))
+
+			if offset == e.session.Open && e.continuous && e.phase(at) == exchange.Continuous {
+				e.openSession()
+			}
+
+			if offset == e.session.Close {
+				e.closeSession()
+			}
+		}
+	}
+}
+
+// Uncrosses deals collected before open, so deals which crossed each other are matched before continuous trading.
+func (e *exchangeService) openSession() {
+	e.bookMu.Lock()
+	res, trades := e.auction()
+	e.bookMu.Unlock()
+
+	e.logger.Info(sessionAction, fmt.Sprintf("session opened, %d results of opening auction", len(res)))
+
+	e.sendAuction(res, trades)
+}
+
+// Uncrosses deals of closing auction and expires day deals.
+func (e *exchangeService) closeSession() {
+	e.bookMu.Lock()
+	res, trades := e.auction()
+
+	filled := len(res)
+
+	for _, deal := range e.dealQueue.List() {
+		if deal.TimeInForce != exchange.Day {
+			continue
+		}
+
+		// Deals left without identifier of the result expire at the next close.
+		id, err := e.nextID()
+		if err != nil {
+			e.logger.Error(sessionAction, err)
+			break
+		}
+
+		e.note(exchange.EventCancel, deal)
+		e.dealQueue.Delete(deal.ID)
+		res = append(res, e.expired(deal, id))
+	}
+	e.bookMu.Unlock()
+
+	e.logger.Info(sessionAction, fmt.Sprintf(
+		"session closed, %d results of closing auction, %d day deals expired", filled, len(res)-filled,
+	))
+
+	e.sendAuction(res, trades)
+}
+
+// Uncrosses deals of every ticker which is not halted and expires immediate deals left after it.
+// Caller holds book lock.
+func (e *exchangeService) auction() ([]exchange.Deal, []exchange.Trade) {
+	var (
+		res    []exchange.Deal
+		trades []exchange.Trade
+	)
+
+	for _, ticker := range e.tickers {
+		if _, halted := e.halted(ticker); halted {
+			continue
+		}
+
+		fills, auctioned := e.uncross(ticker)
+		res = append(res, fills...)
+		trades = append(trades, auctioned...)
+		res = append(res, e.expireImmediate(ticker)...)
+	}
+
+	return res, trades
+}
+
+// Sends results and trades of an auction to observers.
+func (e *exchangeService) sendAuction(res []exchange.Deal, trades []exchange.Trade) {
+	for _, deal := range res {
+		e.notify(deal)
+	}
+
+	for _, trade := range trades {
+		e.publish(trade)
+	}
+}
+
+// Checks that ticker accepts deals.
+func (e *exchangeService) accepting(ticker string) error {
+	if status, halted := e.halted(ticker); halted {
+		return fmt.Errorf("%w: trading of %s is halted: %s", exchange.ErrNotAccepted, ticker, status.Reason)
+	}
+
+	if phase := e.phase(e.clock.Now()); phase == exchange.Closed {
+		return fmt.Errorf("%w: session is %s", exchange.ErrNotAccepted, phase)
+	}
+
+	return nil
+}
+
+// Checks that time in force of a deal is served by session schedule. Day deals expire only at session close.
+func (e *exchangeService) checkTimeInForce(deal exchange.Deal) error {
+	if deal.TimeInForce == exchange.Day && e.session.Close <= 0 {
+		return fmt.Errorf("%w: day deal is not served without session close", exchange.ErrNotAccepted)
+	}
+
+	return nil
+}
+
+// Checks that deals of ticker are filled.
+func (e *exchangeService) filling(ticker string) bool {
+	if _, halted := e.halted(ticker); halted {
+		return false
+	}
+
+	return e.phase(e.clock.Now()) == exchange.Continuous
+}
+
+// Returns halt of ticker. Expired halt is lifted.
+func (e *exchangeService) halted(ticker string) (exchange.TradingStatus, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status, ok := e.halts[ticker]
+	if ok && !status.Until.IsZero() && !e.clock.Now().Before(status.Until) {
+		delete(e.halts, ticker)
+		e.logger.Info(sessionAction, fmt.Sprintf("trading of %s is resumed after %s", ticker, status.Reason))
+
+		return exchange.TradingStatus{}, false
+	}
+
+	return status, ok
+}
+
+// Halt halts trading of ticker until it is resumed.
+func (e *exchangeService) Halt(ticker, reason string) (exchange.TradingStatus, error) {
+	if !e.known(ticker) {
+		return exchange.TradingStatus{}, fmt.Errorf("unknown ticker %s", ticker)
+	}
+
+	if reason == "" {
+		reason = "halted by admin"
+	}
+
+	e.mu.Lock()
+	e.halts[ticker] = exchange.TradingStatus{Ticker: ticker, Halted: true, Reason: reason}
+	e.mu.Unlock()
+
+	e.logger.Warn(sessionAction, fmt.Sprintf("trading of %s is halted: %s", ticker, reason))
+
+	return e.status(ticker), nil
+}
+
+// Resume resumes trading of halted ticker.
+func (e *exchangeService) Resume(ticker string) (exchange.TradingStatus, error) {
+	if !e.known(ticker) {
+		return exchange.TradingStatus{}, fmt.Errorf("unknown ticker %s", ticker)
+	}
+
+	e.mu.Lock()
+	delete(e.halts, ticker)
+	delete(e.moves, ticker)
+	e.mu.Unlock()
+
+	e.logger.Info(sessionAction, fmt.Sprintf("trading of %s is resumed", ticker))
+
+	return e.status(ticker), nil
+}
+
+// Trading returns trading status of ticker. Empty ticker means all tickers.
+func (e *exchangeService) Trading(ticker string) []exchange.TradingStatus {
+	var res []exchange.TradingStatus
+
+	for _, t := range e.tickers {
+		if ticker == "" || ticker == t {
+			res = append(res, e.status(t))
+		}
+	}
+
+	return res
+}
+
+// Returns trading status of ticker.
+func (e *exchangeService) status(ticker string) exchange.TradingStatus {
+	status, _ := e.halted(ticker)
+	status.Ticker = ticker
+	status.Phase = e.phase(e.clock.Now())
+
+	return status
+}
+
+// Checks that ticker is traded at exchange.
+func (e *exchangeService) known(ticker string) bool {
+	for _, t := range e.tickers {
+		if t == ticker {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Halts ticker when its price has moved more than allowed within window of circuit breaker.
+func (e *exchangeService) tripBreaker(tick exchange.Tick) bool {
+	if e.breaker.Move <= 0 {
+		return false
+	}
+
+	now := e.clock.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	points := e.moves[tick.Ticker]
+
+	cut := 0
+	for cut < len(points) && now.Sub(points[cut].time) > e.breaker.Window {
+		cut++
+	}
+
+	points = append(points[cut:], pricePoint{time: now, price: tick.Price})
+
+	low, high := tick.Price, tick.Price
+	for _, p := range points {
+		if p.price < low {
+			low = p.price
+		}
+
+		if p.price > high {
+			high = p.price
+		}
+	}
+
+	if move := float64(high-low) / float64(low) * 100; low > 0 && move > e.breaker.Move {
+		delete(e.moves, tick.Ticker)
+
+		reason := fmt.Sprintf("circuit breaker, price moved %.2f%% within %s", move, e.breaker.Window)
+		e.halts[tick.Ticker] = exchange.TradingStatus{
+			Ticker: tick.Ticker,
+			Halted: true,
+			Reason: reason,
+			Until:  now.Add(e.breaker.Halt),
+		}
+
+		e.logger.Warn(sessionAction, fmt.Sprintf("trading of %s is halted till %s: %s",
+			tick.Ticker, now.Add(e.breaker.Halt).Format(time.RFC3339), reason))
+
+		return true
+	}
+
+	e.moves[tick.Ticker] = points
+
+	return false
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Observer of statistic.
+type statObserver struct {
+	subs []exchange.Subscription
+	sub  *subscriber
+}
+
+// Key of a bar.
+type barKey struct {
+	ticker   string
+	interval time.Duration
+}
+
+// Returns sorted unique intervals of bars. Base interval is always included.
+func statIntervals(cfg config.Exchange) []time.Duration {
+	seen := map[time.Duration]bool{cfg.Interval: true}
+	intervals := []time.Duration{cfg.Interval}
+
+	for _, interval := range cfg.Intervals {
+		if interval > 0 && !seen[interval] {
+			seen[interval] = true
+			intervals = append(intervals, interval)
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+
+	return intervals
+}
+
+// Statistic adds observer for statistic of subscribed tickers and intervals.
+// Without subscriptions observer receives bars of all tickers at base interval.
+func (e *exchangeService) Statistic(broker exchange.Broker, subs []exchange.Subscription, ch chan exchange.OHLCV) error {
+	for _, sub := range subs {
+		if sub.Interval != 0 && !e.hasInterval(sub.Interval) {
+			return fmt.Errorf("interval %s is not supported", sub.Interval)
+		}
+	}
+
+	if len(subs) == 0 {
+		subs = []exchange.Subscription{{}}
+	}
+
+	e.mu.Lock()
+	e.statObs[broker] = statObserver{subs: subs, sub: e.subscriber(broker, statStream, statQueue(ch))}
+	e.mu.Unlock()
+
+	return nil
+}
+
+// StatisticUnsubscribe removes observer for statistic.
+func (e *exchangeService) StatisticUnsubscribe(broker exchange.Broker) {
+	e.mu.Lock()
+	obs, ok := e.statObs[broker]
+	delete(e.statObs, broker)
+	e.mu.Unlock()
+
+	if ok {
+		obs.sub.unsubscribe()
+	}
+}
+
+// Checks that bars are built at interval.
+func (e *exchangeService) hasInterval(interval time.Duration) bool {
+	for _, i := range e.intervals {
+		if i == interval {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sends a statistic to subscribers.
+// Bars are built per ticker at every interval and are aligned to the interval boundaries.
+func (e *exchangeService) sendStatistic(ctx context.Context, in chan exchange.Tick) {
+	bars := make(map[barKey]*exchange.OHLCV)
+
+	e.logger.Info(statAction, "started")
+	defer e.logger.Info(statAction, "stopped")
+
+	for {
+		var (
+			t       clock.Timer
+			timeout <-chan time.Time
+		)
+
+		if next, ok := nextClose(bars); ok {
+			t = e.clock.NewTimer(next.Sub(e.clock.Now()))
+			timeout = t.C()
+		}
+
+		select {
+		case <-ctx.Done():
+			if t != nil {
+				t.Stop()
+			}
+
+			return
+		case tick := <-in:
+			e.closeBars(bars, e.clock.Now())
+			e.updateBars(bars, tick)
+		case <-timeout:
+			e.closeBars(bars, e.clock.Now())
+		}
+
+		if t != nil {
+			t.Stop()
+		}
+	}
+}
+
+// Adds tick to bars of its ticker.
+func (e *exchangeService) updateBars(bars map[barKey]*exchange.OHLCV, tick exchange.Tick) {
+	now := e.clock.Now()
+
+	for _, interval := range e.intervals {
+		key := barKey{ticker: tick.Ticker, interval: interval}
+
+		bar, ok := bars[key]
+		if !ok {
+			// Bar without identifier starts by the next tick.
+			id, err := e.nextID()
+			if err != nil {
+				e.logger.Error(statAction, err)
+				continue
+			}
+
+			bar = &exchange.OHLCV{
+				ID:       id,
+				Time:     now.Truncate(interval),
+				Interval: interval,
+				Open:     tick.Price,
+				High:     tick.Price,
+				Low:      tick.Price,
+				Ticker:   tick.Ticker,
+			}
+			bars[key] = bar
+		}
+
+		if tick.Price > bar.High {
+			bar.High = tick.Price
+		}
+
+		if tick.Price < bar.Low {
+			bar.Low = tick.Price
+		}
+
+		bar.Close = tick.Price
+		bar.Volume += tick.Vol
+	}
+}
+
+// Sends and removes bars which are ended by the time.
+func (e *exchangeService) closeBars(bars map[barKey]*exchange.OHLCV, now time.Time) {
+	var closed []exchange.OHLCV
+
+	for key, bar := range bars {
+		if !now.Before(bar.Time.Add(bar.Interval)) {
+			closed = append(closed, *bar)
+			delete(bars, key)
+		}
+	}
+
+	sort.Slice(closed, func(i, j int) bool {
+		if closed[i].Interval != closed[j].Interval {
+			return closed[i].Interval < closed[j].Interval
+		}
+
+		return closed[i].Ticker < closed[j].Ticker
+	})
+
+	for _, bar := range closed {
+		e.notifyStatistic(bar)
+	}
+}
+
+// Sends bar to subscribed observers.
+func (e *exchangeService) notifyStatistic(bar exchange.OHLCV) {
+	var observers []*subscriber
+
+	e.mu.RLock()
+	for _, obs := range e.statObs {
+		if e.subscribed(obs.subs, bar) {
+			observers = append(observers, obs.sub)
+		}
+	}
+	e.mu.RUnlock()
+
+	for _, sub := range observers {
+		e.deliver(sub, bar)
+	}
+}
+
+// Checks that bar matches any of subscriptions.
+func (e *exchangeService) subscribed(subs []exchange.Subscription, bar exchange.OHLCV) bool {
+	for _, sub := range subs {
+		interval := sub.Interval
+		if interval == 0 {
+			interval = e.interval
+		}
+
+		if (sub.Ticker == "" || sub.Ticker == bar.Ticker) && interval == bar.Interval {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the earliest end of bars.
+func nextClose(bars map[barKey]*exchange.OHLCV) (time.Time, bool) {
+	var (
+		next time.Time
+		ok   bool
+	)
+
+	for _, bar := range bars {
+		end := bar.Time.Add(bar.Interval)
+		if !ok || end.Before(next) {
+			next, ok = end, true
+		}
+	}
+
+	return next, ok
+}
@@ -0,0 +1,117 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/repository/memory"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+func TestBarsAreAggregatedAtEveryInterval(t *testing.T) {
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	clk := clock.NewVirtual(start)
+	cfg := config.Exchange{Tickers: []string{"A"}, Interval: time.Second, Intervals: []time.Duration{time.Minute}}
+	service := services.NewExchangeService(logger, clk, memory.NewOrderBook(), nil, nil, cfg)
+
+	bars := make(chan exchange.OHLCV, 10)
+	subs := []exchange.Subscription{{Ticker: "A", Interval: time.Second}, {Ticker: "A", Interval: time.Minute}}
+
+	if err := service.Statistic(exchange.Broker{ID: 1}, subs, bars); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan exchange.Tick)
+	done := make(chan struct{})
+
+	go func() {
+		services.SendStatistic(ctx, service, in)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	send := func(price string, vol int32) {
+		in <- exchange.Tick{Ticker: "A", Price: decimal.MustParse(price), Vol: vol}
+	}
+
+	// Tick of an unsubscribed ticker is taken only after the previous tick is added,
+	// so the clock is not moved under it.
+	sync := func() {
+		in <- exchange.Tick{Ticker: "B", Price: decimal.New(1), Vol: 1}
+	}
+
+	// Clock is moved until the bar comes, since the builder sets its timer concurrently.
+	closeBar := func(at time.Time) exchange.OHLCV {
+		t.Helper()
+
+		deadline := time.After(time.Second)
+		for {
+			clk.Set(at)
+
+			select {
+			case bar := <-bars:
+				return bar
+			case <-time.After(10 * time.Millisecond):
+			case <-deadline:
+				t.Fatalf("no bar is closed at %s", at)
+			}
+		}
+	}
+
+	check := func(bar exchange.OHLCV, want exchange.OHLCV) {
+		t.Helper()
+
+		if bar.Interval != want.Interval || !bar.Time.Equal(want.Time) || bar.Open != want.Open ||
+			bar.High != want.High || bar.Low != want.Low || bar.Close != want.Close || bar.Volume != want.Volume {
+			t.Errorf("got bar %+v, want %+v", bar, want)
+		}
+	}
+
+	send("100", 1)
+	send("103", 2)
+	send("98", 1)
+	sync()
+
+	check(closeBar(start.Add(time.Second)), exchange.OHLCV{
+		Interval: time.Second, Time: start,
+		Open: decimal.New(100), High: decimal.New(103), Low: decimal.New(98), Close: decimal.New(98), Volume: 4,
+	})
+
+	clk.Set(start.Add(30 * time.Second))
+	send("110", 5)
+	sync()
+
+	check(closeBar(start.Add(31*time.Second)), exchange.OHLCV{
+		Interval: time.Second, Time: start.Add(30 * time.Second),
+		Open: decimal.New(110), High: decimal.New(110), Low: decimal.New(110), Close: decimal.New(110), Volume: 5,
+	})
+
+	check(closeBar(start.Add(time.Minute)), exchange.OHLCV{
+		Interval: time.Minute, Time: start,
+		Open: decimal.New(100), High: decimal.New(110), Low: decimal.New(98), Close: decimal.New(110), Volume: 9,
+	})
+}
+
+func TestStatisticRejectsUnknownInterval(t *testing.T) {
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Exchange{Tickers: []string{"A"}, Interval: time.Second}
+	service := services.NewExchangeService(logger, clock.NewVirtual(start), memory.NewOrderBook(), nil, nil, cfg)
+
+	subs := []exchange.Subscription{{Ticker: "A", Interval: time.Hour}}
+	if err := service.Statistic(exchange.Broker{ID: 1}, subs, make(chan exchange.OHLCV)); err == nil {
+		t.Error("subscription to an interval which is not built is accepted")
+	}
+}
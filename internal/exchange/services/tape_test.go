@@ -0,0 +1,77 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/services"
+)
+
+func TestTickFillsArePrintedWithAggressor(t *testing.T) {
+	e := newLiquid(t, config.TickerLiquidity{})
+
+	trades := make(chan exchange.Trade, 10)
+	e.Trades(exchange.Broker{ID: 1}, trades)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan exchange.Tick)
+	done := make(chan struct{})
+
+	go func() {
+		services.CompleteDeals(ctx, e, ticks)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	market := limit(exchange.Buy, 3, "0")
+	market.Type = exchange.Market
+
+	for _, deal := range []exchange.Deal{limit(exchange.Sell, 5, "99"), market} {
+		if _, err := e.Create(deal); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ticks <- tick("100")
+
+	// Market deal takes the tick, resting limit deal is taken by the opposite side.
+	want := map[exchange.Side]int32{exchange.Buy: 8}
+	got := make(map[exchange.Side]int32)
+
+	for i := 0; i < 2; i++ {
+		trade := nextTrade(t, trades)
+		if trade.Price != decimal.New(100) || trade.ID == 0 || trade.Time.IsZero() {
+			t.Errorf("got trade %+v, want it at 100 with identifier and time", trade)
+		}
+
+		got[trade.Side] += trade.Amount
+	}
+
+	if len(got) != len(want) || got[exchange.Buy] != want[exchange.Buy] {
+		t.Errorf("got volumes by aggressor %v, want %v", got, want)
+	}
+
+	e.TradesUnsubscribe(exchange.Broker{ID: 1})
+
+	if _, err := e.Create(limit(exchange.Sell, 1, "99")); err != nil {
+		t.Fatal(err)
+	}
+
+	ticks <- tick("100")
+
+	select {
+	case trade, ok := <-trades:
+		if ok {
+			t.Errorf("trade %+v is printed after unsubscription", trade)
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -1,129 +1,146 @@
 package services
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
-	"strconv"
-	"strings"
 	"time"
 
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
 	"github.com/marksartdev/trading/internal/exchange"
 	"github.com/marksartdev/trading/internal/log"
 )
 
+// Types of tick sources.
 const (
-	timeIdx = 3
-	lastIdx = 4
-	volIdx  = 5
+	csvSource   = "csv"
+	jsonlSource = "jsonl"
+	gbmSource   = "gbm"
 )
 
+// Error of a source which can not be read further, e.g. a line which is too long.
+var errBrokenSource = errors.New("tick source is broken")
+
+// TickReader reader of ticks from a source.
+type TickReader interface {
+	// Read returns next tick and its time. It returns io.EOF at the end of the source.
+	// Error of an invalid line skips the line, error of the source itself ends reading.
+	Read() (exchange.Tick, time.Time, error)
+	Close() error
+}
+
 // Service for working with ticks.
 type tickService struct {
 	logger log.Logger
+	clock  clock.Clock
+	cfg    config.Exchange
 }
 
 // NewTickService creates new tick service.
-func NewTickService(logger log.Logger) exchange.TickService {
-	return &tickService{logger: logger}
+func NewTickService(logger log.Logger, clk clock.Clock, cfg config.Exchange) exchange.TickService {
+	return &tickService{logger: logger, clock: clk, cfg: cfg}
 }
 
-// StartReading starts reading ticks from a file and sending it to channel.
+// StartReading starts reading ticks from a ticker source and sending it to channel.
 func (t *tickService) StartReading(ctx context.Context, ticker string, out chan exchange.Tick) {
-	f, err := os.Open(filepath.Join("assets", fmt.Sprintf("%s.txt", ticker)))
+	reader, err := OpenTickReader(ticker, t.cfg, t.clock.Now())
 	if err != nil {
 		t.logger.Error(mainAction, err)
 		return
 	}
 	defer func() {
-		_ = f.Close()
+		_ = reader.Close()
 	}()
 
-	scanner := bufio.NewScanner(f)
-	t.start(ctx, ticker, scanner, out)
+	t.start(ctx, ticker, reader, out)
 }
 
-// Starts reading ticks from a file and sending it to channel.
-func (t *tickService) start(ctx context.Context, ticker string, scanner *bufio.Scanner, out chan exchange.Tick) {
-	timeTicker := time.NewTicker(time.Second)
-	defer timeTicker.Stop()
+// OpenTickReader opens reader of a ticker source. Tickers without source are read from Finam files in assets.
+// Ticks without date are placed on a given day, synthetic prices are rounded to tick size of the ticker.
+func OpenTickReader(ticker string, cfg config.Exchange, day time.Time) (TickReader, error) {
+	source := cfg.Sources[ticker]
 
-	now := time.Now().Format("150405")
+	switch source.Type {
+	case "", csvSource:
+		if source.Path == "" {
+			source.Path = filepath.Join("assets", fmt.Sprintf("%s.txt", ticker))
+		}
 
-	var (
-		buffer []string
-		data   []string
-	)
+		return newCSVReader(ticker, source, day)
+	case jsonlSource:
+		return newJSONLReader(ticker, source, day)
+	case gbmSource:
+		return newGBMReader(ticker, source, tickerTickSize(cfg.TickSize, ticker), day), nil
+	default:
+		return nil, fmt.Errorf("unknown tick source %q of ticker %s", source.Type, ticker)
+	}
+}
+
+// Starts reading ticks from a source and sending it to channel.
+// Every tick is sent when the clock reaches its time.
+func (t *tickService) start(ctx context.Context, ticker string, reader TickReader, out chan exchange.Tick) {
+	now := t.clock.Now().Truncate(time.Second)
 
 	t.logger.Info(log.Action(ticker), "started")
 	defer t.logger.Info(log.Action(ticker), "stopped")
 
-	// Skip headers.
-	if scanner.Scan() {
-		scanner.Text()
+	// Skip all past ticks.
+	tick, tickTime, ok := t.next(ticker, reader)
+	for ok && tickTime.Before(now) {
+		tick, tickTime, ok = t.next(ticker, reader)
 	}
 
-	for scanner.Scan() {
-		if buffer != nil {
-			data = buffer
-			buffer = nil
-		} else {
-			data = strings.Split(scanner.Text(), ",")
-		}
-
-		// Skip all past ticks.
-		if strings.TrimSpace(data[timeIdx]) < now {
-			continue
-		}
+	for ok {
+		timer := t.clock.NewTimer(tickTime.Sub(t.clock.Now()))
 
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return
-		case <-timeTicker.C:
-			firstTickTime := strings.TrimSpace(data[timeIdx])
-			if err := t.processTick(data, ticker, out); err != nil {
-				t.logger.Error(log.Action(ticker), err)
-				continue
+		case <-timer.C():
+			firstTickTime := tickTime
+
+			for ok && tickTime.Equal(firstTickTime) {
+				out <- tick
+				tick, tickTime, ok = t.next(ticker, reader)
 			}
+		}
+	}
+}
 
-			for scanner.Scan() {
-				data = strings.Split(scanner.Text(), ",")
-				tickTime := strings.TrimSpace(data[timeIdx])
+// Returns next valid tick of a source. Invalid ticks are logged and skipped, broken source ends reading.
+func (t *tickService) next(ticker string, reader TickReader) (exchange.Tick, time.Time, bool) {
+	for {
+		tick, tickTime, err := reader.Read()
+		if err == nil {
+			return tick, tickTime, true
+		}
 
-				if tickTime != firstTickTime {
-					buffer = data
-					break
-				}
+		if errors.Is(err, io.EOF) {
+			return exchange.Tick{}, time.Time{}, false
+		}
 
-				if err := t.processTick(data, ticker, out); err != nil {
-					t.logger.Error(log.Action(ticker), err)
-					continue
-				}
-			}
+		t.logger.Error(log.Action(ticker), err)
+
+		if errors.Is(err, errBrokenSource) {
+			return exchange.Tick{}, time.Time{}, false
 		}
 	}
 }
 
-// Processes tick.
-func (t *tickService) processTick(data []string, ticker string, out chan exchange.Tick) error {
-	price, err := strconv.ParseFloat(data[lastIdx], 64)
+// Returns time of a tick. Time without date is placed on a given day.
+func tickTime(layout, value string, day time.Time) (time.Time, error) {
+	tm, err := time.ParseInLocation(layout, value, day.Location())
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
-	vol, err := strconv.ParseInt(data[volIdx], 10, 32)
-	if err != nil {
-		return err
+	if tm.Year() == 0 {
+		tm = tm.AddDate(day.Year(), int(day.Month())-1, day.Day()-1)
 	}
 
-	out <- exchange.Tick{Ticker: ticker, Price: price, Vol: int32(vol)}
-
-	return nil
-}
-
-// Wraps message.
-func (t *tickService) wrapMsg(detail, msg string) string {
-	return fmt.Sprintf("Tick service (%s): %s", detail, msg)
+	return tm, nil
 }
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Default layout of Finam files.
+const (
+	timeIdx    = 3
+	lastIdx    = 4
+	volIdx     = 5
+	timeLayout = "150405"
+)
+
+// Reader of ticks from a CSV file with configurable columns.
+type csvReader struct {
+	ticker    string
+	day       time.Time
+	file      *os.File
+	scanner   *bufio.Scanner
+	delimiter string
+	layout    string
+	timeIdx   int
+	priceIdx  int
+	volIdx    int
+}
+
+// Creates new CSV reader.
+func newCSVReader(ticker string, source config.TickSource, day time.Time) (*csvReader, error) {
+	f, err := os.Open(source.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &csvReader{
+		ticker:    ticker,
+		day:       day,
+		file:      f,
+		scanner:   bufio.NewScanner(f),
+		delimiter: source.Delimiter,
+		layout:    source.TimeLayout,
+		timeIdx:   column(source.TimeColumn, timeIdx),
+		priceIdx:  column(source.PriceColumn, lastIdx),
+		volIdx:    column(source.VolumeColumn, volIdx),
+	}
+
+	if r.delimiter == "" {
+		r.delimiter = ","
+	}
+
+	if r.layout == "" {
+		r.layout = timeLayout
+	}
+
+	// Skip headers.
+	if source.Header == nil || *source.Header {
+		r.scanner.Scan()
+	}
+
+	return r, nil
+}
+
+// Read returns next tick of a file.
+func (c *csvReader) Read() (exchange.Tick, time.Time, error) {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return exchange.Tick{}, time.Time{}, fmt.Errorf("%w: %s", errBrokenSource, err)
+		}
+
+		return exchange.Tick{}, time.Time{}, io.EOF
+	}
+
+	data := strings.Split(c.scanner.Text(), c.delimiter)
+	if len(data) <= c.timeIdx || len(data) <= c.priceIdx || len(data) <= c.volIdx {
+		return exchange.Tick{}, time.Time{}, fmt.Errorf("not enough columns in line %q", c.scanner.Text())
+	}
+
+	tm, err := tickTime(c.layout, strings.TrimSpace(data[c.timeIdx]), c.day)
+	if err != nil {
+		return exchange.Tick{}, time.Time{}, err
+	}
+
+	price, err := decimal.Parse(data[c.priceIdx])
+	if err != nil {
+		return exchange.Tick{}, time.Time{}, err
+	}
+
+	vol, err := strconv.ParseInt(strings.TrimSpace(data[c.volIdx]), 10, 32)
+	if err != nil {
+		return exchange.Tick{}, time.Time{}, err
+	}
+
+	return exchange.Tick{Ticker: c.ticker, Price: price, Vol: int32(vol)}, tm, nil
+}
+
+// Close closes file.
+func (c *csvReader) Close() error {
+	return c.file.Close()
+}
+
+// Returns configured column or default one.
+func column(idx *int, def int) int {
+	if idx == nil {
+		return def
+	}
+
+	return *idx
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Defaults of synthetic ticks.
+const (
+	gbmPrice  = 100
+	gbmVolume = 10
+	gbmStep   = time.Second
+	year      = 365 * 24 * time.Hour
+)
+
+// Price step of synthetic ticks of a ticker without tick size.
+var gbmTickSize = decimal.MustParse("0.01")
+
+// Generator of synthetic ticks by geometric Brownian motion.
+// Drift and volatility are annual, so the same seed always gives the same prices.
+type gbmReader struct {
+	ticker     string
+	rnd        *rand.Rand
+	price      float64
+	tickSize   decimal.Decimal
+	drift      float64
+	volatility float64
+	volume     int32
+	step       time.Duration
+	time       time.Time
+}
+
+// Creates new generator of synthetic ticks. Prices are rounded to tick size.
+func newGBMReader(ticker string, source config.TickSource, tickSize decimal.Decimal, start time.Time) *gbmReader {
+	r := &gbmReader{
+		ticker:     ticker,
+		rnd:        rand.New(rand.NewSource(source.Seed)),
+		price:      source.Price,
+		tickSize:   tickSize,
+		drift:      source.Drift,
+		volatility: source.Volatility,
+		volume:     source.Volume,
+		step:       source.Step,
+		time:       start.Truncate(time.Second),
+	}
+
+	if r.price <= 0 {
+		r.price = gbmPrice
+	}
+
+	if r.volume <= 0 {
+		r.volume = gbmVolume
+	}
+
+	if r.step <= 0 {
+		r.step = gbmStep
+	}
+
+	if r.tickSize <= 0 {
+		r.tickSize = gbmTickSize
+	}
+
+	return r
+}
+
+// Read returns next synthetic tick. The generator never ends.
+func (g *gbmReader) Read() (exchange.Tick, time.Time, error) {
+	dt := g.step.Seconds() / year.Seconds()
+	g.price *= math.Exp((g.drift-g.volatility*g.volatility/2)*dt + g.volatility*math.Sqrt(dt)*g.rnd.NormFloat64())
+	g.time = g.time.Add(g.step)
+
+	tick := exchange.Tick{
+		Ticker: g.ticker,
+		Price:  decimal.FromFloat(g.price).RoundTo(g.tickSize),
+		Vol:    g.rnd.Int31n(g.volume) + 1,
+	}
+
+	return tick, g.time, nil
+}
+
+// Close does nothing.
+func (g *gbmReader) Close() error {
+	return nil
+}
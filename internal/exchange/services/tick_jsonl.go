@@ -0,0 +1,84 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+)
+
+// Tick line of a JSON-lines file.
+type jsonlTick struct {
+	Time   string          `json:"time"`
+	Price  decimal.Decimal `json:"price"`
+	Volume int32           `json:"volume"`
+}
+
+// Reader of ticks from a JSON-lines file.
+type jsonlReader struct {
+	ticker  string
+	day     time.Time
+	file    *os.File
+	scanner *bufio.Scanner
+	layout  string
+}
+
+// Creates new JSON-lines reader.
+func newJSONLReader(ticker string, source config.TickSource, day time.Time) (*jsonlReader, error) {
+	f, err := os.Open(source.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &jsonlReader{
+		ticker:  ticker,
+		day:     day,
+		file:    f,
+		scanner: bufio.NewScanner(f),
+		layout:  source.TimeLayout,
+	}
+
+	if r.layout == "" {
+		r.layout = time.RFC3339
+	}
+
+	return r, nil
+}
+
+// Read returns next tick of a file. Empty lines are skipped.
+func (j *jsonlReader) Read() (exchange.Tick, time.Time, error) {
+	for j.scanner.Scan() {
+		if len(j.scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var line jsonlTick
+		if err := json.Unmarshal(j.scanner.Bytes(), &line); err != nil {
+			return exchange.Tick{}, time.Time{}, err
+		}
+
+		tm, err := tickTime(j.layout, line.Time, j.day)
+		if err != nil {
+			return exchange.Tick{}, time.Time{}, err
+		}
+
+		return exchange.Tick{Ticker: j.ticker, Price: line.Price, Vol: line.Volume}, tm, nil
+	}
+
+	if err := j.scanner.Err(); err != nil {
+		return exchange.Tick{}, time.Time{}, fmt.Errorf("%w: %s", errBrokenSource, err)
+	}
+
+	return exchange.Tick{}, time.Time{}, io.EOF
+}
+
+// Close closes file.
+func (j *jsonlReader) Close() error {
+	return j.file.Close()
+}
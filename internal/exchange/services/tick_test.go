@@ -0,0 +1,126 @@
+package services_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marksartdev/trading/internal/clock"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/exchange"
+	"github.com/marksartdev/trading/internal/exchange/services"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+func TestReadingStopsAtBrokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "A.jsonl")
+	line := `{"time":"` + strings.Repeat("1", 100000) + `"}` + "\n"
+
+	if err := os.WriteFile(path, []byte(line), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Exchange{Sources: map[string]config.TickSource{"A": {Type: "jsonl", Path: path}}}
+	service := services.NewTickService(logger, clock.NewVirtual(start), cfg)
+
+	done := make(chan struct{})
+	go func() {
+		service.StartReading(context.Background(), "A", make(chan exchange.Tick, 1))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reading of broken source does not stop")
+	}
+}
+
+func TestReplaySendsTicksAtClockTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "A.jsonl")
+	lines := []string{
+		`{"time":"2021-03-01T09:59:59Z","price":99,"volume":1}`,
+		`{"time":"2021-03-01T10:00:01Z","price":101,"volume":1}`,
+		`{"time":"2021-03-01T10:00:01Z","price":102,"volume":1}`,
+		`{"time":"2021-03-01T10:00:05Z","price":105,"volume":1}`,
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	clk := clock.NewVirtual(start)
+	cfg := config.Exchange{Sources: map[string]config.TickSource{"A": {Type: "jsonl", Path: path}}}
+	service := services.NewTickService(logger, clk, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := make(chan exchange.Tick, 10)
+	go service.StartReading(ctx, "A", out)
+
+	// Clock is moved until the tick comes, since the reader sets its timer concurrently.
+	receive := func(at time.Time) (exchange.Tick, bool) {
+		deadline := time.After(time.Second)
+		for {
+			clk.Set(at)
+
+			select {
+			case tick := <-out:
+				return tick, true
+			case <-time.After(10 * time.Millisecond):
+			case <-deadline:
+				return exchange.Tick{}, false
+			}
+		}
+	}
+
+	for _, want := range []string{"101", "102"} {
+		if tick, ok := receive(start.Add(time.Second)); !ok || tick.Price != decimal.MustParse(want) {
+			t.Fatalf("got tick %v, want price %s", tick, want)
+		}
+	}
+
+	clk.Set(start.Add(4 * time.Second))
+
+	select {
+	case tick := <-out:
+		t.Fatalf("tick at %s is sent early", tick.Price)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if tick, ok := receive(start.Add(5 * time.Second)); !ok || tick.Price != decimal.New(105) {
+		t.Fatalf("got tick %v, want price 105", tick)
+	}
+}
+
+func TestSyntheticPricesAreRoundedToTickSize(t *testing.T) {
+	cfg := config.Exchange{
+		Sources:  map[string]config.TickSource{"A": {Type: "gbm", Seed: 1, Volatility: 5}},
+		TickSize: config.TickSize{Tickers: map[string]decimal.Decimal{"A": decimal.MustParse("0.25")}},
+	}
+
+	reader, err := services.OpenTickReader("A", cfg, time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		tick, _, err := reader.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !tick.Price.Multiple(decimal.MustParse("0.25")) {
+			t.Fatalf("tick %d: price %s is not a multiple of tick size", i, tick.Price)
+		}
+	}
+}
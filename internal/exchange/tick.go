@@ -1,11 +1,15 @@
 package exchange
 
-import "context"
+import (
+	"context"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
 
 // Tick stock exchange tick.
 type Tick struct {
 	Ticker string
-	Price  float64
+	Price  decimal.Decimal
 	Vol    int32
 }
 
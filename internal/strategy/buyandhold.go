@@ -0,0 +1,39 @@
+package strategy
+
+const buyAndHoldName = "buy_and_hold"
+
+// Strategy which buys every ticker once and holds it.
+type buyAndHold struct {
+	amount int32
+	bought map[string]bool
+}
+
+// NewBuyAndHold creates strategy which buys an amount of every ticker by market on its first bar.
+func NewBuyAndHold(amount int32) Strategy {
+	if amount <= 0 {
+		amount = 1
+	}
+
+	return &buyAndHold{amount: amount, bought: make(map[string]bool)}
+}
+
+// Name returns name of strategy.
+func (b *buyAndHold) Name() string {
+	return buyAndHoldName
+}
+
+// OnBar buys ticker on its first bar.
+func (b *buyAndHold) OnBar(bar Bar) []Order {
+	if b.bought[bar.Ticker] {
+		return nil
+	}
+
+	b.bought[bar.Ticker] = true
+
+	return []Order{{Ticker: bar.Ticker, Side: Buy, Type: Market, Amount: b.amount}}
+}
+
+// OnFill does nothing.
+func (b *buyAndHold) OnFill(Fill) []Order {
+	return nil
+}
@@ -0,0 +1,100 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+const meanReversionName = "mean_reversion"
+
+// Default parameters of mean reversion.
+const (
+	defaultWindow    = 20
+	defaultThreshold = 2
+)
+
+// Strategy which buys when price falls below the mean by a number of standard deviations
+// and closes position when price returns to the mean.
+type meanReversion struct {
+	amount    int32
+	window    int
+	threshold float64
+	closes    map[string][]decimal.Decimal
+	positions positions
+}
+
+// NewMeanReversion creates mean reversion strategy.
+func NewMeanReversion(amount int32, window int, threshold float64) Strategy {
+	if amount <= 0 {
+		amount = 1
+	}
+
+	if window <= 1 {
+		window = defaultWindow
+	}
+
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+
+	return &meanReversion{
+		amount:    amount,
+		window:    window,
+		threshold: threshold,
+		closes:    make(map[string][]decimal.Decimal),
+		positions: make(positions),
+	}
+}
+
+// Name returns name of strategy.
+func (m *meanReversion) Name() string {
+	return meanReversionName
+}
+
+// OnBar compares close price with the band around the mean.
+func (m *meanReversion) OnBar(bar Bar) []Order {
+	closes := append(m.closes[bar.Ticker], bar.Close)
+	if len(closes) > m.window {
+		closes = closes[len(closes)-m.window:]
+	}
+
+	m.closes[bar.Ticker] = closes
+
+	pos := m.positions[bar.Ticker]
+	if len(closes) < m.window || pos.pending {
+		return nil
+	}
+
+	avg := mean(closes)
+
+	if pos.amount == 0 && bar.Close < avg-deviation(closes, avg).MulFloat(m.threshold) {
+		m.positions.open(bar.Ticker)
+		return []Order{{Ticker: bar.Ticker, Side: Buy, Type: Market, Amount: m.amount}}
+	}
+
+	if pos.amount > 0 && bar.Close >= avg {
+		m.positions.open(bar.Ticker)
+		return []Order{{Ticker: bar.Ticker, Side: Sell, Type: Market, Amount: pos.amount}}
+	}
+
+	return nil
+}
+
+// OnFill updates position.
+func (m *meanReversion) OnFill(fill Fill) []Order {
+	m.positions.fill(fill)
+	return nil
+}
+
+// Returns standard deviation of values. Squares of prices do not fit decimal, so variance is calculated in floats.
+func deviation(values []decimal.Decimal, avg decimal.Decimal) decimal.Decimal {
+	var sum float64
+
+	for _, v := range values {
+		d := (v - avg).Float64()
+		sum += d * d
+	}
+
+	return decimal.FromFloat(math.Sqrt(sum / float64(len(values))))
+}
@@ -0,0 +1,257 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/marksartdev/trading/internal/broker/delivery/rpc"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+const (
+	timeout     = 5 * time.Second
+	defaultPoll = 5 * time.Second
+	dealStatNew = "NEW"
+)
+
+// MaxRetries maximum rounds of orders placed by strategy in response to rejected ones.
+const MaxRetries = 3
+
+const (
+	mainAction  log.Action = "main"
+	barsAction  log.Action = "bars"
+	fillsAction log.Action = "fills"
+	orderAction log.Action = "order"
+)
+
+// Runner runs strategy against broker.
+type Runner interface {
+	Start()
+	Stop()
+}
+
+// Strategy runner. It polls broker for new bars and fills of created deals.
+type runner struct {
+	logger   log.Logger
+	client   rpc.BrokerClient
+	strategy Strategy
+	cfg      config.Strategy
+	last     map[string]int64
+	deals    map[int64]*rpc.Deal
+	cancel   context.CancelFunc
+}
+
+// NewRunner creates new strategy runner.
+func NewRunner(logger log.Logger, client rpc.BrokerClient, strategy Strategy, cfg config.Strategy) Runner {
+	if cfg.Poll <= 0 {
+		cfg.Poll = defaultPoll
+	}
+
+	return &runner{
+		logger:   logger,
+		client:   client,
+		strategy: strategy,
+		cfg:      cfg,
+		last:     make(map[string]int64),
+		deals:    make(map[int64]*rpc.Deal),
+	}
+}
+
+// Start runs strategy.
+func (r *runner) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.cancel = cancel
+
+	t := time.NewTicker(r.cfg.Poll)
+	defer t.Stop()
+
+	r.logger.Info(mainAction, fmt.Sprintf("started %s for %s", r.strategy.Name(), r.cfg.Login))
+
+	for {
+		r.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info(mainAction, "stopped")
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Stop stops strategy.
+func (r *runner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+		return
+	}
+
+	r.logger.Error(mainAction, fmt.Errorf("cancel func dose not initialized"))
+}
+
+// Passes new bars and fills to strategy.
+func (r *runner) poll(ctx context.Context) {
+	for _, ticker := range r.cfg.Tickers {
+		r.pollBars(ctx, ticker)
+	}
+
+	r.pollFills(ctx)
+}
+
+// Passes new bars of ticker to strategy.
+// Bars received on the first poll only warm up strategy, its orders are dropped.
+func (r *runner) pollBars(ctx context.Context, ticker string) {
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := r.client.Statistic(reqCtx, &rpc.Ticker{
+		Client:   &rpc.Client{Login: r.cfg.Login},
+		Name:     ticker,
+		Interval: r.cfg.Interval.Nanoseconds(),
+	})
+	if err != nil {
+		r.logger.Error(barsAction, err)
+		return
+	}
+
+	prices := resp.GetPrices()
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].GetTime() < prices[j].GetTime()
+	})
+
+	last, warm := r.last[ticker]
+	r.last[ticker] = last
+
+	for _, price := range prices {
+		if warm && price.GetTime() <= last {
+			continue
+		}
+
+		orders := r.strategy.OnBar(Bar{
+			Ticker:   ticker,
+			Time:     time.Unix(price.GetTime(), 0),
+			Interval: time.Duration(price.GetInterval()),
+			Open:     price.GetOpen().Decimal(),
+			High:     price.GetHigh().Decimal(),
+			Low:      price.GetLow().Decimal(),
+			Close:    price.GetClose().Decimal(),
+			Volume:   price.GetVol(),
+		})
+
+		r.submit(ctx, orders, warm)
+
+		r.last[ticker] = price.GetTime()
+	}
+}
+
+// Passes fills of created deals to strategy.
+func (r *runner) pollFills(ctx context.Context) {
+	ids := make([]int64, 0, len(r.deals))
+	for id := range r.deals {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		prev := r.deals[id]
+
+		reqCtx, cancel := context.WithTimeout(ctx, timeout)
+		deal, err := r.client.GetDeal(reqCtx, &rpc.DealRequest{
+			Client: &rpc.Client{Login: r.cfg.Login},
+			DealID: &rpc.DealID{ID: id},
+		})
+		cancel()
+
+		if err != nil {
+			r.logger.Error(fillsAction, err)
+			continue
+		}
+
+		open := deal.GetStatus() == dealStatNew
+		if !open {
+			delete(r.deals, id)
+		} else {
+			r.deals[id] = deal
+		}
+
+		fill := Fill{
+			DealID:  id,
+			Ticker:  deal.GetTicker(),
+			Side:    Side(deal.GetType()),
+			Amount:  deal.GetFilled() - prev.GetFilled(),
+			Partial: open,
+			Time:    time.Now(),
+		}
+
+		if fill.Amount > 0 {
+			// Price of the new fills is derived from the change of the average price.
+			cost := deal.GetAvgPrice().Decimal().Mul(deal.GetFilled()) - prev.GetAvgPrice().Decimal().Mul(prev.GetFilled())
+			fill.Price = cost.Div(int64(fill.Amount))
+		} else if open {
+			continue
+		}
+
+		r.submit(ctx, r.strategy.OnFill(fill), true)
+	}
+}
+
+// Creates deals of the strategy. Rejected orders are reported to strategy and
+// orders placed in response are retried for at most MaxRetries rounds.
+// Until warm-up is complete (live is false) all orders are rejected without placement.
+func (r *runner) submit(ctx context.Context, orders []Order, live bool) {
+	for round := 0; len(orders) > 0; round++ {
+		if round > MaxRetries {
+			r.logger.Warn(orderAction, fmt.Sprintf("%d orders dropped after %d retries", len(orders), MaxRetries))
+			return
+		}
+
+		var retry []Order
+
+		for _, order := range orders {
+			if live && r.create(ctx, order) {
+				continue
+			}
+
+			retry = append(retry, r.reject(order)...)
+		}
+
+		orders = retry
+	}
+}
+
+// Creates deal of the order. Returns false if broker rejects it.
+func (r *runner) create(ctx context.Context, order Order) bool {
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	resp, err := r.client.Create(reqCtx, &rpc.CreateDeal{
+		Client:    &rpc.Client{Login: r.cfg.Login},
+		Ticker:    order.Ticker,
+		Type:      string(order.Side),
+		OrderType: string(order.Type),
+		Amount:    order.Amount,
+		Price:     rpc.NewDecimal(order.Price),
+		StopPrice: rpc.NewDecimal(order.StopPrice),
+	})
+	cancel()
+
+	if err != nil {
+		r.logger.Error(orderAction, err)
+		return false
+	}
+
+	r.deals[resp.GetID()] = &rpc.Deal{ID: resp.GetID(), Ticker: order.Ticker, Type: string(order.Side)}
+	r.logger.Info(orderAction, fmt.Sprintf("deal %d: %s %d %s", resp.GetID(), order.Side, order.Amount, order.Ticker))
+
+	return true
+}
+
+// Reports to strategy that order is closed without execution and returns its new orders.
+func (r *runner) reject(order Order) []Order {
+	return r.strategy.OnFill(Fill{Ticker: order.Ticker, Side: order.Side, Time: time.Now()})
+}
@@ -0,0 +1,122 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/marksartdev/trading/internal/broker/delivery/rpc"
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+	"github.com/marksartdev/trading/internal/log"
+)
+
+// Broker which serves fixed bars and rejects or accepts every deal.
+type fakeBroker struct {
+	rpc.BrokerClient
+	prices  []*rpc.Price
+	reject  bool
+	created int
+}
+
+func (f *fakeBroker) Statistic(context.Context, *rpc.Ticker, ...grpc.CallOption) (*rpc.OHLCV, error) {
+	return &rpc.OHLCV{Prices: f.prices}, nil
+}
+
+func (f *fakeBroker) Create(context.Context, *rpc.CreateDeal, ...grpc.CallOption) (*rpc.DealID, error) {
+	f.created++
+	if f.reject {
+		return nil, errors.New("rejected")
+	}
+
+	return &rpc.DealID{ID: int64(f.created)}, nil
+}
+
+// Strategy which places an order on every bar and again on every rejection.
+type stubborn struct {
+	rejects int
+}
+
+func (s *stubborn) Name() string { return "stubborn" }
+
+func (s *stubborn) OnBar(bar Bar) []Order {
+	return []Order{{Ticker: bar.Ticker, Side: Buy, Type: Market, Amount: 1}}
+}
+
+func (s *stubborn) OnFill(fill Fill) []Order {
+	s.rejects++
+	return []Order{{Ticker: fill.Ticker, Side: Buy, Type: Market, Amount: 1}}
+}
+
+func newTestRunner(client rpc.BrokerClient, s Strategy) *runner {
+	logger := log.NewLogger(zap.NewNop().Sugar(), "test", log.Clean())
+	cfg := config.Strategy{Login: "test", Tickers: []string{"SPFB.RTS"}}
+
+	return NewRunner(logger, client, s, cfg).(*runner)
+}
+
+func bars(closes ...int64) []*rpc.Price {
+	prices := make([]*rpc.Price, 0, len(closes))
+	for i, c := range closes {
+		prices = append(prices, &rpc.Price{Time: int64(i + 1), Close: rpc.NewDecimal(decimal.New(c))})
+	}
+
+	return prices
+}
+
+func TestWarmUpDoesNotPlaceOrders(t *testing.T) {
+	client := &fakeBroker{prices: bars(1, 2, 3)}
+	s := &stubborn{}
+	r := newTestRunner(client, s)
+
+	r.pollBars(context.Background(), "SPFB.RTS")
+
+	if client.created != 0 {
+		t.Fatalf("%d deals created during warm-up", client.created)
+	}
+
+	if want := 3 * (MaxRetries + 1); s.rejects != want {
+		t.Fatalf("got %d rejects, want %d", s.rejects, want)
+	}
+}
+
+func TestRetriesAreBounded(t *testing.T) {
+	client := &fakeBroker{reject: true}
+	s := &stubborn{}
+	r := newTestRunner(client, s)
+
+	r.submit(context.Background(), []Order{{Ticker: "SPFB.RTS", Side: Buy, Type: Market, Amount: 1}}, true)
+
+	if want := MaxRetries + 1; client.created != want {
+		t.Fatalf("got %d attempts, want %d", client.created, want)
+	}
+}
+
+func TestSMAFirstWindowIsNotCross(t *testing.T) {
+	s := NewSMACrossover(1, 2, 4)
+
+	for i, c := range []int64{1, 2, 3, 4, 5} {
+		if orders := s.OnBar(Bar{Ticker: "SPFB.RTS", Close: decimal.New(c)}); len(orders) != 0 {
+			t.Fatalf("bar %d: unexpected orders %v", i, orders)
+		}
+	}
+
+	for _, c := range []int64{4, 3, 2} {
+		s.OnBar(Bar{Ticker: "SPFB.RTS", Close: decimal.New(c)})
+	}
+
+	for _, c := range []int64{5, 8} {
+		if orders := s.OnBar(Bar{Ticker: "SPFB.RTS", Close: decimal.New(c)}); len(orders) != 0 {
+			if orders[0].Side != Buy {
+				t.Fatalf("got %s on upward cross", orders[0].Side)
+			}
+
+			return
+		}
+	}
+
+	t.Fatal("upward cross is not signalled")
+}
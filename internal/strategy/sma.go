@@ -0,0 +1,106 @@
+package strategy
+
+import "github.com/marksartdev/trading/internal/decimal"
+
+const smaCrossoverName = "sma_crossover"
+
+// Default windows of moving averages.
+const (
+	defaultFast = 10
+	defaultSlow = 30
+)
+
+// Strategy which goes long when fast moving average crosses slow one upwards
+// and closes position when it crosses downwards.
+type smaCrossover struct {
+	amount    int32
+	fast      int
+	slow      int
+	closes    map[string][]decimal.Decimal
+	above     map[string]bool
+	positions positions
+}
+
+// NewSMACrossover creates strategy of simple moving averages crossover.
+func NewSMACrossover(amount int32, fast, slow int) Strategy {
+	if amount <= 0 {
+		amount = 1
+	}
+
+	if fast <= 0 {
+		fast = defaultFast
+	}
+
+	if slow <= fast {
+		slow = fast * defaultSlow / defaultFast
+	}
+
+	return &smaCrossover{
+		amount:    amount,
+		fast:      fast,
+		slow:      slow,
+		closes:    make(map[string][]decimal.Decimal),
+		above:     make(map[string]bool),
+		positions: make(positions),
+	}
+}
+
+// Name returns name of strategy.
+func (s *smaCrossover) Name() string {
+	return smaCrossoverName
+}
+
+// OnBar checks crossover of moving averages.
+func (s *smaCrossover) OnBar(bar Bar) []Order {
+	closes := append(s.closes[bar.Ticker], bar.Close)
+	if len(closes) > s.slow {
+		closes = closes[len(closes)-s.slow:]
+	}
+
+	s.closes[bar.Ticker] = closes
+
+	if len(closes) < s.slow {
+		return nil
+	}
+
+	above := mean(closes[len(closes)-s.fast:]) > mean(closes)
+	prev, seeded := s.above[bar.Ticker]
+	s.above[bar.Ticker] = above
+
+	// The first full window only seeds the state, there is no cross yet.
+	crossed := seeded && above != prev
+
+	pos := s.positions[bar.Ticker]
+	if !crossed || pos.pending {
+		return nil
+	}
+
+	if above && pos.amount <= 0 {
+		s.positions.open(bar.Ticker)
+		return []Order{{Ticker: bar.Ticker, Side: Buy, Type: Market, Amount: s.amount - pos.amount}}
+	}
+
+	if !above && pos.amount > 0 {
+		s.positions.open(bar.Ticker)
+		return []Order{{Ticker: bar.Ticker, Side: Sell, Type: Market, Amount: pos.amount}}
+	}
+
+	return nil
+}
+
+// OnFill updates position.
+func (s *smaCrossover) OnFill(fill Fill) []Order {
+	s.positions.fill(fill)
+	return nil
+}
+
+// Returns mean of values.
+func mean(values []decimal.Decimal) decimal.Decimal {
+	var sum decimal.Decimal
+
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum.Div(int64(len(values)))
+}
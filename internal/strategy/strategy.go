@@ -0,0 +1,118 @@
+package strategy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marksartdev/trading/internal/config"
+	"github.com/marksartdev/trading/internal/decimal"
+)
+
+// Side side of order.
+type Side string
+
+const (
+	// Buy purchase.
+	Buy Side = "BUY"
+	// Sell sale.
+	Sell Side = "SELL"
+)
+
+// OrderType type of order.
+type OrderType string
+
+const (
+	// Market order completed by the best available price.
+	Market OrderType = "MARKET"
+	// Limit order completed by the limit price or better.
+	Limit OrderType = "LIMIT"
+	// Stop order which becomes market order when stop price is reached.
+	Stop OrderType = "STOP"
+	// StopLimit order which becomes limit order when stop price is reached.
+	StopLimit OrderType = "STOP_LIMIT"
+)
+
+// Bar OHLCV bar of a ticker.
+type Bar struct {
+	Ticker   string
+	Time     time.Time
+	Interval time.Duration
+	Open     decimal.Decimal
+	High     decimal.Decimal
+	Low      decimal.Decimal
+	Close    decimal.Decimal
+	Volume   int32
+}
+
+// Fill execution of a strategy order. Partial marks that the rest of the order is still open.
+// Fill with zero amount reports that the order is closed without execution.
+type Fill struct {
+	DealID  int64
+	Ticker  string
+	Side    Side
+	Amount  int32
+	Price   decimal.Decimal
+	Partial bool
+	Time    time.Time
+}
+
+// Order intent of a strategy.
+type Order struct {
+	Ticker    string
+	Side      Side
+	Type      OrderType
+	Amount    int32
+	Price     decimal.Decimal
+	StopPrice decimal.Decimal
+}
+
+// Strategy trading strategy. It receives closed bars and fills and returns orders to create.
+type Strategy interface {
+	Name() string
+	OnBar(bar Bar) []Order
+	OnFill(fill Fill) []Order
+}
+
+// New creates strategy by config.
+func New(cfg config.Strategy) (Strategy, error) {
+	switch cfg.Name {
+	case "", buyAndHoldName:
+		return NewBuyAndHold(cfg.Amount), nil
+	case smaCrossoverName:
+		return NewSMACrossover(cfg.Amount, cfg.Fast, cfg.Slow), nil
+	case meanReversionName:
+		return NewMeanReversion(cfg.Amount, cfg.Window, cfg.Threshold), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", cfg.Name)
+	}
+}
+
+// Position of a strategy in a ticker with a flag of an open order.
+type position struct {
+	amount  int32
+	pending bool
+}
+
+// Positions of a strategy by tickers.
+type positions map[string]position
+
+// Marks that an order of a ticker is open.
+func (p positions) open(ticker string) {
+	pos := p[ticker]
+	pos.pending = true
+	p[ticker] = pos
+}
+
+// Applies fill to position.
+func (p positions) fill(fill Fill) {
+	pos := p[fill.Ticker]
+
+	if fill.Side == Buy {
+		pos.amount += fill.Amount
+	} else {
+		pos.amount -= fill.Amount
+	}
+
+	pos.pending = fill.Partial
+	p[fill.Ticker] = pos
+}